@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/momentohq/client-sdk-go/internal/momentoerrors"
@@ -56,6 +57,24 @@ type CredentialProvider interface {
 	WithEndpoints(endpoints AllEndpoints) (CredentialProvider, error)
 }
 
+// ExpiringCredentialProvider is a CredentialProvider that knows when its auth token expires. The providers
+// of this package implement it; use ExpiresAt to ask any CredentialProvider.
+type ExpiringCredentialProvider interface {
+	CredentialProvider
+	// ExpiresAt returns when the auth token expires, or the zero time if it does not expire or its expiry
+	// is unknown. It is decoded from the token without network access.
+	ExpiresAt() time.Time
+}
+
+// ExpiresAt returns when the auth token of provider expires, or the zero time if it does not expire or
+// provider does not implement ExpiringCredentialProvider.
+func ExpiresAt(provider CredentialProvider) time.Time {
+	if expiring, ok := provider.(ExpiringCredentialProvider); ok {
+		return expiring.ExpiresAt()
+	}
+	return time.Time{}
+}
+
 type defaultCredentialProvider struct {
 	authToken       string
 	controlEndpoint Endpoint
@@ -71,6 +90,11 @@ func (credentialProvider defaultCredentialProvider) GetAuthToken() string {
 	return credentialProvider.authToken
 }
 
+// ExpiresAt returns when the auth token expires, or the zero time if it does not expire.
+func (credentialProvider defaultCredentialProvider) ExpiresAt() time.Time {
+	return tokenExpiry(credentialProvider.authToken)
+}
+
 // GetCacheTlsHostname returns the TLS hostname for the cache endpoint.
 func (credentialProvider defaultCredentialProvider) GetCacheTlsHostname() string {
 	return credentialProvider.cacheTlsHostname
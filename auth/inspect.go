@@ -0,0 +1,144 @@
+package auth
+
+import (
+	b64 "encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/momentohq/client-sdk-go/internal/momentoerrors"
+)
+
+// TokenVersion is the format of an API key or disposable token.
+type TokenVersion string
+
+const (
+	// TokenVersionLegacyJwt is a JWT that carries the control and cache endpoints in its claims.
+	TokenVersionLegacyJwt TokenVersion = "legacy-jwt"
+	// TokenVersionV1 is a base64 encoded JSON object holding a JWT and the base endpoint. API keys and
+	// disposable tokens issued by the auth client have this format.
+	TokenVersionV1 TokenVersion = "v1"
+	// TokenVersionV2 is a JWT that carries no endpoints; they are supplied separately.
+	TokenVersionV2 TokenVersion = "v2"
+)
+
+// permissionsClaim is the JWT claim that holds the permissions encoded in a token.
+const permissionsClaim = "p"
+
+// TokenInfo describes an API key or disposable token. It is decoded locally, without checking the token's
+// signature or whether it has been revoked, so it must not be used to make authorization decisions.
+type TokenInfo struct {
+	Version TokenVersion
+	Issuer  string
+	Subject string
+	// Id identifies the token, if it carries an id.
+	Id       string
+	IssuedAt time.Time
+	// ExpiresAt is the zero time if the token does not expire.
+	ExpiresAt time.Time
+	// Endpoints holds the host names of the endpoints encoded in the token. Endpoints the token does not
+	// encode, including all of those of v2 API keys, are empty.
+	Endpoints AllEndpoints
+	// Permissions holds the permission scopes encoded in the token as JSON, or nil if it carries none.
+	Permissions json.RawMessage
+}
+
+// DoesExpire returns true if the token has an expiry.
+func (i TokenInfo) DoesExpire() bool {
+	return !i.ExpiresAt.IsZero()
+}
+
+// IsExpired returns true if the token expired before now.
+func (i TokenInfo) IsExpired(now time.Time) bool {
+	return i.DoesExpire() && now.After(i.ExpiresAt)
+}
+
+func (i TokenInfo) String() string {
+	expiresAt := "never"
+	if i.DoesExpire() {
+		expiresAt = i.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf(
+		"TokenInfo{Version=%s, Issuer=%s, Subject=%s, Id=%s, ExpiresAt=%s, CacheEndpoint=%s}",
+		i.Version, i.Issuer, i.Subject, i.Id, expiresAt, i.Endpoints.CacheEndpoint.Endpoint,
+	)
+}
+
+// Inspect decodes an API key or disposable token, in any of the formats accepted by the credential providers.
+// It makes no network requests.
+func Inspect(token string) (*TokenInfo, error) {
+	version := TokenVersionLegacyJwt
+	jwtToken := token
+	var endpoints AllEndpoints
+	if decoded, err := b64.StdEncoding.DecodeString(token); err == nil {
+		tokenAndEndpoints, err := processV1Token(decoded)
+		if err != nil {
+			return nil, err
+		}
+		version = TokenVersionV1
+		jwtToken = tokenAndEndpoints.AuthToken
+		endpoints = tokenAndEndpoints.Endpoints
+	}
+
+	parsed, _, err := new(jwt.Parser).ParseUnverified(jwtToken, jwt.MapClaims{})
+	if err != nil {
+		return nil, momentoerrors.NewMomentoSvcErr(momentoerrors.InvalidArgumentError, "Could not parse auth token.", err)
+	}
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, momentoerrors.NewMomentoSvcErr(momentoerrors.InvalidArgumentError, "Invalid Auth token.", nil)
+	}
+
+	info := &TokenInfo{
+		Version:   version,
+		Issuer:    stringClaim(claims, "iss"),
+		Subject:   stringClaim(claims, "sub"),
+		Id:        stringClaim(claims, "jti"),
+		IssuedAt:  timeClaim(claims, "iat"),
+		ExpiresAt: timeClaim(claims, "exp"),
+		Endpoints: endpoints,
+	}
+	if version == TokenVersionLegacyJwt {
+		if stringClaim(claims, "t") == "g" {
+			info.Version = TokenVersionV2
+		} else {
+			info.Endpoints = AllEndpoints{
+				ControlEndpoint: Endpoint{Endpoint: stringClaim(claims, "cp")},
+				CacheEndpoint:   Endpoint{Endpoint: stringClaim(claims, "c")},
+			}
+		}
+	}
+	if permissions, ok := claims[permissionsClaim]; ok {
+		if info.Permissions, err = json.Marshal(permissions); err != nil {
+			return nil, momentoerrors.NewMomentoSvcErr(momentoerrors.InvalidArgumentError, "Could not decode token permissions.", err)
+		}
+	}
+	return info, nil
+}
+
+// tokenExpiry returns the expiry of a token, or the zero time if it does not expire or cannot be decoded.
+func tokenExpiry(token string) time.Time {
+	info, err := Inspect(token)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ExpiresAt
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+func timeClaim(claims jwt.MapClaims, name string) time.Time {
+	switch value := claims[name].(type) {
+	case float64:
+		return time.Unix(int64(value), 0)
+	case json.Number:
+		if seconds, err := value.Int64(); err == nil {
+			return time.Unix(seconds, 0)
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,66 @@
+package auth_test
+
+import (
+	"time"
+
+	"github.com/momentohq/client-sdk-go/auth"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("auth inspect", func() {
+	It("decodes a v1 API key", func() {
+		info, err := auth.Inspect(testV1AuthToken)
+		Expect(err).To(BeNil())
+		Expect(info.Version).To(Equal(auth.TokenVersionV1))
+		Expect(info.Issuer).To(Equal("Online JWT Builder"))
+		Expect(info.Subject).To(Equal("jrocket@example.com"))
+		Expect(info.IssuedAt).To(Equal(time.Unix(1678305812, 0)))
+		Expect(info.ExpiresAt).To(Equal(time.Unix(4865515412, 0)))
+		Expect(info.DoesExpire()).To(BeTrue())
+		Expect(info.IsExpired(time.Now())).To(BeFalse())
+		Expect(info.Endpoints.CacheEndpoint.Endpoint).To(Equal("cache.test.momentohq.com"))
+		Expect(info.Endpoints.ControlEndpoint.Endpoint).To(Equal("control.test.momentohq.com"))
+		Expect(info.Permissions).To(BeNil())
+	})
+
+	It("decodes a legacy JWT API key", func() {
+		info, err := auth.Inspect(testPreV1ApiKey)
+		Expect(err).To(BeNil())
+		Expect(info.Version).To(Equal(auth.TokenVersionLegacyJwt))
+		Expect(info.Subject).To(Equal("user@test.com"))
+		Expect(info.DoesExpire()).To(BeFalse())
+		Expect(info.Endpoints.CacheEndpoint.Endpoint).To(Equal("cache.test.com"))
+		Expect(info.Endpoints.ControlEndpoint.Endpoint).To(Equal("control.test.com"))
+	})
+
+	It("decodes a v2 API key", func() {
+		info, err := auth.Inspect(testV2ApiKey)
+		Expect(err).To(BeNil())
+		Expect(info.Version).To(Equal(auth.TokenVersionV2))
+		Expect(info.Id).To(Equal("some-id"))
+		Expect(info.Endpoints.CacheEndpoint.Endpoint).To(BeEmpty())
+	})
+
+	It("rejects a malformed token", func() {
+		_, err := auth.Inspect("not-a-token")
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("reports the expiry from credential providers", func() {
+		credentialProvider, err := auth.FromString(testV1AuthToken)
+		Expect(err).To(BeNil())
+		Expect(auth.ExpiresAt(credentialProvider)).To(Equal(time.Unix(4865515412, 0)))
+
+		rotating := auth.NewRotatingCredentialProvider(credentialProvider)
+		Expect(rotating.ExpiresAt()).To(Equal(time.Unix(4865515412, 0)))
+		rotating.Rotate(testPreV1ApiKey)
+		Expect(rotating.ExpiresAt().IsZero()).To(BeTrue())
+	})
+
+	It("reports no expiry for credential providers that cannot tell", func() {
+		credentialProvider, err := auth.FromString(testV1AuthToken)
+		Expect(err).To(BeNil())
+		Expect(auth.ExpiresAt(struct{ auth.CredentialProvider }{credentialProvider}).IsZero()).To(BeTrue())
+	})
+})
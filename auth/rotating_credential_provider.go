@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/internal/momentoerrors"
+)
+
+// UpdatableCredentialProvider is a CredentialProvider whose auth token may change while clients are using it.
+// Clients read the token for every request, so an update takes effect without recreating them.
+type UpdatableCredentialProvider interface {
+	CredentialProvider
+	// OnUpdate registers a callback that is called with the updated provider after each change of auth
+	// token. It returns a function that removes the callback.
+	OnUpdate(callback func(provider CredentialProvider)) func()
+}
+
+// rotatingState is shared by a RotatingCredentialProvider and the providers derived from it with
+// WithEndpoints, so that a rotation reaches every client created with any of them.
+type rotatingState struct {
+	authToken    atomic.Value
+	mutex        sync.Mutex
+	nextCallback int
+	callbacks    map[int]func(provider CredentialProvider)
+}
+
+// RotatingCredentialProvider is a CredentialProvider whose auth token can be replaced at any time, for
+// example when an API key is rotated. Existing connections and topic subscriptions are kept: the new token
+// is sent with every request made after the rotation, and with every subscription made or resumed after it.
+// The endpoints are those of the provider the RotatingCredentialProvider was created with and never change.
+type RotatingCredentialProvider struct {
+	endpoints CredentialProvider
+	state     *rotatingState
+}
+
+// NewRotatingCredentialProvider returns a RotatingCredentialProvider that starts with the auth token and
+// endpoints of initial.
+// Example usage:
+//
+//	credentialProvider := auth.NewRotatingCredentialProvider(initialProvider)
+//	client, err := momento.NewCacheClient(config.LaptopLatest(), credentialProvider, 60*time.Second)
+//	...
+//	err = credentialProvider.Rotate(newApiKey)
+func NewRotatingCredentialProvider(initial CredentialProvider) *RotatingCredentialProvider {
+	state := &rotatingState{
+		callbacks: make(map[int]func(provider CredentialProvider)),
+	}
+	state.authToken.Store(initial.GetAuthToken())
+	return &RotatingCredentialProvider{endpoints: initial, state: state}
+}
+
+// Rotate replaces the auth token. The token is not decoded, so it must be valid for the endpoints of this
+// provider.
+func (p *RotatingCredentialProvider) Rotate(authToken string) error {
+	if authToken == "" {
+		return momentoerrors.NewMomentoSvcErr(momentoerrors.InvalidArgumentError, "auth token cannot be empty", nil)
+	}
+	p.state.authToken.Store(authToken)
+	p.notify()
+	return nil
+}
+
+// Update replaces the auth token with the one of provider. It returns an error, and keeps the current
+// token, if provider is for a different cache endpoint, as the existing connections cannot be moved to it.
+// Use Rotate instead if the endpoints of this provider were overridden.
+func (p *RotatingCredentialProvider) Update(provider CredentialProvider) error {
+	if provider.GetCacheEndpoint() != p.GetCacheEndpoint() {
+		return momentoerrors.NewMomentoSvcErr(
+			momentoerrors.InvalidArgumentError,
+			fmt.Sprintf(
+				"cannot update credentials for cache endpoint %s with credentials for %s",
+				p.GetCacheEndpoint(), provider.GetCacheEndpoint(),
+			),
+			nil,
+		)
+	}
+	return p.Rotate(provider.GetAuthToken())
+}
+
+func (p *RotatingCredentialProvider) OnUpdate(callback func(provider CredentialProvider)) func() {
+	p.state.mutex.Lock()
+	defer p.state.mutex.Unlock()
+	id := p.state.nextCallback
+	p.state.nextCallback++
+	p.state.callbacks[id] = callback
+	return func() {
+		p.state.mutex.Lock()
+		defer p.state.mutex.Unlock()
+		delete(p.state.callbacks, id)
+	}
+}
+
+func (p *RotatingCredentialProvider) notify() {
+	p.state.mutex.Lock()
+	callbacks := make([]func(provider CredentialProvider), 0, len(p.state.callbacks))
+	for _, callback := range p.state.callbacks {
+		callbacks = append(callbacks, callback)
+	}
+	p.state.mutex.Unlock()
+	for _, callback := range callbacks {
+		callback(p)
+	}
+}
+
+// GetAuthToken returns the current auth token.
+func (p *RotatingCredentialProvider) GetAuthToken() string {
+	return p.state.authToken.Load().(string)
+}
+
+// ExpiresAt returns when the current auth token expires, or the zero time if it does not expire.
+func (p *RotatingCredentialProvider) ExpiresAt() time.Time {
+	return tokenExpiry(p.GetAuthToken())
+}
+
+func (p *RotatingCredentialProvider) GetCacheTlsHostname() string {
+	return p.endpoints.GetCacheTlsHostname()
+}
+
+func (p *RotatingCredentialProvider) GetControlEndpoint() string {
+	return p.endpoints.GetControlEndpoint()
+}
+
+func (p *RotatingCredentialProvider) IsControlEndpointSecure() bool {
+	return p.endpoints.IsControlEndpointSecure()
+}
+
+func (p *RotatingCredentialProvider) GetCacheEndpoint() string {
+	return p.endpoints.GetCacheEndpoint()
+}
+
+func (p *RotatingCredentialProvider) IsCacheEndpointSecure() bool {
+	return p.endpoints.IsCacheEndpointSecure()
+}
+
+func (p *RotatingCredentialProvider) GetTokenEndpoint() string {
+	return p.endpoints.GetTokenEndpoint()
+}
+
+func (p *RotatingCredentialProvider) IsTokenEndpointSecure() bool {
+	return p.endpoints.IsTokenEndpointSecure()
+}
+
+func (p *RotatingCredentialProvider) GetStorageEndpoint() string {
+	return p.endpoints.GetStorageEndpoint()
+}
+
+func (p *RotatingCredentialProvider) IsStorageEndpointSecure() bool {
+	return p.endpoints.IsStorageEndpointSecure()
+}
+
+// WithEndpoints returns a provider with the given endpoints that shares this provider's auth token, so that
+// rotating either of them rotates both.
+func (p *RotatingCredentialProvider) WithEndpoints(endpoints AllEndpoints) (CredentialProvider, error) {
+	withEndpoints, err := p.endpoints.WithEndpoints(endpoints)
+	if err != nil {
+		return nil, err
+	}
+	return &RotatingCredentialProvider{endpoints: withEndpoints, state: p.state}, nil
+}
+
+func (p *RotatingCredentialProvider) String() string {
+	return fmt.Sprintf("RotatingCredentialProvider{endpoints=%v}", p.endpoints)
+}
@@ -0,0 +1,65 @@
+package auth_test
+
+import (
+	"github.com/momentohq/client-sdk-go/auth"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("auth rotating-credential-provider", func() {
+	var initial auth.CredentialProvider
+
+	BeforeEach(func() {
+		var err error
+		initial, err = auth.NewStringMomentoTokenProvider(testV1AuthToken)
+		Expect(err).To(BeNil())
+	})
+
+	It("rotates the auth token and keeps the endpoints", func() {
+		provider := auth.NewRotatingCredentialProvider(initial)
+		Expect(provider.GetAuthToken()).To(Equal(testV1ApiKey))
+
+		Expect(provider.Rotate("new-token")).To(Succeed())
+		Expect(provider.GetAuthToken()).To(Equal("new-token"))
+		Expect(provider.GetCacheEndpoint()).To(Equal(initial.GetCacheEndpoint()))
+		Expect(provider.GetControlEndpoint()).To(Equal(initial.GetControlEndpoint()))
+
+		Expect(provider.Rotate("")).ToNot(Succeed())
+		Expect(provider.GetAuthToken()).To(Equal("new-token"))
+	})
+
+	It("notifies callbacks until they are removed", func() {
+		provider := auth.NewRotatingCredentialProvider(initial)
+		var tokens []string
+		remove := provider.OnUpdate(func(updated auth.CredentialProvider) {
+			tokens = append(tokens, updated.GetAuthToken())
+		})
+
+		Expect(provider.Rotate("first")).To(Succeed())
+		remove()
+		Expect(provider.Rotate("second")).To(Succeed())
+		Expect(tokens).To(Equal([]string{"first"}))
+	})
+
+	It("rejects updates for a different cache endpoint", func() {
+		provider := auth.NewRotatingCredentialProvider(initial)
+		other, err := auth.NewMomentoLocalProvider(&auth.MomentoLocalConfig{})
+		Expect(err).To(BeNil())
+		Expect(provider.Update(other)).ToNot(Succeed())
+		Expect(provider.GetAuthToken()).To(Equal(testV1ApiKey))
+
+		Expect(provider.Update(initial)).To(Succeed())
+	})
+
+	It("shares rotations with providers derived with WithEndpoints", func() {
+		provider := auth.NewRotatingCredentialProvider(initial)
+		derived, err := provider.WithEndpoints(auth.AllEndpoints{
+			CacheEndpoint: auth.Endpoint{Endpoint: "cache.example.com:443"},
+		})
+		Expect(err).To(BeNil())
+		Expect(derived.GetCacheEndpoint()).To(Equal("cache.example.com:443"))
+
+		Expect(provider.Rotate("rotated")).To(Succeed())
+		Expect(derived.GetAuthToken()).To(Equal("rotated"))
+	})
+})
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/internal/momentoerrors"
+)
+
+const (
+	defaultSecretPollInterval = 10 * time.Second
+	defaultSecretFetchTimeout = 30 * time.Second
+)
+
+// SecretSource fetches an API key from wherever it is stored, such as a file or a secrets manager. Implement
+// it to load keys from a store the SDK does not support directly.
+type SecretSource interface {
+	FetchSecret(ctx context.Context) (string, error)
+}
+
+// SecretSourceFunc adapts a function to a SecretSource.
+type SecretSourceFunc func(ctx context.Context) (string, error)
+
+func (f SecretSourceFunc) FetchSecret(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+type fileSecretSource struct {
+	path string
+}
+
+// NewFileSecretSource returns a SecretSource that reads an API key from the file at path. Leading and trailing
+// whitespace is ignored.
+func NewFileSecretSource(path string) SecretSource {
+	return &fileSecretSource{path: path}
+}
+
+func (s *fileSecretSource) FetchSecret(_ context.Context) (string, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (s *fileSecretSource) String() string {
+	return fmt.Sprintf("FileSecretSource{path=%s}", s.path)
+}
+
+type execSecretSource struct {
+	name string
+	args []string
+}
+
+// NewExecSecretSource returns a SecretSource that runs a command and reads an API key from its standard
+// output, ignoring leading and trailing whitespace. The command is run without a shell. It fails if the
+// command exits with a non-zero status.
+// Example usage:
+//
+//	source := auth.NewExecSecretSource("vault", "kv", "get", "-field=api_key", "secret/momento")
+func NewExecSecretSource(name string, args ...string) SecretSource {
+	return &execSecretSource{name: name, args: args}
+}
+
+func (s *execSecretSource) FetchSecret(ctx context.Context) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.name, s.args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("secret command %s failed: %w: %s", s.name, err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func (s *execSecretSource) String() string {
+	return fmt.Sprintf("ExecSecretSource{command=%s}", s.name)
+}
+
+type SecretSourceProps struct {
+	// Source provides the API key.
+	Source SecretSource
+	// Endpoint is the Momento service endpoint for v2 API keys. Leave it empty for legacy keys, which
+	// include their endpoints.
+	Endpoint string
+	// PollInterval is how often the source is checked for a new key. Defaults to 10 seconds.
+	PollInterval time.Duration
+	// OnReloadError, if set, is called when the source cannot be read or provides an invalid key. The
+	// previous key stays in use.
+	OnReloadError func(err error)
+}
+
+// ReloadingCredentialProvider is a CredentialProvider that reloads its API key from a SecretSource whenever
+// the key changes. Clients created with it use each new key without being recreated. Close stops the reloads.
+type ReloadingCredentialProvider struct {
+	*RotatingCredentialProvider
+	props SecretSourceProps
+	// mutex serializes reloads and guards secret.
+	mutex     sync.Mutex
+	secret    string
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// FromSecretSource returns a ReloadingCredentialProvider that loads its API key from props.Source. The first
+// key is loaded before it returns.
+func FromSecretSource(props SecretSourceProps) (*ReloadingCredentialProvider, error) {
+	if props.Source == nil {
+		return nil, momentoerrors.NewMomentoSvcErr(momentoerrors.InvalidArgumentError, "secret source is required", nil)
+	}
+	if props.PollInterval <= 0 {
+		props.PollInterval = defaultSecretPollInterval
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultSecretFetchTimeout)
+	defer cancel()
+	secret, provider, err := loadSecret(ctx, props)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &ReloadingCredentialProvider{
+		RotatingCredentialProvider: NewRotatingCredentialProvider(provider),
+		props:                      props,
+		secret:                     secret,
+		stop:                       make(chan struct{}),
+		done:                       make(chan struct{}),
+	}
+	go p.run()
+	return p, nil
+}
+
+// FromFile returns a ReloadingCredentialProvider that loads a legacy API key from the file at path and
+// reloads it when the file changes, such as a Kubernetes secret mounted as a file.
+func FromFile(path string) (*ReloadingCredentialProvider, error) {
+	return FromSecretSource(SecretSourceProps{Source: NewFileSecretSource(path)})
+}
+
+// Reload loads the key from the source immediately, and rotates to it if it has changed.
+func (p *ReloadingCredentialProvider) Reload(ctx context.Context) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	secret, provider, err := loadSecret(ctx, p.props)
+	if err != nil {
+		return err
+	}
+	if secret == p.secret {
+		return nil
+	}
+	if err := p.Update(provider); err != nil {
+		return err
+	}
+	p.secret = secret
+	return nil
+}
+
+// Close stops reloading the key.
+func (p *ReloadingCredentialProvider) Close() {
+	p.closeOnce.Do(func() {
+		close(p.stop)
+		<-p.done
+	})
+}
+
+func (p *ReloadingCredentialProvider) run() {
+	defer close(p.done)
+	ticker := time.NewTicker(p.props.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-p.stop:
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), defaultSecretFetchTimeout)
+		err := p.Reload(ctx)
+		cancel()
+		if err != nil && p.props.OnReloadError != nil {
+			p.props.OnReloadError(err)
+		}
+	}
+}
+
+// loadSecret fetches a key from the source of props and returns it with a provider for it.
+func loadSecret(ctx context.Context, props SecretSourceProps) (string, CredentialProvider, error) {
+	secret, err := props.Source.FetchSecret(ctx)
+	if err != nil {
+		return "", nil, momentoerrors.NewMomentoSvcErr(momentoerrors.InvalidArgumentError, "failed to fetch API key from secret source", err)
+	}
+	var provider CredentialProvider
+	if props.Endpoint != "" {
+		provider, err = NewApiKeyV2TokenProvider(ApiKeyV2Props{ApiKey: secret, Endpoint: props.Endpoint})
+	} else {
+		provider, err = NewStringMomentoTokenProvider(secret)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return secret, provider, nil
+}
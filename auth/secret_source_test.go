@@ -0,0 +1,77 @@
+package auth_test
+
+import (
+	"context"
+	b64 "encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/auth"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("auth secret-source", func() {
+	It("reloads a key file when it changes", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "api-key")
+		Expect(os.WriteFile(path, []byte(testV1AuthToken+"\n"), 0o600)).To(Succeed())
+
+		provider, err := auth.FromSecretSource(auth.SecretSourceProps{
+			Source:       auth.NewFileSecretSource(path),
+			PollInterval: 10 * time.Millisecond,
+		})
+		Expect(err).To(BeNil())
+		defer provider.Close()
+		Expect(provider.GetAuthToken()).To(Equal(testV1ApiKey))
+		Expect(provider.GetCacheEndpoint()).To(Equal("cache.test.momentohq.com:443"))
+
+		updated := make(chan string, 1)
+		provider.OnUpdate(func(p auth.CredentialProvider) {
+			updated <- p.GetAuthToken()
+		})
+		rotatedKey := b64.StdEncoding.EncodeToString([]byte(`{"api_key": "rotated", "endpoint": "test.momentohq.com"}`))
+		Expect(os.WriteFile(path, []byte(rotatedKey), 0o600)).To(Succeed())
+		Eventually(updated).Should(Receive(Equal("rotated")))
+	})
+
+	It("keeps the current key when the source fails", func() {
+		var reloadErrors []error
+		fail := false
+		source := auth.SecretSourceFunc(func(ctx context.Context) (string, error) {
+			if fail {
+				return "", errors.New("unavailable")
+			}
+			return testV1AuthToken, nil
+		})
+		provider, err := auth.FromSecretSource(auth.SecretSourceProps{
+			Source:        source,
+			PollInterval:  time.Hour,
+			OnReloadError: func(err error) { reloadErrors = append(reloadErrors, err) },
+		})
+		Expect(err).To(BeNil())
+		defer provider.Close()
+
+		fail = true
+		Expect(provider.Reload(context.Background())).ToNot(Succeed())
+		Expect(provider.GetAuthToken()).To(Equal(testV1ApiKey))
+	})
+
+	It("reads keys from a command", func() {
+		source := auth.NewExecSecretSource("echo", testV1AuthToken)
+		secret, err := source.FetchSecret(context.Background())
+		Expect(err).To(BeNil())
+		Expect(secret).To(Equal(testV1AuthToken))
+
+		_, err = auth.NewExecSecretSource("false").FetchSecret(context.Background())
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("fails when the first key is invalid", func() {
+		_, err := auth.FromSecretSource(auth.SecretSourceProps{
+			Source: auth.SecretSourceFunc(func(ctx context.Context) (string, error) { return "invalid", nil }),
+		})
+		Expect(err).ToNot(BeNil())
+	})
+})
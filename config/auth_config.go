@@ -5,25 +5,55 @@ import (
 )
 
 type authConfiguration struct {
-	loggerFactory logger.MomentoLoggerFactory
+	loggerFactory     logger.MomentoLoggerFactory
+	transportStrategy TransportStrategy
 }
 
 type AuthConfigurationProps struct {
 	// LoggerFactory represents a type used to configure the Momento logging system.
 	LoggerFactory logger.MomentoLoggerFactory
+
+	// TransportStrategy configures the connections to the auth and token endpoints, such as TLS, proxy and
+	// dialer settings. Defaults to a transport strategy with the default gRPC configuration.
+	TransportStrategy TransportStrategy
 }
 
 type AuthConfiguration interface {
 	// GetLoggerFactory Returns the current configuration options for logging verbosity and format
 	GetLoggerFactory() logger.MomentoLoggerFactory
+
+	// GetTransportStrategy Returns the current configuration options for wire interactions with the Momento service
+	GetTransportStrategy() TransportStrategy
+
+	// WithTransportStrategy Copy constructor for overriding TransportStrategy returns a new AuthConfiguration
+	// with the specified TransportStrategy
+	WithTransportStrategy(transportStrategy TransportStrategy) AuthConfiguration
 }
 
 func NewAuthConfiguration(props *AuthConfigurationProps) AuthConfiguration {
+	transportStrategy := props.TransportStrategy
+	if transportStrategy == nil {
+		transportStrategy = NewStaticTransportStrategy(&TransportStrategyProps{
+			GrpcConfiguration: NewStaticGrpcConfiguration(&GrpcConfigurationProps{}),
+		})
+	}
 	return &authConfiguration{
-		loggerFactory: props.LoggerFactory,
+		loggerFactory:     props.LoggerFactory,
+		transportStrategy: transportStrategy,
 	}
 }
 
 func (s *authConfiguration) GetLoggerFactory() logger.MomentoLoggerFactory {
 	return s.loggerFactory
 }
+
+func (s *authConfiguration) GetTransportStrategy() TransportStrategy {
+	return s.transportStrategy
+}
+
+func (s *authConfiguration) WithTransportStrategy(transportStrategy TransportStrategy) AuthConfiguration {
+	return &authConfiguration{
+		loggerFactory:     s.loggerFactory,
+		transportStrategy: transportStrategy,
+	}
+}
@@ -0,0 +1,131 @@
+package batching
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWindow       = time.Millisecond
+	defaultMaxBatchSize = 100
+)
+
+// Trigger is the reason a batch was sent.
+type Trigger string
+
+const (
+	// TriggerWindow means the batch was sent because its window elapsed.
+	TriggerWindow Trigger = "window"
+	// TriggerSize means the batch was sent because it reached the maximum batch size.
+	TriggerSize Trigger = "size"
+)
+
+// Props configures automatic batching of Get requests. Get requests to the same cache that arrive within
+// the window are sent together as a single GetBatch request, and each caller receives the response for its
+// own key. The zero value of each field selects its default.
+type Props struct {
+	// Window is how long a batch collects Get requests after the first one arrives. Defaults to 1ms.
+	Window time.Duration
+	// MaxBatchSize is the number of distinct keys at which a batch is sent without waiting for the window
+	// to elapse. Defaults to 100.
+	MaxBatchSize int
+	// Partition, if set, returns a value from the context of a Get request, and only requests with the same
+	// value are batched together. A batch is sent with the values of the context of its first request, so
+	// batching is disabled when middleware reads per-request values from the context, such as a namespace,
+	// unless a partition separates the requests whose values differ.
+	Partition func(ctx context.Context) string
+	// OnBatch, if set, is called after each batch completes. It is called synchronously by the goroutine
+	// that sent the batch, so it should return quickly. See Metrics for a ready-made collector.
+	OnBatch func(batch Batch)
+}
+
+func (p Props) WithDefaults() Props {
+	if p.Window <= 0 {
+		p.Window = defaultWindow
+	}
+	if p.MaxBatchSize <= 0 {
+		p.MaxBatchSize = defaultMaxBatchSize
+	}
+	return p
+}
+
+func (p Props) String() string {
+	return fmt.Sprintf("AutoBatchingProps{Window: %s, MaxBatchSize: %d}", p.Window, p.MaxBatchSize)
+}
+
+// Batch describes a GetBatch request sent on behalf of batched Get requests.
+type Batch struct {
+	CacheName string
+	// Keys is the number of distinct keys in the batch.
+	Keys int
+	// Requests is the number of Get requests the batch answered. It is greater than Keys when several
+	// requests were for the same key.
+	Requests int
+	Trigger  Trigger
+	Latency  time.Duration
+	// Err is the error the batch failed with, if any.
+	Err error
+}
+
+// Metrics collects statistics about batch sizes. Its Record method can be used as Props.OnBatch. It is safe
+// for concurrent use.
+type Metrics struct {
+	mutex    sync.Mutex
+	snapshot MetricsSnapshot
+}
+
+// MetricsSnapshot is a point in time copy of the statistics collected by Metrics.
+type MetricsSnapshot struct {
+	Batches  uint64
+	Keys     uint64
+	Requests uint64
+	Errors   uint64
+	// MaxBatchSize is the largest number of distinct keys sent in one batch.
+	MaxBatchSize int
+	// SizeTriggered and WindowTriggered count the batches sent for each Trigger.
+	SizeTriggered   uint64
+	WindowTriggered uint64
+}
+
+// MeanBatchSize returns the mean number of distinct keys per batch.
+func (s MetricsSnapshot) MeanBatchSize() float64 {
+	if s.Batches == 0 {
+		return 0
+	}
+	return float64(s.Keys) / float64(s.Batches)
+}
+
+// NewMetrics returns an empty Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// Record adds a batch to the statistics.
+func (m *Metrics) Record(batch Batch) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.snapshot.Batches++
+	m.snapshot.Keys += uint64(batch.Keys)
+	m.snapshot.Requests += uint64(batch.Requests)
+	if batch.Err != nil {
+		m.snapshot.Errors++
+	}
+	if batch.Keys > m.snapshot.MaxBatchSize {
+		m.snapshot.MaxBatchSize = batch.Keys
+	}
+	switch batch.Trigger {
+	case TriggerSize:
+		m.snapshot.SizeTriggered++
+	case TriggerWindow:
+		m.snapshot.WindowTriggered++
+	}
+}
+
+// Snapshot returns the statistics collected so far.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.snapshot
+}
@@ -0,0 +1,41 @@
+package batching_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config/batching"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("batching", func() {
+	It("applies defaults", func() {
+		props := batching.Props{}.WithDefaults()
+		Expect(props.Window).To(Equal(time.Millisecond))
+		Expect(props.MaxBatchSize).To(Equal(100))
+
+		props = batching.Props{Window: time.Second, MaxBatchSize: 5}.WithDefaults()
+		Expect(props.Window).To(Equal(time.Second))
+		Expect(props.MaxBatchSize).To(Equal(5))
+	})
+
+	It("collects batch size metrics", func() {
+		metrics := batching.NewMetrics()
+		Expect(metrics.Snapshot().MeanBatchSize()).To(Equal(0.0))
+
+		metrics.Record(batching.Batch{CacheName: "cache", Keys: 2, Requests: 3, Trigger: batching.TriggerWindow})
+		metrics.Record(batching.Batch{CacheName: "cache", Keys: 10, Requests: 10, Trigger: batching.TriggerSize})
+		metrics.Record(batching.Batch{CacheName: "cache", Keys: 3, Requests: 3, Trigger: batching.TriggerWindow, Err: errors.New("failed")})
+
+		snapshot := metrics.Snapshot()
+		Expect(snapshot.Batches).To(Equal(uint64(3)))
+		Expect(snapshot.Keys).To(Equal(uint64(15)))
+		Expect(snapshot.Requests).To(Equal(uint64(16)))
+		Expect(snapshot.Errors).To(Equal(uint64(1)))
+		Expect(snapshot.MaxBatchSize).To(Equal(10))
+		Expect(snapshot.SizeTriggered).To(Equal(uint64(1)))
+		Expect(snapshot.WindowTriggered).To(Equal(uint64(2)))
+		Expect(snapshot.MeanBatchSize()).To(Equal(5.0))
+	})
+})
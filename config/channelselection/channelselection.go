@@ -0,0 +1,157 @@
+package channelselection
+
+import (
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultTargetInFlightPerChannel = 50
+	defaultStallTimeout             = 10 * time.Second
+	defaultCheckInterval            = time.Second
+)
+
+// Channel is a gRPC channel a request can be sent on.
+type Channel interface {
+	// InFlight returns the number of requests in flight on the channel.
+	InFlight() int64
+}
+
+// Selector chooses the channel each request is sent on. Channels in TRANSIENT_FAILURE are removed before
+// Select is called, unless all of them are. Implementations must be safe for concurrent use.
+type Selector interface {
+	// Select returns the index of the channel to send the next request on. channels is never empty.
+	Select(channels []Channel) int
+}
+
+type roundRobinSelector struct {
+	next atomic.Uint64
+}
+
+// NewRoundRobinSelector returns a Selector that cycles through the channels in order. It is the default.
+func NewRoundRobinSelector() Selector {
+	return &roundRobinSelector{}
+}
+
+func (s *roundRobinSelector) Select(channels []Channel) int {
+	return int(s.next.Add(1) % uint64(len(channels)))
+}
+
+func (s *roundRobinSelector) String() string {
+	return "RoundRobin"
+}
+
+type leastInFlightSelector struct {
+	next atomic.Uint64
+}
+
+// NewLeastInFlightSelector returns a Selector that picks the channel with the fewest requests in flight.
+// Ties are broken in round-robin order, so idle channels share the load.
+func NewLeastInFlightSelector() Selector {
+	return &leastInFlightSelector{}
+}
+
+func (s *leastInFlightSelector) Select(channels []Channel) int {
+	start := int(s.next.Add(1) % uint64(len(channels)))
+	best := start
+	bestInFlight := channels[start].InFlight()
+	for i := 1; i < len(channels) && bestInFlight > 0; i++ {
+		index := (start + i) % len(channels)
+		if inFlight := channels[index].InFlight(); inFlight < bestInFlight {
+			best, bestInFlight = index, inFlight
+		}
+	}
+	return best
+}
+
+func (s *leastInFlightSelector) String() string {
+	return "LeastInFlight"
+}
+
+type powerOfTwoChoicesSelector struct{}
+
+// NewPowerOfTwoChoicesSelector returns a Selector that picks two channels at random and uses the one with
+// fewer requests in flight. It balances nearly as well as NewLeastInFlightSelector while inspecting only two
+// channels, and avoids sending a burst of requests to the same momentarily least loaded channel.
+func NewPowerOfTwoChoicesSelector() Selector {
+	return powerOfTwoChoicesSelector{}
+}
+
+func (powerOfTwoChoicesSelector) Select(channels []Channel) int {
+	if len(channels) == 1 {
+		return 0
+	}
+	first := rand.Intn(len(channels))
+	second := rand.Intn(len(channels) - 1)
+	if second >= first {
+		second++
+	}
+	if channels[second].InFlight() < channels[first].InFlight() {
+		return second
+	}
+	return first
+}
+
+func (powerOfTwoChoicesSelector) String() string {
+	return "PowerOfTwoChoices"
+}
+
+// Props configures how the cache client spreads requests over its gRPC channels and maintains them. The zero
+// value of each field selects its default.
+type Props struct {
+	// Selector chooses the channel for each request. Defaults to NewRoundRobinSelector().
+	Selector Selector
+	// MinChannels and MaxChannels bound the number of channels as it grows and shrinks with load. Both
+	// default to the configured number of gRPC channels, which keeps the number fixed.
+	MinChannels uint32
+	MaxChannels uint32
+	// TargetInFlightPerChannel is the average number of requests in flight per channel above which a channel
+	// is added. A channel is removed when the average falls below a quarter of it. Defaults to 50.
+	TargetInFlightPerChannel int64
+	// StallTimeout is how long a channel can have requests in flight without completing any before it is
+	// considered wedged and replaced with a new one. A channel is only considered wedged once one of its
+	// requests has timed out, so long requests within their deadlines do not count as a stall. A replaced
+	// channel is closed once its requests complete or reach their deadlines. Defaults to 10 seconds.
+	StallTimeout time.Duration
+	// CheckInterval is how often channels are checked for stalls and the number of channels is adjusted.
+	// Defaults to 1 second.
+	CheckInterval time.Duration
+}
+
+// WithDefaults returns the props with defaults applied, using numChannels for unset channel bounds.
+func (p Props) WithDefaults(numChannels uint32) Props {
+	if p.Selector == nil {
+		p.Selector = NewRoundRobinSelector()
+	}
+	if numChannels < 1 {
+		numChannels = 1
+	}
+	if p.MinChannels < 1 {
+		p.MinChannels = numChannels
+	}
+	if p.MaxChannels < p.MinChannels {
+		p.MaxChannels = numChannels
+		if p.MaxChannels < p.MinChannels {
+			p.MaxChannels = p.MinChannels
+		}
+	}
+	if p.TargetInFlightPerChannel <= 0 {
+		p.TargetInFlightPerChannel = defaultTargetInFlightPerChannel
+	}
+	if p.StallTimeout <= 0 {
+		p.StallTimeout = defaultStallTimeout
+	}
+	if p.CheckInterval <= 0 {
+		p.CheckInterval = defaultCheckInterval
+	}
+	return p
+}
+
+func (p Props) String() string {
+	return fmt.Sprintf(
+		"ChannelSelectionProps{Selector: %v, MinChannels: %d, MaxChannels: %d, TargetInFlightPerChannel: %d, StallTimeout: %s, CheckInterval: %s}",
+		p.Selector, p.MinChannels, p.MaxChannels, p.TargetInFlightPerChannel, p.StallTimeout, p.CheckInterval,
+	)
+}
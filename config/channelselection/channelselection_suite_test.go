@@ -0,0 +1,13 @@
+package channelselection_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestChannelSelection(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Channel Selection Suite")
+}
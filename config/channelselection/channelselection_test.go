@@ -0,0 +1,83 @@
+package channelselection_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/momentohq/client-sdk-go/config/channelselection"
+)
+
+type fakeChannel int64
+
+func (c fakeChannel) InFlight() int64 {
+	return int64(c)
+}
+
+func channels(inFlight ...int64) []channelselection.Channel {
+	result := make([]channelselection.Channel, len(inFlight))
+	for i, n := range inFlight {
+		result[i] = fakeChannel(n)
+	}
+	return result
+}
+
+var _ = Describe("channel selection", func() {
+	It("cycles through channels in round-robin order", func() {
+		selector := channelselection.NewRoundRobinSelector()
+		counts := make([]int, 3)
+		for i := 0; i < 30; i++ {
+			counts[selector.Select(channels(5, 0, 9))]++
+		}
+		Expect(counts).To(Equal([]int{10, 10, 10}))
+	})
+
+	It("picks the channel with the fewest requests in flight", func() {
+		selector := channelselection.NewLeastInFlightSelector()
+		for i := 0; i < 10; i++ {
+			Expect(selector.Select(channels(5, 1, 9, 3))).To(Equal(1))
+		}
+	})
+
+	It("spreads requests over idle channels", func() {
+		selector := channelselection.NewLeastInFlightSelector()
+		seen := map[int]bool{}
+		for i := 0; i < 4; i++ {
+			seen[selector.Select(channels(0, 0, 0, 0))] = true
+		}
+		Expect(seen).To(HaveLen(4))
+	})
+
+	It("never picks the most loaded of two channels with power of two choices", func() {
+		selector := channelselection.NewPowerOfTwoChoicesSelector()
+		for i := 0; i < 100; i++ {
+			Expect(selector.Select(channels(0, 100))).To(Equal(0))
+		}
+		Expect(selector.Select(channels(7))).To(Equal(0))
+	})
+
+	It("avoids the most loaded channel with power of two choices", func() {
+		selector := channelselection.NewPowerOfTwoChoicesSelector()
+		for i := 0; i < 100; i++ {
+			Expect(selector.Select(channels(1, 2, 100, 3))).ToNot(Equal(2))
+		}
+	})
+
+	It("applies defaults", func() {
+		props := channelselection.Props{}.WithDefaults(4)
+		Expect(props.Selector).ToNot(BeNil())
+		Expect(props.MinChannels).To(Equal(uint32(4)))
+		Expect(props.MaxChannels).To(Equal(uint32(4)))
+		Expect(props.TargetInFlightPerChannel).To(Equal(int64(50)))
+		Expect(props.StallTimeout).To(Equal(10 * time.Second))
+		Expect(props.CheckInterval).To(Equal(time.Second))
+
+		props = channelselection.Props{MinChannels: 2, MaxChannels: 8}.WithDefaults(4)
+		Expect(props.MinChannels).To(Equal(uint32(2)))
+		Expect(props.MaxChannels).To(Equal(uint32(8)))
+
+		props = channelselection.Props{MinChannels: 6}.WithDefaults(4)
+		Expect(props.MaxChannels).To(Equal(uint32(6)))
+	})
+})
@@ -0,0 +1,294 @@
+package circuitbreaker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config/logger"
+)
+
+// State is the state of a circuit.
+type State string
+
+const (
+	// StateClosed is the normal state, in which requests are sent.
+	StateClosed State = "closed"
+	// StateOpen is the state in which requests fail immediately without being sent.
+	StateOpen State = "open"
+	// StateHalfOpen is the state in which a limited number of trial requests are sent to find out whether
+	// the service has recovered.
+	StateHalfOpen State = "half-open"
+)
+
+const (
+	defaultWindow              = 10 * time.Second
+	minWindow                  = 10 * time.Millisecond
+	defaultMinimumRequests     = 20
+	defaultFailureRateThresh   = 0.5
+	defaultOpenDuration        = 30 * time.Second
+	defaultHalfOpenMaxRequests = 1
+	numWindowBuckets           = 10
+)
+
+// StateChange describes a circuit moving from one state to another.
+type StateChange struct {
+	// CacheName is the cache the circuit belongs to, or an empty string if circuits are not tracked per cache.
+	CacheName string
+	From      State
+	To        State
+}
+
+// Props configures a circuit breaker. The zero value of each field selects its default.
+type Props struct {
+	// PerCache tracks a separate circuit for each cache, so that one degraded cache does not stop requests to
+	// the others. By default a single circuit is shared by all requests made by a client.
+	PerCache bool
+	// Window is the period over which failure and slow call rates are measured. Defaults to 10 seconds, and
+	// windows shorter than 10 milliseconds are raised to it.
+	Window time.Duration
+	// MinimumRequests is the number of requests that must be made within the window before the circuit
+	// can trip. Defaults to 20.
+	MinimumRequests int
+	// FailureRateThreshold is the fraction of requests in the window, between 0 and 1, that must fail with
+	// a TimeoutError or ServerUnavailableError to trip the circuit. Timeouts only count for requests that
+	// had the whole client timeout to complete. Defaults to 0.5.
+	FailureRateThreshold float64
+	// SlowCallDuration is the latency above which a successful request counts as slow. Defaults to 0,
+	// meaning latency is not considered.
+	SlowCallDuration time.Duration
+	// SlowCallRateThreshold is the fraction of requests in the window, between 0 and 1, that must be slow
+	// to trip the circuit. Defaults to 1, so the circuit only trips on latency when every request is slow.
+	SlowCallRateThreshold float64
+	// OpenDuration is how long the circuit stays open before trial requests are allowed. Defaults to 30 seconds.
+	OpenDuration time.Duration
+	// HalfOpenMaxRequests is the number of trial requests allowed while half-open. The circuit closes once
+	// they all succeed, and opens again as soon as one fails. Defaults to 1.
+	HalfOpenMaxRequests int
+	// OnStateChange, if set, is called whenever a circuit changes state. It is called synchronously by the
+	// request that caused the change, so it should return quickly.
+	OnStateChange func(change StateChange)
+	// Logger is used to log state changes. Defaults to a no-op logger.
+	Logger logger.MomentoLogger
+}
+
+func (p Props) withDefaults() Props {
+	if p.Window <= 0 {
+		p.Window = defaultWindow
+	} else if p.Window < minWindow {
+		p.Window = minWindow
+	}
+	if p.MinimumRequests <= 0 {
+		p.MinimumRequests = defaultMinimumRequests
+	}
+	if p.FailureRateThreshold <= 0 {
+		p.FailureRateThreshold = defaultFailureRateThresh
+	}
+	if p.SlowCallRateThreshold <= 0 {
+		p.SlowCallRateThreshold = 1
+	}
+	if p.OpenDuration <= 0 {
+		p.OpenDuration = defaultOpenDuration
+	}
+	if p.HalfOpenMaxRequests <= 0 {
+		p.HalfOpenMaxRequests = defaultHalfOpenMaxRequests
+	}
+	if p.Logger == nil {
+		p.Logger = logger.NewNoopMomentoLoggerFactory().GetLogger("circuit-breaker")
+	}
+	return p
+}
+
+func (p Props) String() string {
+	return fmt.Sprintf(
+		"CircuitBreakerProps{PerCache: %t, Window: %s, MinimumRequests: %d, FailureRateThreshold: %v, "+
+			"SlowCallDuration: %s, SlowCallRateThreshold: %v, OpenDuration: %s, HalfOpenMaxRequests: %d}",
+		p.PerCache, p.Window, p.MinimumRequests, p.FailureRateThreshold,
+		p.SlowCallDuration, p.SlowCallRateThreshold, p.OpenDuration, p.HalfOpenMaxRequests,
+	)
+}
+
+// Outcome is the result of a request made through a circuit breaker.
+type Outcome struct {
+	// Failed is true if the request failed in a way that indicates the service is unhealthy.
+	Failed bool
+	// Latency is how long the request took.
+	Latency time.Duration
+}
+
+// CircuitBreaker tracks the health of the requests made by a client and stops sending requests while
+// the service appears to be unhealthy. It is safe for concurrent use.
+type CircuitBreaker struct {
+	props    Props
+	mutex    sync.Mutex
+	circuits map[string]*circuit
+	now      func() time.Time
+}
+
+// New returns a circuit breaker with the given props.
+func New(props Props) *CircuitBreaker {
+	return &CircuitBreaker{
+		props:    props.withDefaults(),
+		circuits: make(map[string]*circuit),
+		now:      time.Now,
+	}
+}
+
+// Allow reports whether a request to cacheName may be sent. If it returns true, the caller must report
+// the outcome of the request with the returned function.
+func (cb *CircuitBreaker) Allow(cacheName string) (func(Outcome), bool) {
+	if !cb.props.PerCache {
+		cacheName = ""
+	}
+
+	var changes []StateChange
+	defer cb.notify(&changes)
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	c, ok := cb.circuits[cacheName]
+	if !ok {
+		c = &circuit{state: StateClosed}
+		cb.circuits[cacheName] = c
+	}
+
+	now := cb.now()
+	var trial bool
+	switch c.state {
+	case StateOpen:
+		if now.Before(c.openedAt.Add(cb.props.OpenDuration)) {
+			return nil, false
+		}
+		changes = append(changes, cb.transition(cacheName, c, StateHalfOpen, now))
+		fallthrough
+	case StateHalfOpen:
+		if c.trialsInFlight+c.trialSuccesses >= cb.props.HalfOpenMaxRequests {
+			return nil, false
+		}
+		c.trialsInFlight++
+		trial = true
+	}
+
+	generation := c.generation
+	return func(outcome Outcome) {
+		cb.record(cacheName, c, generation, trial, outcome)
+	}, true
+}
+
+// State returns the current state of the circuit for cacheName.
+func (cb *CircuitBreaker) State(cacheName string) State {
+	if !cb.props.PerCache {
+		cacheName = ""
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	if c, ok := cb.circuits[cacheName]; ok {
+		return c.state
+	}
+	return StateClosed
+}
+
+func (cb *CircuitBreaker) record(cacheName string, c *circuit, generation uint64, trial bool, outcome Outcome) {
+	var changes []StateChange
+	defer cb.notify(&changes)
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	// Outcomes of requests that were allowed before the last state change no longer apply.
+	if generation != c.generation {
+		return
+	}
+
+	now := cb.now()
+	slow := cb.props.SlowCallDuration > 0 && outcome.Latency > cb.props.SlowCallDuration
+	if trial {
+		c.trialsInFlight--
+		if outcome.Failed || slow {
+			changes = append(changes, cb.transition(cacheName, c, StateOpen, now))
+			return
+		}
+		c.trialSuccesses++
+		if c.trialSuccesses >= cb.props.HalfOpenMaxRequests {
+			changes = append(changes, cb.transition(cacheName, c, StateClosed, now))
+		}
+		return
+	}
+
+	b := c.bucket(now, cb.props.Window)
+	b.requests++
+	if outcome.Failed {
+		b.failures++
+	}
+	if slow {
+		b.slow++
+	}
+
+	requests, failures, slowCalls := c.totals(now, cb.props.Window)
+	if requests < cb.props.MinimumRequests {
+		return
+	}
+	if float64(failures)/float64(requests) >= cb.props.FailureRateThreshold ||
+		(cb.props.SlowCallDuration > 0 && float64(slowCalls)/float64(requests) >= cb.props.SlowCallRateThreshold) {
+		changes = append(changes, cb.transition(cacheName, c, StateOpen, now))
+	}
+}
+
+// transition moves a circuit to a new state. It must be called with the mutex held.
+func (cb *CircuitBreaker) transition(cacheName string, c *circuit, to State, now time.Time) StateChange {
+	from := c.state
+	c.state = to
+	c.generation++
+	c.trialsInFlight = 0
+	c.trialSuccesses = 0
+	c.buckets = [numWindowBuckets]bucket{}
+	if to == StateOpen {
+		c.openedAt = now
+	}
+	return StateChange{CacheName: cacheName, From: from, To: to}
+}
+
+// notify reports state changes once the mutex has been released, so that callbacks may use the breaker.
+func (cb *CircuitBreaker) notify(changes *[]StateChange) {
+	for _, change := range *changes {
+		cb.props.Logger.Warn("Circuit for cache '%s' changed from %s to %s", change.CacheName, change.From, change.To)
+		if cb.props.OnStateChange != nil {
+			cb.props.OnStateChange(change)
+		}
+	}
+}
+
+type circuit struct {
+	state          State
+	generation     uint64
+	openedAt       time.Time
+	trialsInFlight int
+	trialSuccesses int
+	buckets        [numWindowBuckets]bucket
+}
+
+// bucket counts the requests that completed within one slice of the window.
+type bucket struct {
+	start    time.Time
+	requests int
+	failures int
+	slow     int
+}
+
+func (c *circuit) bucket(now time.Time, window time.Duration) *bucket {
+	width := window / numWindowBuckets
+	start := now.Truncate(width)
+	b := &c.buckets[(start.UnixNano()/int64(width))%numWindowBuckets]
+	if !b.start.Equal(start) {
+		*b = bucket{start: start}
+	}
+	return b
+}
+
+func (c *circuit) totals(now time.Time, window time.Duration) (requests int, failures int, slow int) {
+	for _, b := range c.buckets {
+		if now.Sub(b.start) < window {
+			requests += b.requests
+			failures += b.failures
+			slow += b.slow
+		}
+	}
+	return requests, failures, slow
+}
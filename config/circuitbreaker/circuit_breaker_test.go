@@ -0,0 +1,102 @@
+package circuitbreaker_test
+
+import (
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config/circuitbreaker"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// makeRequests sends requests through the breaker and reports the given outcome for each of them.
+func makeRequests(cb *circuitbreaker.CircuitBreaker, cacheName string, count int, outcome circuitbreaker.Outcome) {
+	for i := 0; i < count; i++ {
+		done, ok := cb.Allow(cacheName)
+		Expect(ok).To(BeTrue())
+		done(outcome)
+	}
+}
+
+var _ = Describe("circuit-breaker", func() {
+	var changes []circuitbreaker.StateChange
+	var props circuitbreaker.Props
+
+	BeforeEach(func() {
+		changes = nil
+		props = circuitbreaker.Props{
+			MinimumRequests: 10,
+			OpenDuration:    50 * time.Millisecond,
+			OnStateChange: func(change circuitbreaker.StateChange) {
+				changes = append(changes, change)
+			},
+		}
+	})
+
+	It("opens when the failure rate reaches the threshold", func() {
+		cb := circuitbreaker.New(props)
+		makeRequests(cb, "cache", 5, circuitbreaker.Outcome{})
+		makeRequests(cb, "cache", 4, circuitbreaker.Outcome{Failed: true})
+		Expect(cb.State("cache")).To(Equal(circuitbreaker.StateClosed))
+
+		makeRequests(cb, "cache", 1, circuitbreaker.Outcome{Failed: true})
+		Expect(cb.State("cache")).To(Equal(circuitbreaker.StateOpen))
+		_, ok := cb.Allow("cache")
+		Expect(ok).To(BeFalse())
+		Expect(changes).To(Equal([]circuitbreaker.StateChange{{From: circuitbreaker.StateClosed, To: circuitbreaker.StateOpen}}))
+	})
+
+	It("raises windows too short to divide into buckets", func() {
+		props.Window = 5 * time.Nanosecond
+		cb := circuitbreaker.New(props)
+		makeRequests(cb, "cache", 10, circuitbreaker.Outcome{Failed: true})
+		Expect(cb.State("cache")).To(Equal(circuitbreaker.StateOpen))
+	})
+
+	It("opens when requests are slow", func() {
+		props.SlowCallDuration = 10 * time.Millisecond
+		props.SlowCallRateThreshold = 0.8
+		cb := circuitbreaker.New(props)
+		makeRequests(cb, "cache", 2, circuitbreaker.Outcome{Latency: time.Millisecond})
+		makeRequests(cb, "cache", 8, circuitbreaker.Outcome{Latency: time.Second})
+		Expect(cb.State("cache")).To(Equal(circuitbreaker.StateOpen))
+	})
+
+	It("closes after a successful trial request", func() {
+		cb := circuitbreaker.New(props)
+		makeRequests(cb, "cache", 10, circuitbreaker.Outcome{Failed: true})
+		Expect(cb.State("cache")).To(Equal(circuitbreaker.StateOpen))
+
+		time.Sleep(props.OpenDuration)
+		done, ok := cb.Allow("cache")
+		Expect(ok).To(BeTrue())
+		Expect(cb.State("cache")).To(Equal(circuitbreaker.StateHalfOpen))
+		_, ok = cb.Allow("cache")
+		Expect(ok).To(BeFalse())
+
+		done(circuitbreaker.Outcome{})
+		Expect(cb.State("cache")).To(Equal(circuitbreaker.StateClosed))
+		Expect(changes).To(HaveLen(3))
+	})
+
+	It("opens again after a failed trial request", func() {
+		cb := circuitbreaker.New(props)
+		makeRequests(cb, "cache", 10, circuitbreaker.Outcome{Failed: true})
+
+		time.Sleep(props.OpenDuration)
+		done, ok := cb.Allow("cache")
+		Expect(ok).To(BeTrue())
+		done(circuitbreaker.Outcome{Failed: true})
+		Expect(cb.State("cache")).To(Equal(circuitbreaker.StateOpen))
+	})
+
+	It("tracks circuits per cache when configured", func() {
+		props.PerCache = true
+		cb := circuitbreaker.New(props)
+		makeRequests(cb, "degraded", 10, circuitbreaker.Outcome{Failed: true})
+		Expect(cb.State("degraded")).To(Equal(circuitbreaker.StateOpen))
+		Expect(cb.State("healthy")).To(Equal(circuitbreaker.StateClosed))
+		_, ok := cb.Allow("healthy")
+		Expect(ok).To(BeTrue())
+		Expect(changes[0].CacheName).To(Equal("degraded"))
+	})
+})
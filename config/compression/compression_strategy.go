@@ -10,6 +10,10 @@ type CompressionStrategy interface {
 type CompressionStrategyProps struct {
 	CompressionLevel CompressionLevel
 	Logger           logger.MomentoLogger
+	// MinimumSizeBytes is the size below which values are stored uncompressed. Small values rarely
+	// shrink enough to be worth the cost of compressing them, and may even grow. Defaults to 0,
+	// meaning all values are compressed.
+	MinimumSizeBytes int
 }
 
 type CompressionLevel string
@@ -0,0 +1,48 @@
+package compression
+
+import "bytes"
+
+// FrameFlags records properties of the original value that are lost once it has been compressed.
+type FrameFlags byte
+
+const (
+	// FrameFlagText indicates the framed value was originally a utf-8 string rather than raw bytes.
+	// Topic messages use this to restore the original message kind on the subscriber side.
+	FrameFlagText FrameFlags = 0x01
+)
+
+const frameVersion byte = 0x01
+
+// frameMagic is the byte sequence that begins a framed value, followed by the frame version and flags.
+// Values that start with a NUL byte are still valid utf-8, but typical text and binary payloads rarely
+// begin with these magic bytes, so a collision with a legacy value is unlikely.
+var frameMagic = []byte{0x00, 'M', 'C'}
+
+// frameHeaderLength is the length of the magic bytes plus the version and flags bytes.
+var frameHeaderLength = len(frameMagic) + 2
+
+// EncodeFrame wraps an encoded payload in a self-describing frame so that readers can tell it apart
+// from legacy values that were written without any encoding.
+func EncodeFrame(payload []byte, flags FrameFlags) []byte {
+	framed := make([]byte, 0, frameHeaderLength+len(payload))
+	framed = append(framed, frameMagic...)
+	framed = append(framed, frameVersion, byte(flags))
+	return append(framed, payload...)
+}
+
+// DecodeFrame returns the payload and flags of a framed value. The final return value is false if
+// data is not a frame written by EncodeFrame, in which case it should be treated as a legacy value.
+func DecodeFrame(data []byte) ([]byte, FrameFlags, bool) {
+	if !IsFramed(data) {
+		return nil, 0, false
+	}
+	return data[frameHeaderLength:], FrameFlags(data[len(frameMagic)+1]), true
+}
+
+// IsFramed reports whether data begins with a frame header written by EncodeFrame.
+func IsFramed(data []byte) bool {
+	if len(data) < frameHeaderLength {
+		return false
+	}
+	return bytes.Equal(data[:len(frameMagic)], frameMagic) && data[len(frameMagic)] == frameVersion
+}
@@ -0,0 +1,278 @@
+package concurrency
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Mode is how a Limiter sets its limit.
+type Mode string
+
+const (
+	// ModeFixed keeps the limit at MaxInFlight.
+	ModeFixed Mode = "fixed"
+	// ModeAIMD raises the limit by one for each fast request that used the limit, and multiplies it by
+	// BackoffRatio for each request that was slow or failed because the service was overloaded.
+	ModeAIMD Mode = "aimd"
+	// ModeGradient moves the limit toward the ratio of the long-term average latency to the latest latency,
+	// so that the limit falls as soon as requests queue up in the service or the network.
+	ModeGradient Mode = "gradient"
+)
+
+const (
+	defaultMaxInFlight      = 1000
+	defaultLatencyThreshold = 100 * time.Millisecond
+	defaultBackoffRatio     = 0.9
+	defaultSmoothing        = 0.2
+	// longLatencyWeight is the weight of each sample in the long-term latency average of ModeGradient.
+	longLatencyWeight = 0.01
+)
+
+var (
+	// ErrLimitExceeded is returned by Acquire when the limit is reached and the wait queue is full.
+	ErrLimitExceeded = errors.New("concurrency limit exceeded")
+	// ErrQueueTimeout is returned by Acquire when a request waited MaxQueueWait without being admitted.
+	ErrQueueTimeout = errors.New("timed out waiting for a concurrency slot")
+)
+
+// Props configures a Limiter. The zero value of each field selects its default.
+type Props struct {
+	// Mode selects a fixed or adaptive limit. Defaults to ModeFixed.
+	Mode Mode
+	// MaxInFlight is the largest number of requests in flight at once. Adaptive modes start at this limit
+	// and only ever lower it to shed load. Defaults to 1000.
+	MaxInFlight int
+	// MinInFlight is the lowest limit an adaptive mode can set. Defaults to 1.
+	MinInFlight int
+	// MaxQueued is the number of requests that can wait for a slot once the limit is reached. Further
+	// requests are rejected immediately. Defaults to MaxInFlight; a negative value disables the queue.
+	MaxQueued int
+	// MaxQueueWait is the longest a request waits in the queue. Defaults to 0, meaning until the request's
+	// context is done.
+	MaxQueueWait time.Duration
+	// LatencyThreshold is the latency above which ModeAIMD treats a request as slow. Defaults to 100ms.
+	LatencyThreshold time.Duration
+	// BackoffRatio is the factor, between 0 and 1, that adaptive modes multiply the limit by when a request
+	// fails because the service is overloaded, and ModeAIMD also when a request is slow. Defaults to 0.9.
+	BackoffRatio float64
+	// Smoothing is the weight, between 0 and 1, of each new limit computed by ModeGradient. Defaults to 0.2.
+	Smoothing float64
+	// OnLimitChange, if set, is called when an adaptive mode changes the limit. It is called synchronously
+	// by the request that caused the change, so it should return quickly.
+	OnLimitChange func(oldLimit int, newLimit int)
+}
+
+func (p Props) withDefaults() Props {
+	if p.Mode == "" {
+		p.Mode = ModeFixed
+	}
+	if p.MaxInFlight <= 0 {
+		p.MaxInFlight = defaultMaxInFlight
+	}
+	if p.MinInFlight <= 0 {
+		p.MinInFlight = 1
+	}
+	if p.MinInFlight > p.MaxInFlight {
+		p.MinInFlight = p.MaxInFlight
+	}
+	if p.MaxQueued == 0 {
+		p.MaxQueued = p.MaxInFlight
+	}
+	if p.MaxQueued < 0 {
+		p.MaxQueued = 0
+	}
+	if p.LatencyThreshold <= 0 {
+		p.LatencyThreshold = defaultLatencyThreshold
+	}
+	if p.BackoffRatio <= 0 || p.BackoffRatio >= 1 {
+		p.BackoffRatio = defaultBackoffRatio
+	}
+	if p.Smoothing <= 0 || p.Smoothing > 1 {
+		p.Smoothing = defaultSmoothing
+	}
+	return p
+}
+
+func (p Props) String() string {
+	return fmt.Sprintf(
+		"ConcurrencyLimitProps{Mode: %s, MaxInFlight: %d, MinInFlight: %d, MaxQueued: %d, MaxQueueWait: %s}",
+		p.Mode, p.MaxInFlight, p.MinInFlight, p.MaxQueued, p.MaxQueueWait,
+	)
+}
+
+// Outcome is the result of a request admitted by a Limiter.
+type Outcome struct {
+	// Overloaded is true if the request failed in a way that indicates the service is overloaded, such as
+	// a timeout.
+	Overloaded bool
+	// Latency is how long the request took.
+	Latency time.Duration
+}
+
+// Stats is a point in time view of a Limiter.
+type Stats struct {
+	Limit    int
+	InFlight int
+	Queued   int
+	// Rejected is the number of requests rejected since the limiter was created, whether because the
+	// queue was full or because they timed out in it.
+	Rejected uint64
+}
+
+// waiter is a request waiting in the queue.
+type waiter struct {
+	ready    chan struct{}
+	admitted bool
+}
+
+// Limiter bounds the number of requests in flight at once, queueing a bounded number of requests beyond
+// the limit. It is safe for concurrent use. Configurations that share a Limiter share its limit, so it can
+// bound all the requests of a process.
+type Limiter struct {
+	props Props
+
+	mutex    sync.Mutex
+	limit    float64
+	inFlight int
+	queue    list.List
+	rejected uint64
+	// longLatency is the long-term average latency used by ModeGradient, in seconds.
+	longLatency float64
+}
+
+// New returns a Limiter with the given props.
+func New(props Props) *Limiter {
+	props = props.withDefaults()
+	return &Limiter{props: props, limit: float64(props.MaxInFlight)}
+}
+
+// Acquire admits a request, waiting in the queue if the limit is reached. If it returns no error, the
+// caller must report the outcome of the request with the returned function. Otherwise the error is
+// ErrLimitExceeded, ErrQueueTimeout, or the error of ctx if it is done first.
+func (l *Limiter) Acquire(ctx context.Context) (func(Outcome), error) {
+	l.mutex.Lock()
+	if l.inFlight < int(l.limit) && l.queue.Len() == 0 {
+		l.inFlight++
+		l.mutex.Unlock()
+		return l.release, nil
+	}
+	if l.queue.Len() >= l.props.MaxQueued {
+		l.rejected++
+		l.mutex.Unlock()
+		return nil, ErrLimitExceeded
+	}
+	w := &waiter{ready: make(chan struct{})}
+	element := l.queue.PushBack(w)
+	l.mutex.Unlock()
+
+	var timeout <-chan time.Time
+	if l.props.MaxQueueWait > 0 {
+		timer := time.NewTimer(l.props.MaxQueueWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var err error
+	select {
+	case <-w.ready:
+		return l.release, nil
+	case <-timeout:
+		err = ErrQueueTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	l.mutex.Lock()
+	if w.admitted {
+		// The request was admitted as it gave up, so its slot is handed on.
+		l.mutex.Unlock()
+		l.release(Outcome{})
+		return nil, err
+	}
+	l.queue.Remove(element)
+	l.rejected++
+	l.mutex.Unlock()
+	return nil, err
+}
+
+// Limit returns the current limit.
+func (l *Limiter) Limit() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return int(l.limit)
+}
+
+// Stats returns the current limit, the requests in flight and queued, and the number of rejections.
+func (l *Limiter) Stats() Stats {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return Stats{Limit: int(l.limit), InFlight: l.inFlight, Queued: l.queue.Len(), Rejected: l.rejected}
+}
+
+func (l *Limiter) release(outcome Outcome) {
+	l.mutex.Lock()
+	// A request used the limit if more than half of it was in flight, counting the request itself.
+	limited := l.inFlight*2 > int(l.limit)
+	l.inFlight--
+	oldLimit := int(l.limit)
+	l.adjust(outcome, limited)
+	newLimit := int(l.limit)
+	l.admitWaiters()
+	l.mutex.Unlock()
+
+	if newLimit != oldLimit && l.props.OnLimitChange != nil {
+		l.props.OnLimitChange(oldLimit, newLimit)
+	}
+}
+
+// adjust updates an adaptive limit with the outcome of a request. It must be called with the mutex held.
+func (l *Limiter) adjust(outcome Outcome, limited bool) {
+	if l.props.Mode == ModeFixed {
+		return
+	}
+	limit := l.limit
+	switch {
+	case outcome.Overloaded:
+		limit *= l.props.BackoffRatio
+	case l.props.Mode == ModeAIMD:
+		if outcome.Latency > l.props.LatencyThreshold {
+			limit *= l.props.BackoffRatio
+		} else if limited {
+			limit++
+		}
+	case l.props.Mode == ModeGradient:
+		sample := outcome.Latency.Seconds()
+		if sample <= 0 {
+			return
+		}
+		if l.longLatency == 0 {
+			l.longLatency = sample
+		} else {
+			l.longLatency = l.longLatency*(1-longLatencyWeight) + sample*longLatencyWeight
+		}
+		// Only a request that used the limit shows whether a higher one is sustainable.
+		if !limited && sample <= l.longLatency {
+			return
+		}
+		gradient := math.Max(0.5, math.Min(1, l.longLatency/sample))
+		target := limit*gradient + math.Sqrt(limit)
+		limit = limit*(1-l.props.Smoothing) + target*l.props.Smoothing
+	}
+	l.limit = math.Max(float64(l.props.MinInFlight), math.Min(float64(l.props.MaxInFlight), limit))
+}
+
+// admitWaiters admits queued requests while there is room under the limit. It must be called with the mutex
+// held.
+func (l *Limiter) admitWaiters() {
+	for l.inFlight < int(l.limit) && l.queue.Len() > 0 {
+		w := l.queue.Remove(l.queue.Front()).(*waiter)
+		w.admitted = true
+		l.inFlight++
+		close(w.ready)
+	}
+}
@@ -0,0 +1,143 @@
+package concurrency_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/momentohq/client-sdk-go/config/concurrency"
+)
+
+var _ = Describe("concurrency-limiter", func() {
+	It("admits requests up to the limit and queues the rest", func() {
+		limiter := concurrency.New(concurrency.Props{MaxInFlight: 2, MaxQueued: 1})
+		release1, err := limiter.Acquire(context.Background())
+		Expect(err).To(BeNil())
+		_, err = limiter.Acquire(context.Background())
+		Expect(err).To(BeNil())
+
+		admitted := make(chan func(concurrency.Outcome))
+		go func() {
+			release, err := limiter.Acquire(context.Background())
+			Expect(err).To(BeNil())
+			admitted <- release
+		}()
+		Eventually(func() int { return limiter.Stats().Queued }).Should(Equal(1))
+
+		_, err = limiter.Acquire(context.Background())
+		Expect(err).To(Equal(concurrency.ErrLimitExceeded))
+
+		release1(concurrency.Outcome{})
+		Eventually(admitted).Should(Receive())
+		Expect(limiter.Stats()).To(Equal(concurrency.Stats{Limit: 2, InFlight: 2, Queued: 0, Rejected: 1}))
+	})
+
+	It("rejects immediately when the queue is disabled", func() {
+		limiter := concurrency.New(concurrency.Props{MaxInFlight: 1, MaxQueued: -1})
+		_, err := limiter.Acquire(context.Background())
+		Expect(err).To(BeNil())
+		_, err = limiter.Acquire(context.Background())
+		Expect(err).To(Equal(concurrency.ErrLimitExceeded))
+	})
+
+	It("times out requests that wait too long in the queue", func() {
+		limiter := concurrency.New(concurrency.Props{MaxInFlight: 1, MaxQueueWait: 10 * time.Millisecond})
+		_, err := limiter.Acquire(context.Background())
+		Expect(err).To(BeNil())
+		_, err = limiter.Acquire(context.Background())
+		Expect(err).To(Equal(concurrency.ErrQueueTimeout))
+		Expect(limiter.Stats().Queued).To(Equal(0))
+	})
+
+	It("returns the context error when the context is done while queued", func() {
+		limiter := concurrency.New(concurrency.Props{MaxInFlight: 1})
+		_, err := limiter.Acquire(context.Background())
+		Expect(err).To(BeNil())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = limiter.Acquire(ctx)
+		Expect(err).To(Equal(context.Canceled))
+		Expect(limiter.Stats().Queued).To(Equal(0))
+	})
+
+	It("keeps a fixed limit regardless of outcomes", func() {
+		limiter := concurrency.New(concurrency.Props{MaxInFlight: 4})
+		release, err := limiter.Acquire(context.Background())
+		Expect(err).To(BeNil())
+		release(concurrency.Outcome{Overloaded: true})
+		Expect(limiter.Limit()).To(Equal(4))
+	})
+
+	Describe("aimd", func() {
+		It("backs off on slow or overloaded requests and recovers on fast ones", func() {
+			var changes [][2]int
+			limiter := concurrency.New(concurrency.Props{
+				Mode:             concurrency.ModeAIMD,
+				MaxInFlight:      10,
+				LatencyThreshold: 50 * time.Millisecond,
+				BackoffRatio:     0.5,
+				OnLimitChange: func(oldLimit int, newLimit int) {
+					changes = append(changes, [2]int{oldLimit, newLimit})
+				},
+			})
+
+			release, _ := limiter.Acquire(context.Background())
+			release(concurrency.Outcome{Latency: 100 * time.Millisecond})
+			Expect(limiter.Limit()).To(Equal(5))
+			release, _ = limiter.Acquire(context.Background())
+			release(concurrency.Outcome{Overloaded: true})
+			Expect(limiter.Limit()).To(Equal(2))
+
+			for i := 0; i < 20; i++ {
+				release, _ = limiter.Acquire(context.Background())
+				release(concurrency.Outcome{Latency: time.Millisecond})
+			}
+			Expect(limiter.Limit()).To(Equal(2))
+
+			// Fast requests only raise the limit when more than half of it is in use.
+			releases := make([]func(concurrency.Outcome), 0, 2)
+			for i := 0; i < 2; i++ {
+				r, err := limiter.Acquire(context.Background())
+				Expect(err).To(BeNil())
+				releases = append(releases, r)
+			}
+			releases[0](concurrency.Outcome{Latency: time.Millisecond})
+			Expect(limiter.Limit()).To(Equal(3))
+			Expect(changes).To(Equal([][2]int{{10, 5}, {5, 2}, {2, 3}}))
+		})
+
+		It("stays within the min and max", func() {
+			limiter := concurrency.New(concurrency.Props{Mode: concurrency.ModeAIMD, MaxInFlight: 4, MinInFlight: 2})
+			for i := 0; i < 10; i++ {
+				release, _ := limiter.Acquire(context.Background())
+				release(concurrency.Outcome{Overloaded: true})
+			}
+			Expect(limiter.Limit()).To(Equal(2))
+			for i := 0; i < 10; i++ {
+				r1, _ := limiter.Acquire(context.Background())
+				r2, _ := limiter.Acquire(context.Background())
+				r1(concurrency.Outcome{})
+				r2(concurrency.Outcome{})
+			}
+			Expect(limiter.Limit()).To(Equal(4))
+		})
+	})
+
+	Describe("gradient", func() {
+		It("lowers the limit as latency rises above its average", func() {
+			limiter := concurrency.New(concurrency.Props{Mode: concurrency.ModeGradient, MaxInFlight: 100})
+			for i := 0; i < 10; i++ {
+				release, _ := limiter.Acquire(context.Background())
+				release(concurrency.Outcome{Latency: 10 * time.Millisecond})
+			}
+			Expect(limiter.Limit()).To(Equal(100))
+			for i := 0; i < 10; i++ {
+				release, _ := limiter.Acquire(context.Background())
+				release(concurrency.Outcome{Latency: 100 * time.Millisecond})
+			}
+			Expect(limiter.Limit()).To(BeNumerically("<", 60))
+		})
+	})
+})
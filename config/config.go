@@ -4,6 +4,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/momentohq/client-sdk-go/config/batching"
+	"github.com/momentohq/client-sdk-go/config/channelselection"
+	"github.com/momentohq/client-sdk-go/config/circuitbreaker"
+	"github.com/momentohq/client-sdk-go/config/concurrency"
+	"github.com/momentohq/client-sdk-go/config/health"
+	"github.com/momentohq/client-sdk-go/config/hedging"
 	"github.com/momentohq/client-sdk-go/config/retry"
 
 	"github.com/momentohq/client-sdk-go/config/logger"
@@ -32,6 +38,19 @@ type ConfigurationProps struct {
 	ReadConcern ReadConcern
 	// Middleware is a list of middleware to be used by the cache client.
 	Middleware []middleware.Middleware
+	// CircuitBreaker configures a circuit breaker for the cache client. Nil disables the circuit breaker.
+	CircuitBreaker *circuitbreaker.Props
+	// Hedging configures hedged reads for the cache client. Nil disables hedging.
+	Hedging *hedging.Props
+	// AutoBatching configures automatic batching of Get requests for the cache client. Nil disables it.
+	AutoBatching *batching.Props
+	// ChannelSelection configures how requests are spread over gRPC channels and how the channels are
+	// maintained. Nil uses round-robin selection over a fixed number of channels.
+	ChannelSelection *channelselection.Props
+	// ConcurrencyLimiter bounds the number of requests the cache client has in flight. Nil disables the limit.
+	ConcurrencyLimiter *concurrency.Limiter
+	// HealthMonitor configures background health checks of the cache client's gRPC channels. Nil disables them.
+	HealthMonitor *health.Props
 }
 
 type Configuration interface {
@@ -90,6 +109,86 @@ type Configuration interface {
 
 	// AddMiddleware Copy constructor for adding Middleware returns a new Configuration object.
 	AddMiddleware(m middleware.Middleware) Configuration
+
+	// GetCircuitBreakerProps Returns the circuit breaker configuration, or nil if the circuit breaker is disabled.
+	GetCircuitBreakerProps() *circuitbreaker.Props
+
+	// WithCircuitBreaker Copy constructor for enabling a circuit breaker returns a new Configuration object.
+	// Each client created with the configuration tracks its own circuits. While a circuit is open, requests
+	// fail immediately with a CircuitOpenError instead of waiting for the client side timeout:
+	//   myConfig := config.LaptopLatest().WithCircuitBreaker(circuitbreaker.Props{
+	//     PerCache: true,
+	//     OnStateChange: func(change circuitbreaker.StateChange) {
+	//       log.Printf("circuit for %s is now %s", change.CacheName, change.To)
+	//     },
+	//   })
+	WithCircuitBreaker(props circuitbreaker.Props) Configuration
+
+	// GetHedgingProps Returns the hedged read configuration, or nil if hedging is disabled.
+	GetHedgingProps() *hedging.Props
+
+	// WithHedging Copy constructor for enabling hedged reads returns a new Configuration object.
+	// When a Get, GetBatch or DictionaryGetField request has not completed after the hedging delay, a
+	// duplicate request is sent on a different gRPC channel and the first successful response is returned.
+	// Hedging requires more than one gRPC channel:
+	//   myConfig := config.InRegionLatest().WithNumGrpcChannels(2).WithHedging(hedging.Props{
+	//     Percentile: 0.99,
+	//   })
+	WithHedging(props hedging.Props) Configuration
+
+	// GetAutoBatchingProps Returns the automatic Get batching configuration, or nil if it is disabled.
+	GetAutoBatchingProps() *batching.Props
+
+	// WithAutoBatching Copy constructor for enabling automatic batching of Get requests returns a new
+	// Configuration object. Concurrent Get requests to the same cache are collected for a short window and
+	// sent as a single GetBatch request. Get requests made with momento.WithRequestOptions are not batched:
+	//   metrics := batching.NewMetrics()
+	//   myConfig := config.InRegionLatest().WithAutoBatching(batching.Props{
+	//     Window:  2 * time.Millisecond,
+	//     OnBatch: metrics.Record,
+	//   })
+	WithAutoBatching(props batching.Props) Configuration
+
+	// GetChannelSelectionProps Returns the channel selection configuration, or nil if it is not set.
+	GetChannelSelectionProps() *channelselection.Props
+
+	// WithChannelSelection Copy constructor for configuring how requests are spread over gRPC channels returns
+	// a new Configuration object. Wedged channels are replaced, and the number of channels grows and shrinks
+	// with the number of requests in flight between MinChannels and MaxChannels:
+	//   myConfig := config.InRegionLatest().WithNumGrpcChannels(4).WithChannelSelection(channelselection.Props{
+	//     Selector:    channelselection.NewPowerOfTwoChoicesSelector(),
+	//     MaxChannels: 16,
+	//   })
+	WithChannelSelection(props channelselection.Props) Configuration
+
+	// GetConcurrencyLimiter Returns the concurrency limiter, or nil if it is not set.
+	GetConcurrencyLimiter() *concurrency.Limiter
+
+	// WithConcurrencyLimiter Copy constructor for bounding the number of requests in flight returns a new
+	// Configuration object. Requests beyond the limit wait in a bounded queue, and are rejected with a
+	// ClientResourceExhaustedError once it is full. Clients built from configurations that share a limiter
+	// share its limit, and the limiter reports the current limit:
+	//   limiter := concurrency.New(concurrency.Props{
+	//     Mode:        concurrency.ModeAIMD,
+	//     MaxInFlight: 200,
+	//     MaxQueued:   1000,
+	//   })
+	//   myConfig := config.InRegionLatest().WithConcurrencyLimiter(limiter)
+	WithConcurrencyLimiter(limiter *concurrency.Limiter) Configuration
+
+	// GetHealthMonitorProps Returns the health monitor configuration, or nil if it is disabled.
+	GetHealthMonitorProps() *health.Props
+
+	// WithHealthMonitor Copy constructor for enabling background health checks returns a new Configuration
+	// object. Each gRPC channel is pinged periodically and its connection watched, and the client's Health
+	// method reports the results:
+	//   myConfig := config.InRegionLatest().WithHealthMonitor(health.Props{
+	//     Interval: 5 * time.Second,
+	//     OnUnhealthy: func(channel health.ChannelHealth) {
+	//       log.Printf("channel %d is unhealthy: %v", channel.Index, channel.LastError)
+	//     },
+	//   })
+	WithHealthMonitor(props health.Props) Configuration
 }
 
 type cacheConfiguration struct {
@@ -99,6 +198,12 @@ type cacheConfiguration struct {
 	numGrpcChannels   uint32
 	readConcern       ReadConcern
 	middleware        []middleware.Middleware
+	circuitBreaker    *circuitbreaker.Props
+	hedging           *hedging.Props
+	autoBatching      *batching.Props
+	channelSelection  *channelselection.Props
+	concurrencyLimit  *concurrency.Limiter
+	healthMonitor     *health.Props
 }
 
 func (s *cacheConfiguration) GetLoggerFactory() logger.MomentoLoggerFactory {
@@ -117,6 +222,12 @@ func NewCacheConfiguration(props *ConfigurationProps) Configuration {
 		numGrpcChannels:   props.NumGrpcChannels,
 		readConcern:       props.ReadConcern,
 		middleware:        props.Middleware,
+		circuitBreaker:    props.CircuitBreaker,
+		hedging:           props.Hedging,
+		autoBatching:      props.AutoBatching,
+		channelSelection:  props.ChannelSelection,
+		concurrencyLimit:  props.ConcurrencyLimiter,
+		healthMonitor:     props.HealthMonitor,
 	}
 }
 
@@ -148,6 +259,12 @@ func (s *cacheConfiguration) WithClientTimeout(clientTimeout time.Duration) Conf
 		numGrpcChannels:   s.numGrpcChannels,
 		readConcern:       s.readConcern,
 		middleware:        s.middleware,
+		circuitBreaker:    s.circuitBreaker,
+		hedging:           s.hedging,
+		autoBatching:      s.autoBatching,
+		channelSelection:  s.channelSelection,
+		concurrencyLimit:  s.concurrencyLimit,
+		healthMonitor:     s.healthMonitor,
 	}
 }
 
@@ -159,6 +276,12 @@ func (s *cacheConfiguration) WithMiddleware(middleware []middleware.Middleware)
 		numGrpcChannels:   s.numGrpcChannels,
 		readConcern:       s.readConcern,
 		middleware:        middleware,
+		circuitBreaker:    s.circuitBreaker,
+		hedging:           s.hedging,
+		autoBatching:      s.autoBatching,
+		channelSelection:  s.channelSelection,
+		concurrencyLimit:  s.concurrencyLimit,
+		healthMonitor:     s.healthMonitor,
 	}
 }
 
@@ -170,6 +293,12 @@ func (s *cacheConfiguration) AddMiddleware(m middleware.Middleware) Configuratio
 		numGrpcChannels:   s.numGrpcChannels,
 		readConcern:       s.readConcern,
 		middleware:        append(s.middleware, m),
+		circuitBreaker:    s.circuitBreaker,
+		hedging:           s.hedging,
+		autoBatching:      s.autoBatching,
+		channelSelection:  s.channelSelection,
+		concurrencyLimit:  s.concurrencyLimit,
+		healthMonitor:     s.healthMonitor,
 	}
 }
 
@@ -181,6 +310,12 @@ func (s *cacheConfiguration) WithRetryStrategy(strategy retry.Strategy) Configur
 		numGrpcChannels:   s.numGrpcChannels,
 		readConcern:       s.readConcern,
 		middleware:        s.middleware,
+		circuitBreaker:    s.circuitBreaker,
+		hedging:           s.hedging,
+		autoBatching:      s.autoBatching,
+		channelSelection:  s.channelSelection,
+		concurrencyLimit:  s.concurrencyLimit,
+		healthMonitor:     s.healthMonitor,
 	}
 }
 
@@ -192,6 +327,12 @@ func (s *cacheConfiguration) WithTransportStrategy(transportStrategy TransportSt
 		numGrpcChannels:   s.numGrpcChannels,
 		readConcern:       s.readConcern,
 		middleware:        s.middleware,
+		circuitBreaker:    s.circuitBreaker,
+		hedging:           s.hedging,
+		autoBatching:      s.autoBatching,
+		channelSelection:  s.channelSelection,
+		concurrencyLimit:  s.concurrencyLimit,
+		healthMonitor:     s.healthMonitor,
 	}
 }
 
@@ -203,6 +344,12 @@ func (s *cacheConfiguration) WithNumGrpcChannels(numGrpcChannels uint32) Configu
 		numGrpcChannels:   numGrpcChannels,
 		readConcern:       s.readConcern,
 		middleware:        s.middleware,
+		circuitBreaker:    s.circuitBreaker,
+		hedging:           s.hedging,
+		autoBatching:      s.autoBatching,
+		channelSelection:  s.channelSelection,
+		concurrencyLimit:  s.concurrencyLimit,
+		healthMonitor:     s.healthMonitor,
 	}
 }
 
@@ -214,6 +361,138 @@ func (s *cacheConfiguration) WithReadConcern(readConcern ReadConcern) Configurat
 		numGrpcChannels:   s.numGrpcChannels,
 		readConcern:       readConcern,
 		middleware:        s.middleware,
+		circuitBreaker:    s.circuitBreaker,
+		hedging:           s.hedging,
+		autoBatching:      s.autoBatching,
+		channelSelection:  s.channelSelection,
+		concurrencyLimit:  s.concurrencyLimit,
+		healthMonitor:     s.healthMonitor,
+	}
+}
+
+func (s *cacheConfiguration) GetCircuitBreakerProps() *circuitbreaker.Props {
+	return s.circuitBreaker
+}
+
+func (s *cacheConfiguration) WithCircuitBreaker(props circuitbreaker.Props) Configuration {
+	return &cacheConfiguration{
+		loggerFactory:     s.loggerFactory,
+		transportStrategy: s.transportStrategy,
+		retryStrategy:     s.retryStrategy,
+		numGrpcChannels:   s.numGrpcChannels,
+		readConcern:       s.readConcern,
+		middleware:        s.middleware,
+		circuitBreaker:    &props,
+		hedging:           s.hedging,
+		autoBatching:      s.autoBatching,
+		channelSelection:  s.channelSelection,
+		concurrencyLimit:  s.concurrencyLimit,
+		healthMonitor:     s.healthMonitor,
+	}
+}
+
+func (s *cacheConfiguration) GetHedgingProps() *hedging.Props {
+	return s.hedging
+}
+
+func (s *cacheConfiguration) WithHedging(props hedging.Props) Configuration {
+	return &cacheConfiguration{
+		loggerFactory:     s.loggerFactory,
+		transportStrategy: s.transportStrategy,
+		retryStrategy:     s.retryStrategy,
+		numGrpcChannels:   s.numGrpcChannels,
+		readConcern:       s.readConcern,
+		middleware:        s.middleware,
+		circuitBreaker:    s.circuitBreaker,
+		hedging:           &props,
+		autoBatching:      s.autoBatching,
+		channelSelection:  s.channelSelection,
+		concurrencyLimit:  s.concurrencyLimit,
+		healthMonitor:     s.healthMonitor,
+	}
+}
+
+func (s *cacheConfiguration) GetAutoBatchingProps() *batching.Props {
+	return s.autoBatching
+}
+
+func (s *cacheConfiguration) WithAutoBatching(props batching.Props) Configuration {
+	return &cacheConfiguration{
+		loggerFactory:     s.loggerFactory,
+		transportStrategy: s.transportStrategy,
+		retryStrategy:     s.retryStrategy,
+		numGrpcChannels:   s.numGrpcChannels,
+		readConcern:       s.readConcern,
+		middleware:        s.middleware,
+		circuitBreaker:    s.circuitBreaker,
+		hedging:           s.hedging,
+		autoBatching:      &props,
+		channelSelection:  s.channelSelection,
+		concurrencyLimit:  s.concurrencyLimit,
+		healthMonitor:     s.healthMonitor,
+	}
+}
+
+func (s *cacheConfiguration) GetChannelSelectionProps() *channelselection.Props {
+	return s.channelSelection
+}
+
+func (s *cacheConfiguration) WithChannelSelection(props channelselection.Props) Configuration {
+	return &cacheConfiguration{
+		loggerFactory:     s.loggerFactory,
+		transportStrategy: s.transportStrategy,
+		retryStrategy:     s.retryStrategy,
+		numGrpcChannels:   s.numGrpcChannels,
+		readConcern:       s.readConcern,
+		middleware:        s.middleware,
+		circuitBreaker:    s.circuitBreaker,
+		hedging:           s.hedging,
+		autoBatching:      s.autoBatching,
+		channelSelection:  &props,
+		concurrencyLimit:  s.concurrencyLimit,
+		healthMonitor:     s.healthMonitor,
+	}
+}
+
+func (s *cacheConfiguration) GetConcurrencyLimiter() *concurrency.Limiter {
+	return s.concurrencyLimit
+}
+
+func (s *cacheConfiguration) WithConcurrencyLimiter(limiter *concurrency.Limiter) Configuration {
+	return &cacheConfiguration{
+		loggerFactory:     s.loggerFactory,
+		transportStrategy: s.transportStrategy,
+		retryStrategy:     s.retryStrategy,
+		numGrpcChannels:   s.numGrpcChannels,
+		readConcern:       s.readConcern,
+		middleware:        s.middleware,
+		circuitBreaker:    s.circuitBreaker,
+		hedging:           s.hedging,
+		autoBatching:      s.autoBatching,
+		channelSelection:  s.channelSelection,
+		concurrencyLimit:  limiter,
+		healthMonitor:     s.healthMonitor,
+	}
+}
+
+func (s *cacheConfiguration) GetHealthMonitorProps() *health.Props {
+	return s.healthMonitor
+}
+
+func (s *cacheConfiguration) WithHealthMonitor(props health.Props) Configuration {
+	return &cacheConfiguration{
+		loggerFactory:     s.loggerFactory,
+		transportStrategy: s.transportStrategy,
+		retryStrategy:     s.retryStrategy,
+		numGrpcChannels:   s.numGrpcChannels,
+		readConcern:       s.readConcern,
+		middleware:        s.middleware,
+		circuitBreaker:    s.circuitBreaker,
+		hedging:           s.hedging,
+		autoBatching:      s.autoBatching,
+		channelSelection:  s.channelSelection,
+		concurrencyLimit:  s.concurrencyLimit,
+		healthMonitor:     &props,
 	}
 }
 
@@ -0,0 +1,156 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// ErrIntegrityCheckFailed is returned when an encrypted value cannot be authenticated, either because
+// it was modified after it was written or because its header is malformed.
+var ErrIntegrityCheckFailed = errors.New("encrypted value failed integrity check")
+
+// EnvelopeFlags records properties of the original value that are lost once it has been encrypted.
+type EnvelopeFlags byte
+
+const (
+	// EnvelopeFlagText indicates the encrypted value was originally a utf-8 string rather than raw bytes.
+	// Topic messages use this to restore the original message kind on the subscriber side.
+	EnvelopeFlagText EnvelopeFlags = 0x01
+)
+
+const (
+	envelopeVersion byte = 0x01
+	maxKeyIdLength       = 255
+	nonceLength          = 12
+)
+
+// envelopeMagic is the byte sequence that begins an encrypted value. Like the compression frame, it
+// starts with a NUL byte, which typical text and binary payloads rarely begin with, so a collision with
+// an unencrypted value is unlikely.
+var envelopeMagic = []byte{0x00, 'M', 'E'}
+
+// deterministicNonceLabel separates the nonce derivation key from the encryption key.
+var deterministicNonceLabel = []byte("momento deterministic nonce")
+
+// Encryptor encrypts values with AES-GCM using keys from a KeyProvider. Each encrypted value is an
+// envelope of the form:
+//
+//	magic (3 bytes) | version (1 byte) | flags (1 byte) | key id length (1 byte) | key id | nonce (12 bytes) | ciphertext and tag
+//
+// The header up to and including the key id is authenticated along with the ciphertext, so neither the
+// flags nor the key id can be changed without the value failing its integrity check.
+type Encryptor struct {
+	keys KeyProvider
+}
+
+// NewEncryptor returns an Encryptor that uses keys from the given provider.
+func NewEncryptor(keys KeyProvider) *Encryptor {
+	return &Encryptor{keys: keys}
+}
+
+// Encrypt encrypts plaintext with the current key and a random nonce.
+func (e *Encryptor) Encrypt(plaintext []byte, flags EnvelopeFlags) ([]byte, error) {
+	return e.encrypt(plaintext, flags, false)
+}
+
+// EncryptDeterministic encrypts plaintext with the current key and a nonce derived from the plaintext,
+// so that equal values encrypt to equal envelopes. This is required for values that the server compares,
+// such as set elements, but reveals which of the values encrypted under the same key are equal.
+func (e *Encryptor) EncryptDeterministic(plaintext []byte, flags EnvelopeFlags) ([]byte, error) {
+	return e.encrypt(plaintext, flags, true)
+}
+
+func (e *Encryptor) encrypt(plaintext []byte, flags EnvelopeFlags, deterministic bool) ([]byte, error) {
+	key, err := e.keys.CurrentKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current encryption key: %w", err)
+	}
+	// The key id length is stored in a single byte, so a longer id would corrupt the envelope.
+	if err := key.validate(); err != nil {
+		return nil, fmt.Errorf("invalid current encryption key: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, len(envelopeMagic)+3+len(key.Id))
+	header = append(header, envelopeMagic...)
+	header = append(header, envelopeVersion, byte(flags), byte(len(key.Id)))
+	header = append(header, key.Id...)
+
+	nonce := make([]byte, nonceLength)
+	if deterministic {
+		mac := hmac.New(sha256.New, deriveNonceKey(key))
+		mac.Write(header)
+		mac.Write(plaintext)
+		copy(nonce, mac.Sum(nil))
+	} else if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	envelope := make([]byte, 0, len(header)+nonceLength+len(plaintext)+aead.Overhead())
+	envelope = append(envelope, header...)
+	envelope = append(envelope, nonce...)
+	return aead.Seal(envelope, nonce, plaintext, header), nil
+}
+
+// Decrypt returns the plaintext and flags of an envelope written by Encrypt or EncryptDeterministic.
+// It returns an error wrapping ErrIntegrityCheckFailed if the envelope has been tampered with, or
+// ErrKeyNotFound if it was encrypted with a key the provider does not have.
+func (e *Encryptor) Decrypt(data []byte) ([]byte, EnvelopeFlags, error) {
+	if !IsEncrypted(data) {
+		return nil, 0, fmt.Errorf("%w: missing envelope header", ErrIntegrityCheckFailed)
+	}
+	flags := EnvelopeFlags(data[len(envelopeMagic)+1])
+	keyIdLength := int(data[len(envelopeMagic)+2])
+	headerLength := len(envelopeMagic) + 3 + keyIdLength
+	if len(data) < headerLength+nonceLength {
+		return nil, 0, fmt.Errorf("%w: envelope is truncated", ErrIntegrityCheckFailed)
+	}
+
+	key, err := e.keys.GetKey(string(data[headerLength-keyIdLength : headerLength]))
+	if err != nil {
+		return nil, 0, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	header := data[:headerLength]
+	nonce := data[headerLength : headerLength+nonceLength]
+	plaintext, err := aead.Open(nil, nonce, data[headerLength+nonceLength:], header)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrIntegrityCheckFailed, err)
+	}
+	return plaintext, flags, nil
+}
+
+// IsEncrypted reports whether data begins with an envelope header written by an Encryptor.
+func IsEncrypted(data []byte) bool {
+	if len(data) < len(envelopeMagic)+3 {
+		return false
+	}
+	return bytes.Equal(data[:len(envelopeMagic)], envelopeMagic) && data[len(envelopeMagic)] == envelopeVersion
+}
+
+func newAEAD(key DataKey) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key.Material)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key %s: %w", key.Id, err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func deriveNonceKey(key DataKey) []byte {
+	mac := hmac.New(sha256.New, key.Material)
+	mac.Write(deterministicNonceLabel)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,119 @@
+package encryption
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrKeyNotFound is returned by a KeyProvider when it has no key with the requested id.
+var ErrKeyNotFound = errors.New("encryption key not found")
+
+// DataKey is a symmetric key used to encrypt values with AES-GCM.
+type DataKey struct {
+	// Id identifies the key. It is stored in the header of every value encrypted with the key, so that
+	// the key can be found again when the value is read. It must be between 1 and 255 bytes long.
+	Id string
+	// Material is the raw AES key: 16, 24 or 32 bytes for AES-128, AES-192 or AES-256.
+	Material []byte
+}
+
+func (k DataKey) validate() error {
+	if len(k.Id) == 0 || len(k.Id) > maxKeyIdLength {
+		return fmt.Errorf("key id must be between 1 and %d bytes, got %d", maxKeyIdLength, len(k.Id))
+	}
+	switch len(k.Material) {
+	case 16, 24, 32:
+		return nil
+	default:
+		return fmt.Errorf("key %s must be 16, 24 or 32 bytes, got %d", k.Id, len(k.Material))
+	}
+}
+
+// KeyProvider supplies the data keys used to encrypt and decrypt values.
+//
+// This is the extension point for envelope encryption: an implementation can keep its data keys wrapped
+// by a key management service, unwrap them on first use and cache the plaintext key material in memory.
+type KeyProvider interface {
+	// CurrentKey returns the key used to encrypt new values.
+	CurrentKey() (DataKey, error)
+	// GetKey returns the key with the given id, used to decrypt values. It returns an error wrapping
+	// ErrKeyNotFound if the key is unknown.
+	GetKey(id string) (DataKey, error)
+}
+
+// InMemoryKeyProvider is a KeyProvider that holds its keys in memory. Keys can be rotated while the
+// client is in use: values are always encrypted with the current key, and any key that has been added
+// can still be used to decrypt values until it is removed.
+type InMemoryKeyProvider struct {
+	mutex     sync.RWMutex
+	currentId string
+	keys      map[string]DataKey
+}
+
+// NewInMemoryKeyProvider returns a provider that encrypts with current and can also decrypt values
+// written with any of the previous keys. It returns an error if any of the keys is invalid.
+func NewInMemoryKeyProvider(current DataKey, previous ...DataKey) (*InMemoryKeyProvider, error) {
+	provider := &InMemoryKeyProvider{keys: make(map[string]DataKey)}
+	for _, key := range previous {
+		if err := provider.AddKey(key); err != nil {
+			return nil, err
+		}
+	}
+	if err := provider.Rotate(current); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// AddKey makes a key available for decryption without using it to encrypt new values. This is useful
+// for deploying a new key to every reader before any writer starts to use it.
+func (p *InMemoryKeyProvider) AddKey(key DataKey) error {
+	if err := key.validate(); err != nil {
+		return err
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.keys[key.Id] = key
+	return nil
+}
+
+// Rotate makes key the current key. The previous current key remains available for decryption.
+func (p *InMemoryKeyProvider) Rotate(key DataKey) error {
+	if err := key.validate(); err != nil {
+		return err
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.keys[key.Id] = key
+	p.currentId = key.Id
+	return nil
+}
+
+// RemoveKey retires a key once no values encrypted with it remain in the cache. The current key
+// cannot be removed.
+func (p *InMemoryKeyProvider) RemoveKey(id string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if id == p.currentId {
+		return fmt.Errorf("cannot remove the current key %s", id)
+	}
+	delete(p.keys, id)
+	return nil
+}
+
+func (p *InMemoryKeyProvider) CurrentKey() (DataKey, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.keys[p.currentId], nil
+}
+
+func (p *InMemoryKeyProvider) GetKey(id string) (DataKey, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	key, ok := p.keys[id]
+	if !ok {
+		return DataKey{}, fmt.Errorf("%w: %s", ErrKeyNotFound, id)
+	}
+	return key, nil
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+
+	"google.golang.org/grpc"
+)
+
+// GrpcDialer opens the connection to a server, e.g. through a tunnel or over a private network. The address
+// is the host and port of the endpoint being connected to.
+type GrpcDialer func(ctx context.Context, address string) (net.Conn, error)
+
+// grpcDialSettings holds the connection settings shared by GrpcConfiguration and TopicsGrpcConfiguration.
+type grpcDialSettings struct {
+	tlsConfig          *tls.Config
+	rootCAs            *x509.CertPool
+	clientCertificates []tls.Certificate
+	proxyUrl           *url.URL
+	dialer             GrpcDialer
+	dialOptions        []grpc.DialOption
+	// tlsServerNames maps endpoint host names to the server name sent to them for SNI.
+	tlsServerNames map[string]string
+}
+
+func (s grpcDialSettings) GetTlsConfig() *tls.Config {
+	return s.tlsConfig
+}
+
+func (s grpcDialSettings) GetRootCAs() *x509.CertPool {
+	return s.rootCAs
+}
+
+func (s grpcDialSettings) GetClientCertificates() []tls.Certificate {
+	return s.clientCertificates
+}
+
+func (s grpcDialSettings) GetProxyUrl() *url.URL {
+	return s.proxyUrl
+}
+
+func (s grpcDialSettings) GetDialer() GrpcDialer {
+	return s.dialer
+}
+
+func (s grpcDialSettings) GetDialOptions() []grpc.DialOption {
+	return s.dialOptions
+}
+
+func (s grpcDialSettings) GetTlsServerName(endpoint string) string {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		host = endpoint
+	}
+	return s.tlsServerNames[host]
+}
+
+// withTlsServerName returns a copy of the settings with the server name of the endpoint host set, leaving
+// the map of the original untouched.
+func (s grpcDialSettings) withTlsServerName(host string, serverName string) grpcDialSettings {
+	serverNames := make(map[string]string, len(s.tlsServerNames)+1)
+	for h, name := range s.tlsServerNames {
+		serverNames[h] = name
+	}
+	serverNames[host] = serverName
+	s.tlsServerNames = serverNames
+	return s
+}
+
+func (s grpcDialSettings) String() string {
+	proxy := ""
+	if s.proxyUrl != nil {
+		// The user info may hold proxy credentials.
+		proxy = s.proxyUrl.Redacted()
+	}
+	return fmt.Sprintf(
+		"tlsConfig=%v, rootCAs=%v, clientCertificates=%d, proxyUrl=%s, dialer=%v, dialOptions=%d, tlsServerNames=%v",
+		s.tlsConfig != nil, s.rootCAs != nil, len(s.clientCertificates), proxy, s.dialer != nil, len(s.dialOptions),
+		s.tlsServerNames,
+	)
+}
@@ -0,0 +1,457 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/momentohq/client-sdk-go/config/logger"
+)
+
+// State is the health of a channel or a client.
+type State string
+
+const (
+	// StateUnknown is the state of a channel that has not been checked yet.
+	StateUnknown State = "unknown"
+	// StateHealthy is the state of a channel whose pings succeed.
+	StateHealthy State = "healthy"
+	// StateUnhealthy is the state of a channel whose pings fail, or whose connection has failed.
+	StateUnhealthy State = "unhealthy"
+)
+
+const (
+	defaultInterval         = 10 * time.Second
+	defaultTimeout          = 5 * time.Second
+	defaultFailureThreshold = 2
+	defaultSuccessThreshold = 1
+)
+
+// Props configures a health monitor. The zero value of each field selects its default.
+type Props struct {
+	// Interval is the time between pings on each channel. Defaults to 10 seconds.
+	Interval time.Duration
+	// Timeout is how long a ping may take before it counts as failed. Defaults to 5 seconds, or Interval if
+	// it is shorter.
+	Timeout time.Duration
+	// FailureThreshold is the number of consecutive failed pings that make a channel unhealthy. A channel
+	// whose connection fails is unhealthy immediately. Defaults to 2.
+	FailureThreshold int
+	// SuccessThreshold is the number of consecutive successful pings that make a channel healthy again.
+	// Defaults to 1.
+	SuccessThreshold int
+	// OnHealthy, if set, is called when a channel becomes healthy.
+	OnHealthy func(channel ChannelHealth)
+	// OnUnhealthy, if set, is called when a channel becomes unhealthy.
+	OnUnhealthy func(channel ChannelHealth)
+	// OnStateChange, if set, is called when the state of the client as a whole changes.
+	OnStateChange func(health Health)
+	// Logger is used to log changes in health. Defaults to a no-op logger.
+	Logger logger.MomentoLogger
+}
+
+func (p Props) withDefaults() Props {
+	if p.Interval <= 0 {
+		p.Interval = defaultInterval
+	}
+	if p.Timeout <= 0 {
+		p.Timeout = defaultTimeout
+		if p.Interval < p.Timeout {
+			p.Timeout = p.Interval
+		}
+	}
+	if p.FailureThreshold <= 0 {
+		p.FailureThreshold = defaultFailureThreshold
+	}
+	if p.SuccessThreshold <= 0 {
+		p.SuccessThreshold = defaultSuccessThreshold
+	}
+	if p.Logger == nil {
+		p.Logger = logger.NewNoopMomentoLoggerFactory().GetLogger("health-monitor")
+	}
+	return p
+}
+
+func (p Props) String() string {
+	return fmt.Sprintf(
+		"HealthMonitorProps{Interval: %s, Timeout: %s, FailureThreshold: %d, SuccessThreshold: %d}",
+		p.Interval, p.Timeout, p.FailureThreshold, p.SuccessThreshold,
+	)
+}
+
+// ChannelHealth is the health of one channel.
+type ChannelHealth struct {
+	// Index is the position of the channel among the client's channels when the health was reported.
+	Index int
+	State State
+	// Connectivity is the state of the channel's gRPC connection.
+	Connectivity connectivity.State
+	// LastCheck is when the channel was last pinged, or the zero time if it has not been.
+	LastCheck time.Time
+	// LastLatency is how long the last successful ping took.
+	LastLatency time.Duration
+	// LastError is the error of the last failed ping or connection failure, or nil if there has not been one.
+	LastError error
+	// LastErrorAt is when LastError happened.
+	LastErrorAt time.Time
+	// ConsecutiveFailures is the number of failed pings since the last successful one.
+	ConsecutiveFailures int
+}
+
+func (c ChannelHealth) String() string {
+	return fmt.Sprintf(
+		"ChannelHealth{Index: %d, State: %s, Connectivity: %s, ConsecutiveFailures: %d, LastError: %v}",
+		c.Index, c.State, c.Connectivity, c.ConsecutiveFailures, c.LastError,
+	)
+}
+
+// Health is the health of a client's channels. The client is healthy if any of its channels is, since
+// requests avoid unhealthy channels, and unhealthy if all of them are.
+type Health struct {
+	State    State
+	Channels []ChannelHealth
+}
+
+func (h Health) String() string {
+	channels := make([]string, len(h.Channels))
+	for i, channel := range h.Channels {
+		channels[i] = channel.String()
+	}
+	return fmt.Sprintf("Health{State: %s, Channels: [%s]}", h.State, strings.Join(channels, ", "))
+}
+
+// Channel is a gRPC channel watched by a Monitor.
+type Channel interface {
+	// Ping sends a ping on the channel.
+	Ping(ctx context.Context) error
+	// ConnectivityState returns the state of the channel's connection.
+	ConnectivityState() connectivity.State
+	// WaitForStateChange waits until the state of the channel's connection differs from source, and
+	// returns false if ctx is done first.
+	WaitForStateChange(ctx context.Context, source connectivity.State) bool
+}
+
+// channelState is what a Monitor knows about a channel.
+type channelState struct {
+	health               ChannelHealth
+	consecutiveSuccesses int
+	stopWatching         context.CancelFunc
+}
+
+type connectivityChange struct {
+	channel Channel
+	state   connectivity.State
+}
+
+// Monitor periodically pings each of a client's channels and watches their connections, reporting
+// transitions between healthy and unhealthy. It is safe for concurrent use.
+type Monitor struct {
+	props    Props
+	channels func() []Channel
+
+	mutex  sync.Mutex
+	states map[Channel]*channelState
+	order  []Channel
+	state  State
+	// started is set by Start, so that Close knows whether to wait for the monitor's goroutine.
+	started bool
+
+	changes chan connectivityChange
+	// ctx is cancelled by Close to abandon a round of pings in progress.
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMonitor returns a Monitor of the channels returned by channels, which is called before each round of
+// pings so that the channels may change over time. Call Start to begin monitoring.
+func NewMonitor(props Props, channels func() []Channel) *Monitor {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Monitor{
+		props:    props.withDefaults(),
+		channels: channels,
+		states:   make(map[Channel]*channelState),
+		state:    StateUnknown,
+		changes:  make(chan connectivityChange),
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+}
+
+// Start pings the channels right away and then every Interval until Close is called.
+func (m *Monitor) Start() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.started {
+		return
+	}
+	m.started = true
+	go m.run()
+}
+
+// Close stops monitoring. No callback is called once it returns.
+func (m *Monitor) Close() {
+	m.closeOnce.Do(func() {
+		m.cancel()
+		m.mutex.Lock()
+		started := m.started
+		m.started = true
+		m.mutex.Unlock()
+		if started {
+			<-m.done
+		}
+	})
+}
+
+// Health returns the current health of the channels.
+func (m *Monitor) Health() Health {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.healthLocked()
+}
+
+func (m *Monitor) run() {
+	defer close(m.done)
+	defer m.stopWatching()
+	ticker := time.NewTicker(m.props.Interval)
+	defer ticker.Stop()
+	m.Check(m.ctx)
+	for {
+		select {
+		case <-ticker.C:
+			m.Check(m.ctx)
+		case change := <-m.changes:
+			m.onConnectivityChange(change)
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
+// Check pings every channel once and waits for the results. The monitor calls it every Interval; it is
+// exported so that a check can be forced.
+func (m *Monitor) Check(ctx context.Context) {
+	channels := m.syncChannels()
+	results := make([]error, len(channels))
+	latencies := make([]time.Duration, len(channels))
+	var wg sync.WaitGroup
+	for i, channel := range channels {
+		wg.Add(1)
+		go func(i int, channel Channel) {
+			defer wg.Done()
+			pingCtx, cancel := context.WithTimeout(ctx, m.props.Timeout)
+			defer cancel()
+			start := time.Now()
+			results[i] = channel.Ping(pingCtx)
+			latencies[i] = time.Since(start)
+		}(i, channel)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		// The pings were abandoned rather than failed.
+		return
+	}
+
+	now := time.Now()
+	var notify []func()
+	m.mutex.Lock()
+	for i, channel := range channels {
+		state, ok := m.states[channel]
+		if !ok {
+			// The channel was removed while it was pinged.
+			continue
+		}
+		notify = append(notify, m.recordPing(state, results[i], latencies[i], now)...)
+	}
+	notify = append(notify, m.updateClientState()...)
+	m.mutex.Unlock()
+
+	for _, callback := range notify {
+		callback()
+	}
+}
+
+// syncChannels starts watching new channels and forgets removed ones, and returns the current channels.
+func (m *Monitor) syncChannels() []Channel {
+	channels := m.channels()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	current := make(map[Channel]bool, len(channels))
+	for _, channel := range channels {
+		current[channel] = true
+		if _, ok := m.states[channel]; ok {
+			continue
+		}
+		ctx, cancel := context.WithCancel(m.ctx)
+		m.states[channel] = &channelState{
+			health:       ChannelHealth{State: StateUnknown, Connectivity: channel.ConnectivityState()},
+			stopWatching: cancel,
+		}
+		go m.watch(ctx, channel)
+	}
+	for channel, state := range m.states {
+		if !current[channel] {
+			state.stopWatching()
+			delete(m.states, channel)
+		}
+	}
+	m.order = channels
+	return channels
+}
+
+// watch reports the connectivity changes of a channel to the monitor's goroutine.
+func (m *Monitor) watch(ctx context.Context, channel Channel) {
+	state := channel.ConnectivityState()
+	for channel.WaitForStateChange(ctx, state) {
+		state = channel.ConnectivityState()
+		select {
+		case m.changes <- connectivityChange{channel: channel, state: state}:
+		case <-ctx.Done():
+			return
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *Monitor) stopWatching() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, state := range m.states {
+		state.stopWatching()
+	}
+}
+
+// onConnectivityChange marks a channel whose connection failed unhealthy without waiting for its pings to
+// fail, and pings a channel whose connection recovered.
+func (m *Monitor) onConnectivityChange(change connectivityChange) {
+	m.mutex.Lock()
+	state, ok := m.states[change.channel]
+	if !ok {
+		m.mutex.Unlock()
+		return
+	}
+	state.health.Connectivity = change.state
+	var notify []func()
+	switch change.state {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		state.consecutiveSuccesses = 0
+		state.health.LastError = fmt.Errorf("connection entered %s", change.state)
+		state.health.LastErrorAt = time.Now()
+		notify = append(notify, m.transition(state, StateUnhealthy)...)
+		notify = append(notify, m.updateClientState()...)
+	}
+	recovered := change.state == connectivity.Ready && state.health.State == StateUnhealthy
+	m.mutex.Unlock()
+
+	for _, callback := range notify {
+		callback()
+	}
+	if recovered {
+		m.Check(m.ctx)
+	}
+}
+
+// recordPing updates a channel with the result of a ping. It must be called with the mutex held, and
+// returns the callbacks to call once it is released.
+func (m *Monitor) recordPing(state *channelState, err error, latency time.Duration, now time.Time) []func() {
+	state.health.LastCheck = now
+	if err != nil {
+		state.consecutiveSuccesses = 0
+		state.health.ConsecutiveFailures++
+		state.health.LastError = err
+		state.health.LastErrorAt = now
+		if state.health.ConsecutiveFailures >= m.props.FailureThreshold {
+			return m.transition(state, StateUnhealthy)
+		}
+		return nil
+	}
+	state.health.ConsecutiveFailures = 0
+	state.health.LastLatency = latency
+	state.consecutiveSuccesses++
+	if state.health.State == StateUnknown || state.consecutiveSuccesses >= m.props.SuccessThreshold {
+		return m.transition(state, StateHealthy)
+	}
+	return nil
+}
+
+// transition moves a channel to a new state. It must be called with the mutex held, and returns the
+// callbacks to call once it is released.
+func (m *Monitor) transition(state *channelState, to State) []func() {
+	from := state.health.State
+	if from == to {
+		return nil
+	}
+	state.health.State = to
+	health := state.health
+	health.Index = m.indexOf(state)
+	if to == StateUnhealthy {
+		m.props.Logger.Warn("gRPC channel %d became unhealthy: %v", health.Index, health.LastError)
+		if m.props.OnUnhealthy != nil {
+			return []func(){func() { m.props.OnUnhealthy(health) }}
+		}
+		return nil
+	}
+	if from == StateUnhealthy {
+		m.props.Logger.Info("gRPC channel %d became healthy", health.Index)
+	}
+	if m.props.OnHealthy != nil {
+		return []func(){func() { m.props.OnHealthy(health) }}
+	}
+	return nil
+}
+
+// updateClientState recomputes the state of the client. It must be called with the mutex held, and returns
+// the callbacks to call once it is released.
+func (m *Monitor) updateClientState() []func() {
+	health := m.healthLocked()
+	if health.State == m.state {
+		return nil
+	}
+	m.state = health.State
+	m.props.Logger.Info("Client health changed to %s", health.State)
+	if m.props.OnStateChange != nil {
+		return []func(){func() { m.props.OnStateChange(health) }}
+	}
+	return nil
+}
+
+func (m *Monitor) indexOf(state *channelState) int {
+	for i, channel := range m.order {
+		if m.states[channel] == state {
+			return i
+		}
+	}
+	return -1
+}
+
+// healthLocked must be called with the mutex held.
+func (m *Monitor) healthLocked() Health {
+	health := Health{State: StateUnknown, Channels: make([]ChannelHealth, 0, len(m.order))}
+	unhealthy := 0
+	for i, channel := range m.order {
+		state, ok := m.states[channel]
+		if !ok {
+			continue
+		}
+		channelHealth := state.health
+		channelHealth.Index = i
+		health.Channels = append(health.Channels, channelHealth)
+		switch channelHealth.State {
+		case StateHealthy:
+			health.State = StateHealthy
+		case StateUnhealthy:
+			unhealthy++
+		}
+	}
+	if health.State != StateHealthy && unhealthy > 0 && unhealthy == len(health.Channels) {
+		health.State = StateUnhealthy
+	}
+	return health
+}
@@ -0,0 +1,199 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/momentohq/client-sdk-go/config/health"
+)
+
+type fakeChannel struct {
+	mutex   sync.Mutex
+	pingErr error
+	state   connectivity.State
+	changed chan struct{}
+}
+
+func newFakeChannel() *fakeChannel {
+	return &fakeChannel{state: connectivity.Ready, changed: make(chan struct{})}
+}
+
+func (c *fakeChannel) Ping(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.pingErr
+}
+
+func (c *fakeChannel) setPingErr(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.pingErr = err
+}
+
+func (c *fakeChannel) ConnectivityState() connectivity.State {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.state
+}
+
+func (c *fakeChannel) WaitForStateChange(ctx context.Context, source connectivity.State) bool {
+	for {
+		c.mutex.Lock()
+		state, changed := c.state, c.changed
+		c.mutex.Unlock()
+		if state != source {
+			return true
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+func (c *fakeChannel) setState(state connectivity.State) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.state = state
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+type recorder struct {
+	mutex  sync.Mutex
+	events []string
+}
+
+func (r *recorder) record(event string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) get() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string{}, r.events...)
+}
+
+var _ = Describe("health-monitor", func() {
+	var (
+		channels []*fakeChannel
+		events   *recorder
+		props    health.Props
+	)
+
+	BeforeEach(func() {
+		channels = []*fakeChannel{newFakeChannel(), newFakeChannel()}
+		events = &recorder{}
+		props = health.Props{
+			Interval:         time.Hour,
+			FailureThreshold: 2,
+			OnHealthy: func(channel health.ChannelHealth) {
+				events.record("healthy " + string(rune('0'+channel.Index)))
+			},
+			OnUnhealthy: func(channel health.ChannelHealth) {
+				events.record("unhealthy " + string(rune('0'+channel.Index)))
+			},
+			OnStateChange: func(h health.Health) {
+				events.record("client " + string(h.State))
+			},
+		}
+	})
+
+	newMonitor := func() *health.Monitor {
+		return health.NewMonitor(props, func() []health.Channel {
+			result := make([]health.Channel, len(channels))
+			for i, channel := range channels {
+				result[i] = channel
+			}
+			return result
+		})
+	}
+
+	It("is unknown until the channels are checked", func() {
+		monitor := newMonitor()
+		Expect(monitor.Health().State).To(Equal(health.StateUnknown))
+		monitor.Check(context.Background())
+		h := monitor.Health()
+		Expect(h.State).To(Equal(health.StateHealthy))
+		Expect(h.Channels).To(HaveLen(2))
+		Expect(h.Channels[1].Index).To(Equal(1))
+		Expect(h.Channels[1].Connectivity).To(Equal(connectivity.Ready))
+		Expect(h.Channels[1].LastCheck).NotTo(BeZero())
+		Expect(events.get()).To(Equal([]string{"healthy 0", "healthy 1", "client healthy"}))
+	})
+
+	It("marks a channel unhealthy after consecutive failed pings", func() {
+		monitor := newMonitor()
+		monitor.Check(context.Background())
+		pingErr := errors.New("unavailable")
+		channels[0].setPingErr(pingErr)
+
+		monitor.Check(context.Background())
+		Expect(monitor.Health().Channels[0].State).To(Equal(health.StateHealthy))
+		Expect(monitor.Health().Channels[0].ConsecutiveFailures).To(Equal(1))
+		Expect(monitor.Health().Channels[0].LastError).To(Equal(pingErr))
+
+		monitor.Check(context.Background())
+		h := monitor.Health()
+		Expect(h.Channels[0].State).To(Equal(health.StateUnhealthy))
+		Expect(h.State).To(Equal(health.StateHealthy))
+
+		channels[1].setPingErr(pingErr)
+		monitor.Check(context.Background())
+		monitor.Check(context.Background())
+		Expect(monitor.Health().State).To(Equal(health.StateUnhealthy))
+
+		channels[0].setPingErr(nil)
+		monitor.Check(context.Background())
+		Expect(monitor.Health().Channels[0].State).To(Equal(health.StateHealthy))
+		Expect(monitor.Health().Channels[0].LastError).To(Equal(pingErr))
+		Expect(events.get()[3:]).To(Equal([]string{
+			"unhealthy 0", "unhealthy 1", "client unhealthy", "healthy 0", "client healthy",
+		}))
+	})
+
+	It("marks a channel unhealthy as soon as its connection fails", func() {
+		monitor := newMonitor()
+		monitor.Start()
+		defer monitor.Close()
+		Eventually(func() health.State { return monitor.Health().State }).Should(Equal(health.StateHealthy))
+
+		channels[0].setState(connectivity.TransientFailure)
+		Eventually(func() health.State { return monitor.Health().Channels[0].State }).Should(Equal(health.StateUnhealthy))
+		Expect(monitor.Health().Channels[0].Connectivity).To(Equal(connectivity.TransientFailure))
+
+		channels[0].setState(connectivity.Ready)
+		Eventually(func() health.State { return monitor.Health().Channels[0].State }).Should(Equal(health.StateHealthy))
+	})
+
+	It("follows the channels as they change", func() {
+		monitor := newMonitor()
+		monitor.Check(context.Background())
+		channels = channels[1:]
+		monitor.Check(context.Background())
+		Expect(monitor.Health().Channels).To(HaveLen(1))
+		channels = append(channels, newFakeChannel())
+		monitor.Check(context.Background())
+		Expect(monitor.Health().Channels).To(HaveLen(2))
+		Expect(monitor.Health().Channels[1].State).To(Equal(health.StateHealthy))
+	})
+
+	It("calls no callbacks once closed", func() {
+		monitor := newMonitor()
+		monitor.Start()
+		Eventually(func() health.State { return monitor.Health().State }).Should(Equal(health.StateHealthy))
+		monitor.Close()
+		count := len(events.get())
+		channels[0].setState(connectivity.TransientFailure)
+		Consistently(func() int { return len(events.get()) }, 50*time.Millisecond).Should(Equal(count))
+	})
+})
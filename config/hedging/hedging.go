@@ -0,0 +1,143 @@
+package hedging
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPercentile    = 0.95
+	defaultMinimumDelay  = 5 * time.Millisecond
+	defaultBudgetRatio   = 0.05
+	defaultMaxBudget     = 10
+	latencySampleSize    = 512
+	minimumLatencySample = 64
+	recomputeInterval    = 64
+	// budgetEpsilon absorbs the rounding error of adding up fractional budget ratios.
+	budgetEpsilon = 1e-9
+)
+
+// Props configures hedged reads. A hedged read sends a duplicate of a slow read request on a different
+// connection and returns whichever response succeeds first. Only idempotent requests are hedged, as
+// determined by retry.IsIdempotentMethod.
+type Props struct {
+	// Delay is how long to wait for a response before sending the hedge request. If it is zero, the delay
+	// is the Percentile of the latencies observed by the client instead.
+	Delay time.Duration
+	// Percentile of observed latencies, between 0 and 1, used as the delay when Delay is zero. Defaults to
+	// 0.95. No requests are hedged until enough latencies have been observed.
+	Percentile float64
+	// MinimumDelay is the lower bound of the delay computed from observed latencies. Defaults to 5ms.
+	MinimumDelay time.Duration
+	// BudgetRatio limits hedge requests to this fraction of all eligible requests, so that hedging cannot
+	// double the load on a service that is slow because it is overloaded. Defaults to 0.05.
+	BudgetRatio float64
+	// MaxBudget is the number of hedge requests that can be sent in a burst when the budget has built up.
+	// Defaults to 10.
+	MaxBudget float64
+}
+
+func (p Props) withDefaults() Props {
+	if p.Percentile <= 0 || p.Percentile >= 1 {
+		p.Percentile = defaultPercentile
+	}
+	if p.MinimumDelay <= 0 {
+		p.MinimumDelay = defaultMinimumDelay
+	}
+	if p.BudgetRatio <= 0 {
+		p.BudgetRatio = defaultBudgetRatio
+	}
+	if p.MaxBudget <= 0 {
+		p.MaxBudget = defaultMaxBudget
+	}
+	return p
+}
+
+func (p Props) String() string {
+	return fmt.Sprintf(
+		"HedgingProps{Delay: %s, Percentile: %v, MinimumDelay: %s, BudgetRatio: %v, MaxBudget: %v}",
+		p.Delay, p.Percentile, p.MinimumDelay, p.BudgetRatio, p.MaxBudget,
+	)
+}
+
+// Hedger decides when read requests should be hedged. It tracks the latency of requests and the hedge
+// budget of a single client, and is safe for concurrent use.
+type Hedger struct {
+	props Props
+	mutex sync.Mutex
+	// budget is the number of hedge requests that may currently be sent.
+	budget float64
+	// latencies is a ring buffer of recent request latencies.
+	latencies     []time.Duration
+	nextLatency   int
+	sinceComputed int
+	delay         time.Duration
+}
+
+// New returns a Hedger with the given props.
+func New(props Props) *Hedger {
+	return &Hedger{
+		props:     props.withDefaults(),
+		latencies: make([]time.Duration, 0, latencySampleSize),
+	}
+}
+
+// Delay returns how long to wait before hedging a request, and false if not enough latencies have been
+// observed to compute it yet.
+func (h *Hedger) Delay() (time.Duration, bool) {
+	if h.props.Delay > 0 {
+		return h.props.Delay, true
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.delay, h.delay > 0
+}
+
+// RecordLatency records the latency of a successful request. Each call also adds to the hedge budget.
+func (h *Hedger) RecordLatency(latency time.Duration) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.budget += h.props.BudgetRatio
+	if h.budget > h.props.MaxBudget {
+		h.budget = h.props.MaxBudget
+	}
+	if h.props.Delay > 0 {
+		return
+	}
+
+	if len(h.latencies) < latencySampleSize {
+		h.latencies = append(h.latencies, latency)
+	} else {
+		h.latencies[h.nextLatency] = latency
+		h.nextLatency = (h.nextLatency + 1) % latencySampleSize
+	}
+	h.sinceComputed++
+	if len(h.latencies) >= minimumLatencySample && h.sinceComputed >= recomputeInterval {
+		h.sinceComputed = 0
+		h.delay = h.percentile()
+	}
+}
+
+// TryAcquire takes one hedge request from the budget, and returns false if the budget is exhausted.
+func (h *Hedger) TryAcquire() bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.budget+budgetEpsilon < 1 {
+		return false
+	}
+	h.budget--
+	return true
+}
+
+func (h *Hedger) percentile() time.Duration {
+	sorted := make([]time.Duration, len(h.latencies))
+	copy(sorted, h.latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	delay := sorted[int(float64(len(sorted)-1)*h.props.Percentile)]
+	if delay < h.props.MinimumDelay {
+		return h.props.MinimumDelay
+	}
+	return delay
+}
@@ -0,0 +1,13 @@
+package hedging_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHedging(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Hedging Suite")
+}
@@ -0,0 +1,68 @@
+package hedging_test
+
+import (
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config/hedging"
+	"github.com/momentohq/client-sdk-go/config/retry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("hedging", func() {
+	It("uses a fixed delay when one is configured", func() {
+		hedger := hedging.New(hedging.Props{Delay: 20 * time.Millisecond})
+		delay, ok := hedger.Delay()
+		Expect(ok).To(BeTrue())
+		Expect(delay).To(Equal(20 * time.Millisecond))
+	})
+
+	It("computes the delay from observed latencies", func() {
+		hedger := hedging.New(hedging.Props{Percentile: 0.9})
+		_, ok := hedger.Delay()
+		Expect(ok).To(BeFalse())
+
+		for i := 1; i <= 128; i++ {
+			hedger.RecordLatency(time.Duration(i) * time.Millisecond)
+		}
+		delay, ok := hedger.Delay()
+		Expect(ok).To(BeTrue())
+		Expect(delay).To(BeNumerically("~", 115*time.Millisecond, time.Millisecond))
+	})
+
+	It("does not go below the minimum delay", func() {
+		hedger := hedging.New(hedging.Props{MinimumDelay: 10 * time.Millisecond})
+		for i := 0; i < 100; i++ {
+			hedger.RecordLatency(time.Millisecond)
+		}
+		delay, ok := hedger.Delay()
+		Expect(ok).To(BeTrue())
+		Expect(delay).To(Equal(10 * time.Millisecond))
+	})
+
+	It("limits hedges to the budget", func() {
+		hedger := hedging.New(hedging.Props{Delay: time.Millisecond, BudgetRatio: 0.1, MaxBudget: 2})
+		Expect(hedger.TryAcquire()).To(BeFalse())
+
+		for i := 0; i < 10; i++ {
+			hedger.RecordLatency(time.Millisecond)
+		}
+		Expect(hedger.TryAcquire()).To(BeTrue())
+		Expect(hedger.TryAcquire()).To(BeFalse())
+
+		for i := 0; i < 100; i++ {
+			hedger.RecordLatency(time.Millisecond)
+		}
+		Expect(hedger.TryAcquire()).To(BeTrue())
+		Expect(hedger.TryAcquire()).To(BeTrue())
+		Expect(hedger.TryAcquire()).To(BeFalse())
+	})
+
+	It("only treats idempotent methods as eligible", func() {
+		Expect(retry.IsIdempotentMethod("/cache_client.Scs/Get")).To(BeTrue())
+		Expect(retry.IsIdempotentMethod("/cache_client.Scs/GetBatch")).To(BeTrue())
+		Expect(retry.IsIdempotentMethod("/cache_client.Scs/DictionaryGet")).To(BeTrue())
+		Expect(retry.IsIdempotentMethod("/cache_client.Scs/Increment")).To(BeFalse())
+		Expect(retry.IsIdempotentMethod("/cache_client.Scs/ListPopFront")).To(BeFalse())
+	})
+})
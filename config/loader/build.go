@@ -0,0 +1,327 @@
+package loader
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/config/compression"
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/config/logger/momento_default_logger"
+	"github.com/momentohq/client-sdk-go/config/middleware"
+	"github.com/momentohq/client-sdk-go/config/middleware/impl"
+	"github.com/momentohq/client-sdk-go/config/retry"
+)
+
+var logLevels = map[LogLevel]momento_default_logger.LogLevel{
+	LogLevelTrace: momento_default_logger.TRACE,
+	LogLevelDebug: momento_default_logger.DEBUG,
+	LogLevelInfo:  momento_default_logger.INFO,
+	LogLevelWarn:  momento_default_logger.WARN,
+	LogLevelError: momento_default_logger.ERROR,
+}
+
+func (s *Spec) loggerFactory() logger.MomentoLoggerFactory {
+	if s.LoggerFactory != nil {
+		return s.LoggerFactory
+	}
+	level, ok := logLevels[s.LogLevel]
+	if !ok {
+		level = momento_default_logger.INFO
+	}
+	return momento_default_logger.NewDefaultMomentoLoggerFactory(level)
+}
+
+// CacheConfiguration builds the configuration of a cache client from the cache section.
+func (s *Spec) CacheConfiguration() (config.Configuration, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	loggerFactory := s.loggerFactory()
+	spec := s.Cache
+	if spec == nil {
+		spec = &CacheSpec{}
+	}
+
+	var cfg config.Configuration
+	switch spec.Profile {
+	case ProfileInRegion:
+		cfg = config.InRegionLatestWithLogger(loggerFactory)
+	case ProfileLambda:
+		cfg = config.LambdaLatestWithLogger(loggerFactory)
+	default:
+		cfg = config.LaptopLatestWithLogger(loggerFactory)
+	}
+	if spec.ClientTimeout != 0 {
+		cfg = cfg.WithClientTimeout(time.Duration(spec.ClientTimeout))
+	}
+	if spec.NumGrpcChannels != 0 {
+		cfg = cfg.WithNumGrpcChannels(spec.NumGrpcChannels)
+	}
+	if spec.ReadConcern != "" {
+		cfg = cfg.WithReadConcern(spec.ReadConcern)
+	}
+	transportStrategy := cfg.GetTransportStrategy()
+	transportStrategy = transportStrategy.WithGrpcConfig(applyGrpc(transportStrategy.GetGrpcConfig(), spec.Grpc))
+	if static, ok := transportStrategy.(*config.StaticTransportStrategy); ok && spec.MaxIdle != 0 {
+		transportStrategy = static.WithMaxIdle(time.Duration(spec.MaxIdle))
+	}
+	cfg = cfg.WithTransportStrategy(transportStrategy)
+	if spec.Retry != nil {
+		cfg = cfg.WithRetryStrategy(buildRetryStrategy(spec.Retry, loggerFactory))
+	}
+	if spec.Middleware != nil {
+		cfg = cfg.WithMiddleware(buildMiddleware(spec.Middleware, loggerFactory))
+	}
+	return cfg, nil
+}
+
+// TopicsConfiguration builds the configuration of a topic client from the topics section.
+func (s *Spec) TopicsConfiguration() (config.TopicsConfiguration, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	loggerFactory := s.loggerFactory()
+	cfg := config.TopicsDefaultWithLogger(loggerFactory)
+	spec := s.Topics
+	if spec == nil {
+		return cfg, nil
+	}
+
+	transportStrategy := cfg.GetTransportStrategy()
+	if spec.ClientTimeout != 0 {
+		transportStrategy = transportStrategy.WithClientTimeout(time.Duration(spec.ClientTimeout))
+	}
+	transportStrategy = transportStrategy.WithGrpcConfig(applyTopicsGrpc(transportStrategy.GetGrpcConfig(), spec.Grpc))
+	cfg = cfg.WithTransportStrategy(transportStrategy)
+	if spec.NumStreamGrpcChannels != 0 {
+		cfg = cfg.WithNumStreamGrpcChannels(spec.NumStreamGrpcChannels)
+	}
+	if spec.NumUnaryGrpcChannels != 0 {
+		cfg = cfg.WithNumUnaryGrpcChannels(spec.NumUnaryGrpcChannels)
+	}
+	if spec.MaxSubscriptions != 0 {
+		cfg = cfg.WithMaxSubscriptions(spec.MaxSubscriptions)
+	}
+	if spec.ReconnectDelay != 0 {
+		reconnectMs := int(time.Duration(spec.ReconnectDelay).Milliseconds())
+		cfg = cfg.WithRetryStrategy(retry.NewLegacyTopicSubscriptionRetryStrategy(retry.LegacyTopicSubscriptionRetryStrategyProps{
+			LoggerFactory: loggerFactory,
+			RetryMs:       &reconnectMs,
+		}))
+	}
+	if spec.Middleware != nil {
+		cfg = cfg.WithMiddleware(buildTopicsMiddleware(spec.Middleware, loggerFactory))
+	}
+	return cfg, nil
+}
+
+// LeaderboardConfiguration builds the configuration of a leaderboard client from the leaderboard section.
+func (s *Spec) LeaderboardConfiguration() (config.LeaderboardConfiguration, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	cfg := config.LeaderboardDefaultWithLogger(s.loggerFactory())
+	spec := s.Leaderboard
+	if spec == nil {
+		return cfg, nil
+	}
+	if spec.ClientTimeout != 0 {
+		cfg = cfg.WithClientTimeout(time.Duration(spec.ClientTimeout))
+	}
+	transportStrategy := cfg.GetTransportStrategy()
+	return cfg.WithTransportStrategy(transportStrategy.WithGrpcConfig(applyGrpc(transportStrategy.GetGrpcConfig(), spec.Grpc))), nil
+}
+
+// StorageConfiguration builds the configuration of a storage client from the storage section.
+func (s *Spec) StorageConfiguration() (config.StorageConfiguration, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	cfg := config.StorageLaptopLatestWithLogger(s.loggerFactory())
+	spec := s.Storage
+	if spec == nil {
+		return cfg, nil
+	}
+	if spec.ClientTimeout != 0 {
+		cfg = cfg.WithClientTimeout(time.Duration(spec.ClientTimeout))
+	}
+	if spec.NumGrpcChannels != 0 {
+		cfg = cfg.WithNumGrpcChannels(spec.NumGrpcChannels)
+	}
+	transportStrategy := cfg.GetTransportStrategy()
+	return cfg.WithTransportStrategy(transportStrategy.WithGrpcConfig(applyGrpc(transportStrategy.GetGrpcConfig(), spec.Grpc))), nil
+}
+
+// AuthConfiguration builds the configuration of an auth client from the auth section.
+func (s *Spec) AuthConfiguration() (config.AuthConfiguration, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	cfg := config.AuthDefaultWithLogger(s.loggerFactory())
+	if s.Auth == nil {
+		return cfg, nil
+	}
+	transportStrategy := cfg.GetTransportStrategy()
+	return cfg.WithTransportStrategy(transportStrategy.WithGrpcConfig(applyGrpc(transportStrategy.GetGrpcConfig(), s.Auth.Grpc))), nil
+}
+
+func applyGrpc(grpcConfig config.GrpcConfiguration, spec *GrpcSpec) config.GrpcConfiguration {
+	if spec == nil {
+		return grpcConfig
+	}
+	if keepAlive := spec.KeepAlive; keepAlive != nil {
+		if keepAlive.Disabled {
+			grpcConfig = grpcConfig.WithKeepAliveDisabled()
+		}
+		if keepAlive.PermitWithoutCalls != nil {
+			grpcConfig = grpcConfig.WithKeepAlivePermitWithoutCalls(*keepAlive.PermitWithoutCalls)
+		}
+		if keepAlive.Time != 0 {
+			grpcConfig = grpcConfig.WithKeepAliveTime(time.Duration(keepAlive.Time))
+		}
+		if keepAlive.Timeout != 0 {
+			grpcConfig = grpcConfig.WithKeepAliveTimeout(time.Duration(keepAlive.Timeout))
+		}
+	}
+	if spec.ProxyUrl != "" {
+		// The URL was checked by Validate.
+		proxyUrl, _ := url.Parse(spec.ProxyUrl)
+		grpcConfig = grpcConfig.WithProxyUrl(proxyUrl)
+	}
+	return grpcConfig
+}
+
+func applyTopicsGrpc(grpcConfig config.TopicsGrpcConfiguration, spec *GrpcSpec) config.TopicsGrpcConfiguration {
+	if spec == nil {
+		return grpcConfig
+	}
+	if keepAlive := spec.KeepAlive; keepAlive != nil {
+		if keepAlive.Disabled {
+			grpcConfig = grpcConfig.WithKeepAliveDisabled()
+		}
+		if keepAlive.PermitWithoutCalls != nil {
+			grpcConfig = grpcConfig.WithKeepAlivePermitWithoutCalls(*keepAlive.PermitWithoutCalls)
+		}
+		if keepAlive.Time != 0 {
+			grpcConfig = grpcConfig.WithKeepAliveTime(time.Duration(keepAlive.Time))
+		}
+		if keepAlive.Timeout != 0 {
+			grpcConfig = grpcConfig.WithKeepAliveTimeout(time.Duration(keepAlive.Timeout))
+		}
+	}
+	if spec.ProxyUrl != "" {
+		proxyUrl, _ := url.Parse(spec.ProxyUrl)
+		grpcConfig = grpcConfig.WithProxyUrl(proxyUrl)
+	}
+	return grpcConfig
+}
+
+func buildRetryStrategy(spec *RetrySpec, loggerFactory logger.MomentoLoggerFactory) retry.Strategy {
+	var strategy retry.Strategy
+	switch spec.Strategy {
+	case RetryFixedCount:
+		strategy = retry.NewFixedCountRetryStrategy(retry.FixedCountRetryStrategyProps{
+			LoggerFactory: loggerFactory,
+			MaxAttempts:   spec.MaxAttempts,
+		})
+	case RetryTimeoutAwareFixedCount:
+		strategy = retry.NewTimeoutAwareFixedCountRetryStrategy(retry.TimeoutAwareFixedCountRetryStrategyProps{
+			LoggerFactory:   loggerFactory,
+			MaxAttempts:     spec.MaxAttempts,
+			TimeoutDuration: time.Duration(spec.AttemptTimeout),
+		})
+	case RetryExponentialBackoff:
+		strategy = retry.NewExponentialBackoffRetryStrategy(retry.ExponentialBackoffRetryStrategyProps{
+			LoggerFactory:      loggerFactory,
+			InitialDelayMillis: float64(spec.InitialDelay) / float64(time.Millisecond),
+			MaxBackoffMillis:   int(time.Duration(spec.MaxBackoff).Milliseconds()),
+			GrowthFactor:       spec.GrowthFactor,
+		})
+	case RetryFixedTimeout:
+		strategy = retry.NewFixedTimeoutRetryStrategy(retry.FixedTimeoutRetryStrategyProps{
+			LoggerFactory:            loggerFactory,
+			RetryTimeoutMillis:       int(time.Duration(spec.RetryTimeout).Milliseconds()),
+			RetryDelayIntervalMillis: int(time.Duration(spec.RetryDelayInterval).Milliseconds()),
+		})
+	default:
+		return retry.NewNeverRetryStrategy()
+	}
+	if spec.Budget != nil {
+		strategy = retry.NewRetryBudgetStrategy(retry.RetryBudgetStrategyProps{
+			LoggerFactory: loggerFactory,
+			Strategy:      strategy,
+			Budget: retry.NewRetryBudget(retry.RetryBudgetProps{
+				Ratio:     spec.Budget.Ratio,
+				MaxTokens: spec.Budget.MaxTokens,
+			}),
+		})
+	}
+	return strategy
+}
+
+func compressorFactory(algorithm CompressionAlgorithm) compression.CompressionStrategyFactory {
+	switch algorithm {
+	case CompressionZstd:
+		return impl.ZstdCompressorFactory{}
+	case CompressionLz4:
+		return impl.Lz4CompressorFactory{}
+	case CompressionSnappy:
+		return impl.SnappyCompressorFactory{}
+	default:
+		return impl.GzipCompressorFactory{}
+	}
+}
+
+func compressionProps(spec *CompressionSpec, loggerFactory logger.MomentoLoggerFactory) compression.CompressionStrategyProps {
+	level := compression.CompressionLevel(spec.Level)
+	if level == "" {
+		level = compression.CompressionLevelDefault
+	}
+	return compression.CompressionStrategyProps{
+		CompressionLevel: level,
+		Logger:           loggerFactory.GetLogger("compression-middleware"),
+		MinimumSizeBytes: spec.MinimumSizeBytes,
+	}
+}
+
+func buildMiddleware(spec *MiddlewareSpec, loggerFactory logger.MomentoLoggerFactory) []middleware.Middleware {
+	var middlewares []middleware.Middleware
+	if spec.Namespace != nil {
+		middlewares = append(middlewares, impl.NewNamespaceMiddleware(impl.NamespaceMiddlewareProps{
+			Logger:    loggerFactory.GetLogger("namespace-middleware"),
+			Namespace: spec.Namespace.Namespace,
+			Separator: spec.Namespace.Separator,
+		}))
+	}
+	if spec.Compression != nil {
+		middlewares = append(middlewares, impl.NewCompressionMiddleware(impl.CompressionMiddlewareProps{
+			CompressorFactory:        compressorFactory(spec.Compression.Algorithm),
+			CompressionStrategyProps: compressionProps(spec.Compression, loggerFactory),
+		}))
+	}
+	if spec.InFlightRequestCount {
+		middlewares = append(middlewares, impl.NewInFlightRequestCountMiddleware(middleware.Props{
+			Logger: loggerFactory.GetLogger("in-flight-request-count-middleware"),
+		}))
+	}
+	return middlewares
+}
+
+func buildTopicsMiddleware(spec *TopicsMiddlewareSpec, loggerFactory logger.MomentoLoggerFactory) []middleware.TopicMiddleware {
+	var middlewares []middleware.TopicMiddleware
+	if spec.Namespace != nil {
+		middlewares = append(middlewares, impl.NewTopicNamespaceMiddleware(impl.NamespaceMiddlewareProps{
+			Logger:    loggerFactory.GetLogger("namespace-middleware"),
+			Namespace: spec.Namespace.Namespace,
+			Separator: spec.Namespace.Separator,
+		}))
+	}
+	if spec.Compression != nil {
+		middlewares = append(middlewares, impl.NewTopicCompressionMiddleware(impl.TopicCompressionMiddlewareProps{
+			CompressorFactory:        compressorFactory(spec.Compression.Algorithm),
+			CompressionStrategyProps: compressionProps(spec.Compression, loggerFactory),
+		}))
+	}
+	return middlewares
+}
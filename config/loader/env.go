@@ -0,0 +1,185 @@
+package loader
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const envPrefix = "MOMENTO_"
+
+// envSetting is a setting that can be set by an environment variable. index is the path of struct field
+// indexes from Spec to the setting.
+type envSetting struct {
+	index []int
+	typ   reflect.Type
+}
+
+// envSettings maps the name of each environment variable to its setting. The name is the path of the
+// setting in a file, upper-cased, with its elements joined by underscores and prefixed with MOMENTO_.
+var envSettings = collectEnvSettings(reflect.TypeOf(Spec{}), envPrefix, nil, map[string]envSetting{})
+
+// envSections are the prefixes of the variables of each section. Unknown variables with these prefixes
+// are errors, while other MOMENTO_* variables, such as MOMENTO_API_KEY, are left alone.
+var envSections = []string{"MOMENTO_CACHE_", "MOMENTO_TOPICS_", "MOMENTO_LEADERBOARD_", "MOMENTO_STORAGE_", "MOMENTO_AUTH_"}
+
+var durationType = reflect.TypeOf(Duration(0))
+
+func collectEnvSettings(typ reflect.Type, prefix string, index []int, settings map[string]envSetting) map[string]envSetting {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == "" || tag == "-" {
+			continue
+		}
+		name := prefix + strings.ToUpper(tag)
+		fieldIndex := append(append([]int{}, index...), i)
+		if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
+			collectEnvSettings(field.Type.Elem(), name+"_", fieldIndex, settings)
+			continue
+		}
+		settings[name] = envSetting{index: fieldIndex, typ: field.Type}
+	}
+	return settings
+}
+
+// ApplyEnv overrides the configuration with the MOMENTO_* variables in environ, which holds "NAME=value"
+// entries as returned by os.Environ. The result is validated.
+func (s *Spec) ApplyEnv(environ []string) error {
+	v := &validator{}
+	for _, entry := range environ {
+		name, value, found := strings.Cut(entry, "=")
+		if !found || !strings.HasPrefix(name, envPrefix) {
+			continue
+		}
+		setting, ok := envSettings[name]
+		if !ok {
+			for _, section := range envSections {
+				if strings.HasPrefix(name, section) {
+					v.problems = append(v.problems, fmt.Sprintf("%s: unknown setting%s", name, suggestEnv(name)))
+					break
+				}
+			}
+			continue
+		}
+		if err := setting.set(reflect.ValueOf(s).Elem(), value); err != nil {
+			v.problems = append(v.problems, fmt.Sprintf("%s: %s", name, err.Error()))
+		}
+	}
+	if len(v.problems) > 0 {
+		sort.Strings(v.problems)
+		return &ValidationError{Problems: v.problems}
+	}
+	return s.Validate()
+}
+
+func (setting envSetting) set(spec reflect.Value, value string) error {
+	field := spec
+	for _, i := range setting.index {
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			field = field.Elem()
+		}
+		field = field.Field(i)
+	}
+	if field.Kind() == reflect.Ptr {
+		target := reflect.New(field.Type().Elem())
+		if err := parseEnvValue(target.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(target)
+		return nil
+	}
+	return parseEnvValue(field, value)
+}
+
+func parseEnvValue(field reflect.Value, value string) error {
+	if field.Type() == durationType {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q, expected a value such as \"500ms\" or \"5s\"", value)
+		}
+		field.SetInt(int64(parsed))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %q, expected true or false", value)
+		}
+		field.SetBool(parsed)
+	case reflect.Int:
+		parsed, err := strconv.ParseInt(value, 10, 0)
+		if err != nil {
+			return fmt.Errorf("invalid value %q, expected an integer", value)
+		}
+		field.SetInt(parsed)
+	case reflect.Uint32:
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid value %q, expected a non-negative integer", value)
+		}
+		field.SetUint(parsed)
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid value %q, expected a number", value)
+		}
+		field.SetFloat(parsed)
+	default:
+		return fmt.Errorf("cannot be set from the environment")
+	}
+	return nil
+}
+
+// suggestEnv returns a hint naming the known variable closest to an unknown one, if any is close.
+func suggestEnv(name string) string {
+	best, bestDistance := "", 4
+	for known := range envSettings {
+		if distance := editDistance(name, known); distance < bestDistance || (distance == bestDistance && known < best) {
+			best, bestDistance = known, distance
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %s?", best)
+}
+
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func min(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
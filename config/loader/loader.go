@@ -0,0 +1,367 @@
+// Package loader builds client configurations from YAML or JSON files and from MOMENTO_* environment
+// variables, so that timeouts, channels, keepalive, retries and middleware can be tuned per deployment
+// without recompiling.
+//
+// A file has one optional section per client, and each setting that is omitted keeps the value of the
+// pre-built configuration it starts from:
+//
+//	log_level: warn
+//	cache:
+//	  profile: in-region
+//	  client_timeout: 2s
+//	  num_grpc_channels: 4
+//	  grpc:
+//	    keep_alive:
+//	      time: 10s
+//	  retry:
+//	    strategy: fixed-count
+//	    max_attempts: 5
+//	  middleware:
+//	    compression:
+//	      algorithm: zstd
+//	topics:
+//	  num_stream_grpc_channels: 8
+//
+// Each setting can also be set by an environment variable named after its path, e.g.
+// MOMENTO_CACHE_CLIENT_TIMEOUT=2s or MOMENTO_CACHE_RETRY_MAX_ATTEMPTS=5.
+//
+//	spec, err := loader.LoadFile("momento.yaml")
+//	if err != nil {
+//	  return err
+//	}
+//	if err := spec.ApplyEnv(os.Environ()); err != nil {
+//	  return err
+//	}
+//	cacheConfig, err := spec.CacheConfiguration()
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/config/logger"
+)
+
+// Format is the encoding of a configuration file.
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+// FormatForPath returns the format of a configuration file from its extension: .yaml, .yml or .json.
+func FormatForPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("cannot tell the format of %s: expected a .yaml, .yml or .json extension", path)
+	}
+}
+
+// Profile selects the pre-built cache configuration that a file's settings are applied to.
+type Profile string
+
+const (
+	// ProfileLaptop starts from config.LaptopLatest. It is the default.
+	ProfileLaptop Profile = "laptop"
+	// ProfileInRegion starts from config.InRegionLatest.
+	ProfileInRegion Profile = "in-region"
+	// ProfileLambda starts from config.LambdaLatest.
+	ProfileLambda Profile = "lambda"
+)
+
+// LogLevel is the level of the default logger used by the configurations.
+type LogLevel string
+
+const (
+	LogLevelTrace LogLevel = "trace"
+	LogLevelDebug LogLevel = "debug"
+	LogLevelInfo  LogLevel = "info"
+	LogLevelWarn  LogLevel = "warn"
+	LogLevelError LogLevel = "error"
+)
+
+// RetryStrategy names one of the retry strategies in the retry package.
+type RetryStrategy string
+
+const (
+	RetryFixedCount             RetryStrategy = "fixed-count"
+	RetryTimeoutAwareFixedCount RetryStrategy = "timeout-aware-fixed-count"
+	RetryExponentialBackoff     RetryStrategy = "exponential-backoff"
+	RetryFixedTimeout           RetryStrategy = "fixed-timeout"
+	RetryNever                  RetryStrategy = "never"
+)
+
+// CompressionAlgorithm names one of the compressors of the compression middleware.
+type CompressionAlgorithm string
+
+const (
+	CompressionGzip   CompressionAlgorithm = "gzip"
+	CompressionZstd   CompressionAlgorithm = "zstd"
+	CompressionLz4    CompressionAlgorithm = "lz4"
+	CompressionSnappy CompressionAlgorithm = "snappy"
+)
+
+// Spec is the contents of a configuration file. The zero value of each field keeps the value of the
+// pre-built configuration, and a nil section keeps all of them.
+type Spec struct {
+	// LogLevel is the level of the default logger. Defaults to info.
+	LogLevel    LogLevel         `json:"log_level,omitempty" yaml:"log_level,omitempty"`
+	Cache       *CacheSpec       `json:"cache,omitempty" yaml:"cache,omitempty"`
+	Topics      *TopicsSpec      `json:"topics,omitempty" yaml:"topics,omitempty"`
+	Leaderboard *LeaderboardSpec `json:"leaderboard,omitempty" yaml:"leaderboard,omitempty"`
+	Storage     *StorageSpec     `json:"storage,omitempty" yaml:"storage,omitempty"`
+	Auth        *AuthSpec        `json:"auth,omitempty" yaml:"auth,omitempty"`
+
+	// LoggerFactory, if set, is used instead of the default logger. It cannot be set from a file.
+	LoggerFactory logger.MomentoLoggerFactory `json:"-" yaml:"-"`
+}
+
+// CacheSpec configures a cache client.
+type CacheSpec struct {
+	// Profile is the pre-built configuration the settings are applied to. Defaults to laptop.
+	Profile         Profile            `json:"profile,omitempty" yaml:"profile,omitempty"`
+	ClientTimeout   Duration           `json:"client_timeout,omitempty" yaml:"client_timeout,omitempty"`
+	NumGrpcChannels uint32             `json:"num_grpc_channels,omitempty" yaml:"num_grpc_channels,omitempty"`
+	ReadConcern     config.ReadConcern `json:"read_concern,omitempty" yaml:"read_concern,omitempty"`
+	// MaxIdle is how long a connection may be idle before it is reconnected.
+	MaxIdle    Duration        `json:"max_idle,omitempty" yaml:"max_idle,omitempty"`
+	Grpc       *GrpcSpec       `json:"grpc,omitempty" yaml:"grpc,omitempty"`
+	Retry      *RetrySpec      `json:"retry,omitempty" yaml:"retry,omitempty"`
+	Middleware *MiddlewareSpec `json:"middleware,omitempty" yaml:"middleware,omitempty"`
+}
+
+// TopicsSpec configures a topic client.
+type TopicsSpec struct {
+	ClientTimeout         Duration `json:"client_timeout,omitempty" yaml:"client_timeout,omitempty"`
+	MaxSubscriptions      uint32   `json:"max_subscriptions,omitempty" yaml:"max_subscriptions,omitempty"`
+	NumStreamGrpcChannels uint32   `json:"num_stream_grpc_channels,omitempty" yaml:"num_stream_grpc_channels,omitempty"`
+	NumUnaryGrpcChannels  uint32   `json:"num_unary_grpc_channels,omitempty" yaml:"num_unary_grpc_channels,omitempty"`
+	// ReconnectDelay is the time between attempts to reconnect an interrupted subscription.
+	ReconnectDelay Duration              `json:"reconnect_delay,omitempty" yaml:"reconnect_delay,omitempty"`
+	Grpc           *GrpcSpec             `json:"grpc,omitempty" yaml:"grpc,omitempty"`
+	Middleware     *TopicsMiddlewareSpec `json:"middleware,omitempty" yaml:"middleware,omitempty"`
+}
+
+// LeaderboardSpec configures a leaderboard client.
+type LeaderboardSpec struct {
+	ClientTimeout Duration  `json:"client_timeout,omitempty" yaml:"client_timeout,omitempty"`
+	Grpc          *GrpcSpec `json:"grpc,omitempty" yaml:"grpc,omitempty"`
+}
+
+// StorageSpec configures a storage client.
+type StorageSpec struct {
+	ClientTimeout   Duration  `json:"client_timeout,omitempty" yaml:"client_timeout,omitempty"`
+	NumGrpcChannels uint32    `json:"num_grpc_channels,omitempty" yaml:"num_grpc_channels,omitempty"`
+	Grpc            *GrpcSpec `json:"grpc,omitempty" yaml:"grpc,omitempty"`
+}
+
+// AuthSpec configures an auth client.
+type AuthSpec struct {
+	Grpc *GrpcSpec `json:"grpc,omitempty" yaml:"grpc,omitempty"`
+}
+
+// GrpcSpec configures the gRPC connections of a client.
+type GrpcSpec struct {
+	KeepAlive *KeepAliveSpec `json:"keep_alive,omitempty" yaml:"keep_alive,omitempty"`
+	// ProxyUrl is an HTTP or HTTPS proxy that connections are tunneled through.
+	ProxyUrl string `json:"proxy_url,omitempty" yaml:"proxy_url,omitempty"`
+}
+
+// KeepAliveSpec configures gRPC keepalive pings.
+type KeepAliveSpec struct {
+	// Disabled turns keepalive pings off. The other settings must be omitted.
+	Disabled           bool     `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	PermitWithoutCalls *bool    `json:"permit_without_calls,omitempty" yaml:"permit_without_calls,omitempty"`
+	Time               Duration `json:"time,omitempty" yaml:"time,omitempty"`
+	Timeout            Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// RetrySpec selects a retry strategy. Only the settings of the selected strategy may be set.
+type RetrySpec struct {
+	Strategy RetryStrategy `json:"strategy,omitempty" yaml:"strategy,omitempty"`
+	// MaxAttempts applies to fixed-count and timeout-aware-fixed-count.
+	MaxAttempts int `json:"max_attempts,omitempty" yaml:"max_attempts,omitempty"`
+	// AttemptTimeout applies to timeout-aware-fixed-count.
+	AttemptTimeout Duration `json:"attempt_timeout,omitempty" yaml:"attempt_timeout,omitempty"`
+	// InitialDelay, MaxBackoff and GrowthFactor apply to exponential-backoff.
+	InitialDelay Duration `json:"initial_delay,omitempty" yaml:"initial_delay,omitempty"`
+	MaxBackoff   Duration `json:"max_backoff,omitempty" yaml:"max_backoff,omitempty"`
+	GrowthFactor int      `json:"growth_factor,omitempty" yaml:"growth_factor,omitempty"`
+	// RetryTimeout and RetryDelayInterval apply to fixed-timeout.
+	RetryTimeout       Duration `json:"retry_timeout,omitempty" yaml:"retry_timeout,omitempty"`
+	RetryDelayInterval Duration `json:"retry_delay_interval,omitempty" yaml:"retry_delay_interval,omitempty"`
+	// Budget, if set, caps retries as a fraction of successful requests.
+	Budget *RetryBudgetSpec `json:"budget,omitempty" yaml:"budget,omitempty"`
+}
+
+// RetryBudgetSpec configures a retry.RetryBudget.
+type RetryBudgetSpec struct {
+	Ratio     float64 `json:"ratio,omitempty" yaml:"ratio,omitempty"`
+	MaxTokens float64 `json:"max_tokens,omitempty" yaml:"max_tokens,omitempty"`
+}
+
+// MiddlewareSpec turns on the cache client middleware that needs no code to configure.
+type MiddlewareSpec struct {
+	Compression *CompressionSpec `json:"compression,omitempty" yaml:"compression,omitempty"`
+	Namespace   *NamespaceSpec   `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// InFlightRequestCount logs the number of requests in flight.
+	InFlightRequestCount bool `json:"in_flight_request_count,omitempty" yaml:"in_flight_request_count,omitempty"`
+}
+
+// TopicsMiddlewareSpec turns on the topic client middleware that needs no code to configure.
+type TopicsMiddlewareSpec struct {
+	Compression *CompressionSpec `json:"compression,omitempty" yaml:"compression,omitempty"`
+	Namespace   *NamespaceSpec   `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+}
+
+// CompressionSpec configures the compression middleware.
+type CompressionSpec struct {
+	Algorithm CompressionAlgorithm `json:"algorithm,omitempty" yaml:"algorithm,omitempty"`
+	// Level is one of default, fastest or smallestSize. Defaults to default.
+	Level            string `json:"level,omitempty" yaml:"level,omitempty"`
+	MinimumSizeBytes int    `json:"minimum_size_bytes,omitempty" yaml:"minimum_size_bytes,omitempty"`
+}
+
+// NamespaceSpec configures the namespace middleware.
+type NamespaceSpec struct {
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// Separator is placed between the namespace and the name it prefixes. Defaults to ":".
+	Separator string `json:"separator,omitempty" yaml:"separator,omitempty"`
+}
+
+// Duration is a time.Duration written as a string such as "500ms" or "5s".
+type Duration time.Duration
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("invalid duration %s: expected a string such as \"500ms\" or \"5s\"", data)
+	}
+	return d.parse(text)
+}
+
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: invalid duration: expected a string such as \"500ms\" or \"5s\"", node.Line)
+	}
+	if err := d.parse(node.Value); err != nil {
+		return fmt.Errorf("line %d: %w", node.Line, err)
+	}
+	return nil
+}
+
+func (d *Duration) parse(text string) error {
+	parsed, err := time.ParseDuration(text)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: expected a value such as \"500ms\" or \"5s\"", text)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// LoadFile reads and validates a configuration file, in the format given by its extension.
+func LoadFile(path string) (*Spec, error) {
+	format, err := FormatForPath(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := Parse(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// Parse decodes and validates a configuration. Unknown settings are errors.
+func Parse(data []byte, format Format) (*Spec, error) {
+	spec := &Spec{}
+	switch format {
+	case FormatYAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(spec); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+	case FormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(spec); err != nil {
+			return nil, err
+		}
+		if decoder.More() {
+			return nil, fmt.Errorf("unexpected data after the configuration")
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// FromEnv returns the configuration set by the MOMENTO_* environment variables of the process.
+func FromEnv() (*Spec, error) {
+	spec := &Spec{}
+	if err := spec.ApplyEnv(os.Environ()); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// Marshal encodes the configuration, e.g. to log the settings a process was started with. Parsing the
+// result returns an equal Spec.
+func (s *Spec) Marshal(format Format) ([]byte, error) {
+	switch format {
+	case FormatYAML:
+		var buffer bytes.Buffer
+		encoder := yaml.NewEncoder(&buffer)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(s); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), nil
+	case FormatJSON:
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
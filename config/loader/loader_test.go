@@ -0,0 +1,216 @@
+package loader_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/config/loader"
+)
+
+const yamlConfig = `
+log_level: warn
+cache:
+  profile: in-region
+  client_timeout: 2s
+  num_grpc_channels: 4
+  read_concern: consistent
+  grpc:
+    keep_alive:
+      time: 10s
+      permit_without_calls: false
+    proxy_url: http://proxy:3128
+  retry:
+    strategy: fixed-count
+    max_attempts: 5
+  middleware:
+    compression:
+      algorithm: zstd
+      level: fastest
+    in_flight_request_count: true
+topics:
+  client_timeout: 3s
+  num_stream_grpc_channels: 8
+  reconnect_delay: 1s
+leaderboard:
+  client_timeout: 7s
+storage:
+  num_grpc_channels: 2
+auth:
+  grpc:
+    keep_alive:
+      disabled: true
+`
+
+var _ = Describe("loader", func() {
+	It("builds configurations from YAML", func() {
+		spec, err := loader.Parse([]byte(yamlConfig), loader.FormatYAML)
+		Expect(err).To(BeNil())
+
+		cacheConfig, err := spec.CacheConfiguration()
+		Expect(err).To(BeNil())
+		Expect(cacheConfig.GetClientSideTimeout()).To(Equal(2 * time.Second))
+		Expect(cacheConfig.GetNumGrpcChannels()).To(Equal(uint32(4)))
+		Expect(cacheConfig.GetReadConcern()).To(Equal(config.CONSISTENT))
+		grpcConfig := cacheConfig.GetTransportStrategy().GetGrpcConfig()
+		Expect(grpcConfig.GetKeepAliveTime()).To(Equal(10 * time.Second))
+		Expect(grpcConfig.GetKeepAliveTimeout()).To(Equal(config.DEFAULT_KEEPALIVE_TIMEOUT))
+		Expect(grpcConfig.GetKeepAlivePermitWithoutCalls()).To(BeFalse())
+		Expect(grpcConfig.GetProxyUrl().Host).To(Equal("proxy:3128"))
+		Expect(cacheConfig.GetMiddleware()).To(HaveLen(2))
+
+		topicsConfig, err := spec.TopicsConfiguration()
+		Expect(err).To(BeNil())
+		Expect(topicsConfig.GetClientSideTimeout()).To(Equal(3 * time.Second))
+		Expect(topicsConfig.GetNumStreamGrpcChannels()).To(Equal(uint32(8)))
+
+		leaderboardConfig, err := spec.LeaderboardConfiguration()
+		Expect(err).To(BeNil())
+		Expect(leaderboardConfig.GetClientSideTimeout()).To(Equal(7 * time.Second))
+
+		storageConfig, err := spec.StorageConfiguration()
+		Expect(err).To(BeNil())
+		Expect(storageConfig.GetNumGrpcChannels()).To(Equal(uint32(2)))
+		Expect(storageConfig.GetClientSideTimeout()).To(Equal(15 * time.Second))
+
+		authConfig, err := spec.AuthConfiguration()
+		Expect(err).To(BeNil())
+		Expect(authConfig.GetTransportStrategy().GetGrpcConfig().GetKeepAliveTime()).To(BeZero())
+	})
+
+	It("keeps the pre-built settings that are not set", func() {
+		spec, err := loader.Parse([]byte(`cache: {profile: lambda}`), loader.FormatYAML)
+		Expect(err).To(BeNil())
+		cacheConfig, err := spec.CacheConfiguration()
+		Expect(err).To(BeNil())
+		lambda := config.LambdaLatest()
+		Expect(cacheConfig.GetClientSideTimeout()).To(Equal(lambda.GetClientSideTimeout()))
+		Expect(cacheConfig.GetNumGrpcChannels()).To(Equal(lambda.GetNumGrpcChannels()))
+		Expect(cacheConfig.GetTransportStrategy().GetGrpcConfig().GetKeepAliveTime()).To(BeZero())
+
+		empty, err := loader.Parse(nil, loader.FormatYAML)
+		Expect(err).To(BeNil())
+		cacheConfig, err = empty.CacheConfiguration()
+		Expect(err).To(BeNil())
+		Expect(cacheConfig.GetClientSideTimeout()).To(Equal(config.LaptopLatest().GetClientSideTimeout()))
+	})
+
+	It("reads JSON files and round-trips them", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "momento.json")
+		Expect(os.WriteFile(path, []byte(`{"cache": {"client_timeout": "1500ms", "retry": {"strategy": "never"}}}`), 0o600)).To(Succeed())
+		spec, err := loader.LoadFile(path)
+		Expect(err).To(BeNil())
+		Expect(spec.Cache.ClientTimeout).To(Equal(loader.Duration(1500 * time.Millisecond)))
+
+		for _, format := range []loader.Format{loader.FormatJSON, loader.FormatYAML} {
+			data, err := spec.Marshal(format)
+			Expect(err).To(BeNil())
+			parsed, err := loader.Parse(data, format)
+			Expect(err).To(BeNil())
+			Expect(parsed).To(Equal(spec))
+		}
+
+		full, err := loader.Parse([]byte(yamlConfig), loader.FormatYAML)
+		Expect(err).To(BeNil())
+		data, err := full.Marshal(loader.FormatYAML)
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(ContainSubstring("client_timeout: 2s"))
+		parsed, err := loader.Parse(data, loader.FormatYAML)
+		Expect(err).To(BeNil())
+		Expect(parsed).To(Equal(full))
+	})
+
+	It("rejects unknown settings", func() {
+		_, err := loader.Parse([]byte("cache:\n  clinet_timeout: 2s\n"), loader.FormatYAML)
+		Expect(err).To(MatchError(ContainSubstring("field clinet_timeout not found")))
+		_, err = loader.Parse([]byte(`{"cache": {"clinet_timeout": "2s"}}`), loader.FormatJSON)
+		Expect(err).To(MatchError(ContainSubstring(`unknown field "clinet_timeout"`)))
+		_, err = loader.Parse([]byte("topics:\n  middleware:\n    in_flight_request_count: true\n"), loader.FormatYAML)
+		Expect(err).To(HaveOccurred())
+		_, err = loader.LoadFile("momento.toml")
+		Expect(err).To(MatchError(ContainSubstring("expected a .yaml, .yml or .json extension")))
+	})
+
+	It("reports every invalid value with its path", func() {
+		_, err := loader.Parse([]byte(`
+log_level: loud
+cache:
+  profile: desktop
+  retry:
+    strategy: fixed-count
+    initial_delay: 10ms
+  grpc:
+    keep_alive:
+      disabled: true
+      time: 5s
+  middleware:
+    compression:
+      algorithm: brotli
+`), loader.FormatYAML)
+		var validationErr *loader.ValidationError
+		Expect(err).To(BeAssignableToTypeOf(validationErr))
+		Expect(err.(*loader.ValidationError).Problems).To(ConsistOf(
+			`log_level: unknown value "loud", expected one of trace, debug, info, warn, error`,
+			`cache.profile: unknown value "desktop", expected one of laptop, in-region, lambda`,
+			"cache.retry.initial_delay: does not apply to the fixed-count strategy",
+			"cache.grpc.keep_alive: cannot set permit_without_calls, time or timeout when disabled",
+			`cache.middleware.compression.algorithm: unknown value "brotli", expected one of gzip, zstd, lz4, snappy`,
+		))
+
+		_, err = loader.Parse([]byte("cache:\n  client_timeout: 5\n"), loader.FormatYAML)
+		Expect(err).To(MatchError(ContainSubstring(`line 2: invalid duration "5"`)))
+	})
+
+	Describe("environment variables", func() {
+		It("override the settings of a file", func() {
+			spec, err := loader.Parse([]byte(yamlConfig), loader.FormatYAML)
+			Expect(err).To(BeNil())
+			Expect(spec.ApplyEnv([]string{
+				"MOMENTO_API_KEY=secret",
+				"MOMENTO_CACHE_CLIENT_TIMEOUT=750ms",
+				"MOMENTO_CACHE_RETRY_MAX_ATTEMPTS=2",
+				"MOMENTO_CACHE_GRPC_KEEP_ALIVE_PERMIT_WITHOUT_CALLS=true",
+				"MOMENTO_STORAGE_GRPC_PROXY_URL=https://proxy:8443",
+				"PATH=/usr/bin",
+			})).To(Succeed())
+			Expect(spec.Cache.ClientTimeout).To(Equal(loader.Duration(750 * time.Millisecond)))
+			Expect(spec.Cache.Retry.MaxAttempts).To(Equal(2))
+			Expect(*spec.Cache.Grpc.KeepAlive.PermitWithoutCalls).To(BeTrue())
+			Expect(spec.Storage.Grpc.ProxyUrl).To(Equal("https://proxy:8443"))
+		})
+
+		It("build a configuration on their own", func() {
+			spec := &loader.Spec{}
+			Expect(spec.ApplyEnv([]string{
+				"MOMENTO_LOG_LEVEL=error",
+				"MOMENTO_TOPICS_NUM_UNARY_GRPC_CHANNELS=6",
+				"MOMENTO_TOPICS_MIDDLEWARE_NAMESPACE_NAMESPACE=staging",
+			})).To(Succeed())
+			Expect(spec.LogLevel).To(Equal(loader.LogLevelError))
+			topicsConfig, err := spec.TopicsConfiguration()
+			Expect(err).To(BeNil())
+			Expect(topicsConfig.GetNumUnaryGrpcChannels()).To(Equal(uint32(6)))
+			Expect(topicsConfig.GetMiddleware()).To(HaveLen(1))
+		})
+
+		It("reject unknown names and invalid values", func() {
+			err := (&loader.Spec{}).ApplyEnv([]string{
+				"MOMENTO_CACHE_CLIENT_TIMOUT=1s",
+				"MOMENTO_CACHE_NUM_GRPC_CHANNELS=-1",
+				"MOMENTO_CACHE_RETRY_STRATEGY=sometimes",
+			})
+			Expect(err).To(BeAssignableToTypeOf(&loader.ValidationError{}))
+			Expect(err.(*loader.ValidationError).Problems).To(ConsistOf(
+				"MOMENTO_CACHE_CLIENT_TIMOUT: unknown setting, did you mean MOMENTO_CACHE_CLIENT_TIMEOUT?",
+				`MOMENTO_CACHE_NUM_GRPC_CHANNELS: invalid value "-1", expected a non-negative integer`,
+			))
+
+			err = (&loader.Spec{}).ApplyEnv([]string{"MOMENTO_CACHE_RETRY_STRATEGY=sometimes"})
+			Expect(err).To(MatchError(ContainSubstring(`cache.retry.strategy: unknown value "sometimes"`)))
+		})
+	})
+})
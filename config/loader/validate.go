@@ -0,0 +1,216 @@
+package loader
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/config/compression"
+)
+
+// ValidationError lists every problem found in a configuration, each prefixed with the path of the
+// setting, e.g. "cache.retry.max_attempts".
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) add(path string, format string, args ...interface{}) {
+	v.problems = append(v.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+// oneOf checks that a set value is one of the allowed ones.
+func oneOf[T ~string](v *validator, path string, value T, allowed ...T) {
+	if value == "" {
+		return
+	}
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	names := make([]string, len(allowed))
+	for i, a := range allowed {
+		names[i] = string(a)
+	}
+	v.add(path, "unknown value %q, expected one of %s", value, strings.Join(names, ", "))
+}
+
+func (v *validator) nonNegative(path string, d Duration) {
+	if d < 0 {
+		v.add(path, "must not be negative")
+	}
+}
+
+// Validate checks the values of the settings.
+func (s *Spec) Validate() error {
+	v := &validator{}
+	oneOf(v, "log_level", s.LogLevel, LogLevelTrace, LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError)
+	if s.Cache != nil {
+		s.Cache.validate(v, "cache")
+	}
+	if s.Topics != nil {
+		s.Topics.validate(v, "topics")
+	}
+	if s.Leaderboard != nil {
+		v.nonNegative("leaderboard.client_timeout", s.Leaderboard.ClientTimeout)
+		s.Leaderboard.Grpc.validate(v, "leaderboard.grpc")
+	}
+	if s.Storage != nil {
+		v.nonNegative("storage.client_timeout", s.Storage.ClientTimeout)
+		s.Storage.Grpc.validate(v, "storage.grpc")
+	}
+	if s.Auth != nil {
+		s.Auth.Grpc.validate(v, "auth.grpc")
+	}
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+func (s *CacheSpec) validate(v *validator, path string) {
+	oneOf(v, path+".profile", s.Profile, ProfileLaptop, ProfileInRegion, ProfileLambda)
+	oneOf(v, path+".read_concern", s.ReadConcern, config.BALANCED, config.CONSISTENT)
+	v.nonNegative(path+".client_timeout", s.ClientTimeout)
+	v.nonNegative(path+".max_idle", s.MaxIdle)
+	s.Grpc.validate(v, path+".grpc")
+	if s.Retry != nil {
+		s.Retry.validate(v, path+".retry")
+	}
+	if s.Middleware != nil {
+		s.Middleware.Compression.validate(v, path+".middleware.compression")
+		s.Middleware.Namespace.validate(v, path+".middleware.namespace")
+	}
+}
+
+func (s *TopicsSpec) validate(v *validator, path string) {
+	v.nonNegative(path+".client_timeout", s.ClientTimeout)
+	v.nonNegative(path+".reconnect_delay", s.ReconnectDelay)
+	if s.MaxSubscriptions > 0 && (s.NumStreamGrpcChannels > 0 || s.NumUnaryGrpcChannels > 0) {
+		v.add(path+".max_subscriptions", "cannot be set with num_stream_grpc_channels or num_unary_grpc_channels, which it overrides")
+	}
+	s.Grpc.validate(v, path+".grpc")
+	if s.Middleware != nil {
+		s.Middleware.Compression.validate(v, path+".middleware.compression")
+		s.Middleware.Namespace.validate(v, path+".middleware.namespace")
+	}
+}
+
+func (s *GrpcSpec) validate(v *validator, path string) {
+	if s == nil {
+		return
+	}
+	if s.KeepAlive != nil {
+		keepAlive := s.KeepAlive
+		if keepAlive.Disabled && (keepAlive.PermitWithoutCalls != nil || keepAlive.Time != 0 || keepAlive.Timeout != 0) {
+			v.add(path+".keep_alive", "cannot set permit_without_calls, time or timeout when disabled")
+		}
+		v.nonNegative(path+".keep_alive.time", keepAlive.Time)
+		v.nonNegative(path+".keep_alive.timeout", keepAlive.Timeout)
+	}
+	if s.ProxyUrl != "" {
+		proxyUrl, err := url.Parse(s.ProxyUrl)
+		if err != nil || proxyUrl.Host == "" || (proxyUrl.Scheme != "http" && proxyUrl.Scheme != "https") {
+			v.add(path+".proxy_url", "invalid proxy URL %q, expected a URL such as \"http://proxy:3128\"", s.ProxyUrl)
+		}
+	}
+}
+
+// retrySettings are the settings that apply to each retry strategy.
+var retrySettings = map[RetryStrategy][]string{
+	RetryFixedCount:             {"max_attempts"},
+	RetryTimeoutAwareFixedCount: {"max_attempts", "attempt_timeout"},
+	RetryExponentialBackoff:     {"initial_delay", "max_backoff", "growth_factor"},
+	RetryFixedTimeout:           {"retry_timeout", "retry_delay_interval"},
+	RetryNever:                  {},
+}
+
+func (s *RetrySpec) validate(v *validator, path string) {
+	if s.Strategy == "" {
+		v.add(path+".strategy", "is required")
+		return
+	}
+	oneOf(v, path+".strategy", s.Strategy,
+		RetryFixedCount, RetryTimeoutAwareFixedCount, RetryExponentialBackoff, RetryFixedTimeout, RetryNever)
+	allowed, ok := retrySettings[s.Strategy]
+	if !ok {
+		return
+	}
+	set := map[string]bool{
+		"max_attempts":         s.MaxAttempts != 0,
+		"attempt_timeout":      s.AttemptTimeout != 0,
+		"initial_delay":        s.InitialDelay != 0,
+		"max_backoff":          s.MaxBackoff != 0,
+		"growth_factor":        s.GrowthFactor != 0,
+		"retry_timeout":        s.RetryTimeout != 0,
+		"retry_delay_interval": s.RetryDelayInterval != 0,
+	}
+	for _, name := range []string{
+		"max_attempts", "attempt_timeout", "initial_delay", "max_backoff", "growth_factor", "retry_timeout", "retry_delay_interval",
+	} {
+		if set[name] && !contains(allowed, name) {
+			v.add(path+"."+name, "does not apply to the %s strategy", s.Strategy)
+		}
+	}
+	if s.MaxAttempts < 0 {
+		v.add(path+".max_attempts", "must not be negative")
+	}
+	if s.GrowthFactor < 0 {
+		v.add(path+".growth_factor", "must not be negative")
+	}
+	v.nonNegative(path+".attempt_timeout", s.AttemptTimeout)
+	v.nonNegative(path+".initial_delay", s.InitialDelay)
+	v.nonNegative(path+".max_backoff", s.MaxBackoff)
+	v.nonNegative(path+".retry_timeout", s.RetryTimeout)
+	v.nonNegative(path+".retry_delay_interval", s.RetryDelayInterval)
+	if s.Budget != nil {
+		if s.Strategy == RetryNever {
+			v.add(path+".budget", "does not apply to the never strategy")
+		}
+		if s.Budget.Ratio < 0 {
+			v.add(path+".budget.ratio", "must not be negative")
+		}
+		if s.Budget.MaxTokens < 0 {
+			v.add(path+".budget.max_tokens", "must not be negative")
+		}
+	}
+}
+
+func (s *CompressionSpec) validate(v *validator, path string) {
+	if s == nil {
+		return
+	}
+	if s.Algorithm == "" {
+		v.add(path+".algorithm", "is required")
+	}
+	oneOf(v, path+".algorithm", s.Algorithm, CompressionGzip, CompressionZstd, CompressionLz4, CompressionSnappy)
+	oneOf(v, path+".level", compression.CompressionLevel(s.Level),
+		compression.CompressionLevelDefault, compression.CompressionLevelFastest, compression.CompressionLevelSmallestSize)
+	if s.MinimumSizeBytes < 0 {
+		v.add(path+".minimum_size_bytes", "must not be negative")
+	}
+}
+
+func (s *NamespaceSpec) validate(v *validator, path string) {
+	if s != nil && s.Namespace == "" {
+		v.add(path+".namespace", "is required")
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
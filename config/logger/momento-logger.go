@@ -1,5 +1,10 @@
 package logger
 
+import (
+	"fmt"
+	"strings"
+)
+
 type MomentoLogger interface {
 	Trace(message string, args ...any)
 	Debug(message string, args ...any)
@@ -11,3 +16,77 @@ type MomentoLogger interface {
 type MomentoLoggerFactory interface {
 	GetLogger(loggerName string) MomentoLogger
 }
+
+// Field is a key-value pair attached to the messages of a logger returned by With.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Keys of the fields attached to log messages by the SDK.
+const (
+	LoggerNameKey  = "logger"
+	CacheNameKey   = "cache"
+	RequestNameKey = "request"
+	AttemptKey     = "attempt"
+)
+
+// FieldLogger is a MomentoLogger that can attach structured fields to its messages. Loggers that write
+// structured output should implement it so that fields are emitted as fields rather than as text.
+type FieldLogger interface {
+	MomentoLogger
+	// With returns a logger that attaches fields to every message, in addition to any fields attached to
+	// this logger.
+	With(fields ...Field) MomentoLogger
+}
+
+// With returns a logger that attaches fields to every message logged with it. If l implements FieldLogger
+// its With method is used; otherwise the fields are appended to each message as key=value pairs.
+func With(l MomentoLogger, fields ...Field) MomentoLogger {
+	if len(fields) == 0 {
+		return l
+	}
+	if fl, ok := l.(FieldLogger); ok {
+		return fl.With(fields...)
+	}
+	return &textFieldLogger{logger: l, suffix: FormatFields(fields)}
+}
+
+// FormatFields formats fields as space separated key=value pairs, each preceded by a space.
+func FormatFields(fields []Field) string {
+	var sb strings.Builder
+	for _, field := range fields {
+		sb.WriteString(fmt.Sprintf(" %s=%v", field.Key, field.Value))
+	}
+	return sb.String()
+}
+
+// textFieldLogger appends fields to the messages of a logger that does not support them.
+type textFieldLogger struct {
+	logger MomentoLogger
+	suffix string
+}
+
+func (l *textFieldLogger) Trace(message string, args ...any) {
+	l.logger.Trace("%s", fmt.Sprintf(message, args...)+l.suffix)
+}
+
+func (l *textFieldLogger) Debug(message string, args ...any) {
+	l.logger.Debug("%s", fmt.Sprintf(message, args...)+l.suffix)
+}
+
+func (l *textFieldLogger) Info(message string, args ...any) {
+	l.logger.Info("%s", fmt.Sprintf(message, args...)+l.suffix)
+}
+
+func (l *textFieldLogger) Warn(message string, args ...any) {
+	l.logger.Warn("%s", fmt.Sprintf(message, args...)+l.suffix)
+}
+
+func (l *textFieldLogger) Error(message string, args ...any) {
+	l.logger.Error("%s", fmt.Sprintf(message, args...)+l.suffix)
+}
+
+func (l *textFieldLogger) With(fields ...Field) MomentoLogger {
+	return &textFieldLogger{logger: l.logger, suffix: l.suffix + FormatFields(fields)}
+}
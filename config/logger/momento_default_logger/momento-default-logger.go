@@ -21,35 +21,45 @@ const (
 type DefaultMomentoLogger struct {
 	loggerName string
 	level      LogLevel
+	// fields is the text form of the fields attached by With, appended to every message.
+	fields string
 }
 
 func (l *DefaultMomentoLogger) Trace(message string, args ...any) {
 	if l.level <= TRACE {
-		momentoLog("TRACE", l.loggerName, message, args...)
+		momentoLog("TRACE", l.loggerName, l.fields, message, args...)
 	}
 }
 
 func (l *DefaultMomentoLogger) Debug(message string, args ...any) {
 	if l.level <= DEBUG {
-		momentoLog("DEBUG", l.loggerName, message, args...)
+		momentoLog("DEBUG", l.loggerName, l.fields, message, args...)
 	}
 }
 
 func (l *DefaultMomentoLogger) Info(message string, args ...any) {
 	if l.level <= INFO {
-		momentoLog("INFO", l.loggerName, message, args...)
+		momentoLog("INFO", l.loggerName, l.fields, message, args...)
 	}
 }
 
 func (l *DefaultMomentoLogger) Warn(message string, args ...any) {
 	if l.level <= WARN {
-		momentoLog("WARN", l.loggerName, message, args...)
+		momentoLog("WARN", l.loggerName, l.fields, message, args...)
 	}
 }
 
 func (l *DefaultMomentoLogger) Error(message string, args ...any) {
 	if l.level <= ERROR {
-		momentoLog("ERROR", l.loggerName, message, args...)
+		momentoLog("ERROR", l.loggerName, l.fields, message, args...)
+	}
+}
+
+func (l *DefaultMomentoLogger) With(fields ...logger.Field) logger.MomentoLogger {
+	return &DefaultMomentoLogger{
+		loggerName: l.loggerName,
+		level:      l.level,
+		fields:     l.fields + logger.FormatFields(fields),
 	}
 }
 
@@ -57,8 +67,8 @@ func (l *DefaultMomentoLogger) String() string {
 	return fmt.Sprintf("DefaultMomentoLogger{loggerName=%s, level=%v}", l.loggerName, l.level)
 }
 
-func momentoLog(level string, loggerName string, message string, args ...any) {
-	finalMessage := fmt.Sprintf(message, args...)
+func momentoLog(level string, loggerName string, fields string, message string, args ...any) {
+	finalMessage := fmt.Sprintf(message, args...) + fields
 	log.Printf("[%s] %s (%s): %s\n", time.Now().UTC().Format(time.RFC3339), level, loggerName, finalMessage)
 }
 
@@ -23,6 +23,10 @@ func (*NoopMomentoLogger) Error(message string, args ...any) {
 	// no-op
 }
 
+func (l *NoopMomentoLogger) With(fields ...Field) MomentoLogger {
+	return l
+}
+
 type NoopMomentoLoggerFactory struct {
 }
 
@@ -0,0 +1,78 @@
+//go:build go1.21
+
+package slog_logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/momentohq/client-sdk-go/config/logger"
+)
+
+// LevelTrace is the slog level used for trace messages, which are more verbose than debug messages.
+const LevelTrace = slog.LevelDebug - 4
+
+// SlogMomentoLogger is a MomentoLogger that writes to a log/slog logger. Messages are formatted with their
+// arguments, and the logger name and any fields attached with With are emitted as attributes.
+type SlogMomentoLogger struct {
+	logger *slog.Logger
+}
+
+func (l *SlogMomentoLogger) Trace(message string, args ...any) {
+	l.log(LevelTrace, message, args...)
+}
+
+func (l *SlogMomentoLogger) Debug(message string, args ...any) {
+	l.log(slog.LevelDebug, message, args...)
+}
+
+func (l *SlogMomentoLogger) Info(message string, args ...any) {
+	l.log(slog.LevelInfo, message, args...)
+}
+
+func (l *SlogMomentoLogger) Warn(message string, args ...any) {
+	l.log(slog.LevelWarn, message, args...)
+}
+
+func (l *SlogMomentoLogger) Error(message string, args ...any) {
+	l.log(slog.LevelError, message, args...)
+}
+
+func (l *SlogMomentoLogger) With(fields ...logger.Field) logger.MomentoLogger {
+	attrs := make([]any, 0, len(fields))
+	for _, field := range fields {
+		attrs = append(attrs, slog.Any(field.Key, field.Value))
+	}
+	return &SlogMomentoLogger{logger: l.logger.With(attrs...)}
+}
+
+func (l *SlogMomentoLogger) log(level slog.Level, message string, args ...any) {
+	ctx := context.Background()
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+	l.logger.Log(ctx, level, fmt.Sprintf(message, args...))
+}
+
+type SlogMomentoLoggerFactory struct {
+	logger *slog.Logger
+}
+
+// NewSlogMomentoLoggerFactory returns a factory for loggers that write to the given slog logger, or to
+// slog.Default() if it is nil. The level is controlled by the logger's handler; use LevelTrace to enable
+// trace messages.
+func NewSlogMomentoLoggerFactory(slogLogger *slog.Logger) logger.MomentoLoggerFactory {
+	if slogLogger == nil {
+		slogLogger = slog.Default()
+	}
+	return &SlogMomentoLoggerFactory{logger: slogLogger}
+}
+
+func (lf *SlogMomentoLoggerFactory) GetLogger(loggerName string) logger.MomentoLogger {
+	return &SlogMomentoLogger{logger: lf.logger.With(slog.String(logger.LoggerNameKey, loggerName))}
+}
+
+func (lf *SlogMomentoLoggerFactory) String() string {
+	return "SlogMomentoLoggerFactory{}"
+}
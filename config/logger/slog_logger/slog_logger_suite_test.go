@@ -0,0 +1,15 @@
+//go:build go1.21
+
+package slog_logger_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSlogLogger(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Slog Logger Suite")
+}
@@ -0,0 +1,79 @@
+//go:build go1.21
+
+package slog_logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/config/logger/slog_logger"
+)
+
+// decodeLines decodes the JSON records written by a slog.JSONHandler.
+func decodeLines(buffer *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	decoder := json.NewDecoder(buffer)
+	for decoder.More() {
+		record := map[string]any{}
+		Expect(decoder.Decode(&record)).To(Succeed())
+		records = append(records, record)
+	}
+	return records
+}
+
+var _ = Describe("slog-logger", func() {
+	var buffer *bytes.Buffer
+
+	newLogger := func(level slog.Level) logger.MomentoLogger {
+		handler := slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: level})
+		return slog_logger.NewSlogMomentoLoggerFactory(slog.New(handler)).GetLogger("test")
+	}
+
+	BeforeEach(func() {
+		buffer = &bytes.Buffer{}
+	})
+
+	It("formats messages and records the logger name", func() {
+		newLogger(slog.LevelInfo).Info("hello %s", "world")
+
+		records := decodeLines(buffer)
+		Expect(records).To(HaveLen(1))
+		Expect(records[0]).To(HaveKeyWithValue("msg", "hello world"))
+		Expect(records[0]).To(HaveKeyWithValue("level", "INFO"))
+		Expect(records[0]).To(HaveKeyWithValue(logger.LoggerNameKey, "test"))
+	})
+
+	It("emits fields as attributes", func() {
+		log := logger.With(newLogger(slog.LevelInfo), logger.Field{Key: logger.CacheNameKey, Value: "cache"})
+		log.Warn("warning")
+
+		records := decodeLines(buffer)
+		Expect(records).To(HaveLen(1))
+		Expect(records[0]).To(HaveKeyWithValue("msg", "warning"))
+		Expect(records[0]).To(HaveKeyWithValue(logger.CacheNameKey, "cache"))
+	})
+
+	It("only logs trace messages at the trace level", func() {
+		newLogger(slog.LevelDebug).Trace("hidden")
+		Expect(buffer.Len()).To(BeZero())
+
+		newLogger(slog_logger.LevelTrace).Trace("shown")
+		records := decodeLines(buffer)
+		Expect(records).To(HaveLen(1))
+		Expect(records[0]).To(HaveKeyWithValue("msg", "shown"))
+	})
+
+	It("does not log below the handler's level", func() {
+		log := newLogger(slog.LevelWarn)
+		log.Debug("debug")
+		log.Info("info")
+		Expect(buffer.Len()).To(BeZero())
+		log.Error("error")
+		Expect(decodeLines(buffer)).To(HaveLen(1))
+	})
+})
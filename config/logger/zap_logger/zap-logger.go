@@ -0,0 +1,69 @@
+package zap_logger
+
+import (
+	"fmt"
+
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ZapMomentoLogger is a MomentoLogger that writes to a zap logger. Messages are formatted with their
+// arguments, and the logger name and any fields attached with With are emitted as zap fields. zap has no
+// trace level, so trace messages are logged at debug level.
+type ZapMomentoLogger struct {
+	logger *zap.Logger
+}
+
+func (l *ZapMomentoLogger) Trace(message string, args ...any) {
+	l.log(zapcore.DebugLevel, message, args...)
+}
+
+func (l *ZapMomentoLogger) Debug(message string, args ...any) {
+	l.log(zapcore.DebugLevel, message, args...)
+}
+
+func (l *ZapMomentoLogger) Info(message string, args ...any) {
+	l.log(zapcore.InfoLevel, message, args...)
+}
+
+func (l *ZapMomentoLogger) Warn(message string, args ...any) {
+	l.log(zapcore.WarnLevel, message, args...)
+}
+
+func (l *ZapMomentoLogger) Error(message string, args ...any) {
+	l.log(zapcore.ErrorLevel, message, args...)
+}
+
+func (l *ZapMomentoLogger) With(fields ...logger.Field) logger.MomentoLogger {
+	zapFields := make([]zap.Field, 0, len(fields))
+	for _, field := range fields {
+		zapFields = append(zapFields, zap.Any(field.Key, field.Value))
+	}
+	return &ZapMomentoLogger{logger: l.logger.With(zapFields...)}
+}
+
+func (l *ZapMomentoLogger) log(level zapcore.Level, message string, args ...any) {
+	if entry := l.logger.Check(level, ""); entry != nil {
+		entry.Message = fmt.Sprintf(message, args...)
+		entry.Write()
+	}
+}
+
+type ZapMomentoLoggerFactory struct {
+	logger *zap.Logger
+}
+
+// NewZapMomentoLoggerFactory returns a factory for loggers that write to the given zap logger. The logger
+// name is recorded with zap.Logger.Named, so it appears under the encoder's name key.
+func NewZapMomentoLoggerFactory(zapLogger *zap.Logger) logger.MomentoLoggerFactory {
+	return &ZapMomentoLoggerFactory{logger: zapLogger}
+}
+
+func (lf *ZapMomentoLoggerFactory) GetLogger(loggerName string) logger.MomentoLogger {
+	return &ZapMomentoLogger{logger: lf.logger.Named(loggerName)}
+}
+
+func (lf *ZapMomentoLoggerFactory) String() string {
+	return "ZapMomentoLoggerFactory{}"
+}
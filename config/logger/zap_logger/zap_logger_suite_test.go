@@ -0,0 +1,13 @@
+package zap_logger_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestZapLogger(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Zap Logger Suite")
+}
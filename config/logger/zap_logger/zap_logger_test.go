@@ -0,0 +1,58 @@
+package zap_logger_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/config/logger/zap_logger"
+)
+
+var _ = Describe("zap-logger", func() {
+	var logs *observer.ObservedLogs
+
+	newLogger := func(level zapcore.Level) logger.MomentoLogger {
+		var core zapcore.Core
+		core, logs = observer.New(level)
+		return zap_logger.NewZapMomentoLoggerFactory(zap.New(core)).GetLogger("test")
+	}
+
+	It("formats messages and records the logger name", func() {
+		newLogger(zapcore.InfoLevel).Info("hello %s", "world")
+
+		entries := logs.AllUntimed()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Message).To(Equal("hello world"))
+		Expect(entries[0].Level).To(Equal(zapcore.InfoLevel))
+		Expect(entries[0].LoggerName).To(Equal("test"))
+	})
+
+	It("emits fields as zap fields", func() {
+		log := logger.With(newLogger(zapcore.InfoLevel), logger.Field{Key: logger.CacheNameKey, Value: "cache"})
+		log.Warn("warning")
+
+		entries := logs.AllUntimed()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].ContextMap()).To(HaveKeyWithValue(logger.CacheNameKey, "cache"))
+	})
+
+	It("logs trace messages at debug level", func() {
+		newLogger(zapcore.DebugLevel).Trace("trace")
+
+		entries := logs.AllUntimed()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Level).To(Equal(zapcore.DebugLevel))
+	})
+
+	It("does not log below the core's level", func() {
+		log := newLogger(zapcore.WarnLevel)
+		log.Debug("debug")
+		log.Info("info")
+		Expect(logs.Len()).To(BeZero())
+		log.Error("error")
+		Expect(logs.Len()).To(Equal(1))
+	})
+})
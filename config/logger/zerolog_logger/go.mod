@@ -0,0 +1,21 @@
+module github.com/momentohq/client-sdk-go/config/logger/zerolog_logger
+
+go 1.19
+
+require (
+	github.com/momentohq/client-sdk-go v1.40.1
+	github.com/onsi/ginkgo/v2 v2.8.1
+	github.com/onsi/gomega v1.26.0
+	github.com/rs/zerolog v1.33.0
+)
+
+require (
+	github.com/go-logr/logr v1.2.3 // indirect
+	github.com/google/go-cmp v0.6.0 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	golang.org/x/net v0.23.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
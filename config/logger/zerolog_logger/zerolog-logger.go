@@ -0,0 +1,57 @@
+package zerolog_logger
+
+import (
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/rs/zerolog"
+)
+
+// ZerologMomentoLogger is a MomentoLogger that writes to a zerolog logger. Messages are formatted with
+// their arguments, and the logger name and any fields attached with With are emitted as zerolog fields.
+type ZerologMomentoLogger struct {
+	logger zerolog.Logger
+}
+
+func (l *ZerologMomentoLogger) Trace(message string, args ...any) {
+	l.logger.Trace().Msgf(message, args...)
+}
+
+func (l *ZerologMomentoLogger) Debug(message string, args ...any) {
+	l.logger.Debug().Msgf(message, args...)
+}
+
+func (l *ZerologMomentoLogger) Info(message string, args ...any) {
+	l.logger.Info().Msgf(message, args...)
+}
+
+func (l *ZerologMomentoLogger) Warn(message string, args ...any) {
+	l.logger.Warn().Msgf(message, args...)
+}
+
+func (l *ZerologMomentoLogger) Error(message string, args ...any) {
+	l.logger.Error().Msgf(message, args...)
+}
+
+func (l *ZerologMomentoLogger) With(fields ...logger.Field) logger.MomentoLogger {
+	ctx := l.logger.With()
+	for _, field := range fields {
+		ctx = ctx.Interface(field.Key, field.Value)
+	}
+	return &ZerologMomentoLogger{logger: ctx.Logger()}
+}
+
+type ZerologMomentoLoggerFactory struct {
+	logger zerolog.Logger
+}
+
+// NewZerologMomentoLoggerFactory returns a factory for loggers that write to the given zerolog logger.
+func NewZerologMomentoLoggerFactory(zerologLogger zerolog.Logger) logger.MomentoLoggerFactory {
+	return &ZerologMomentoLoggerFactory{logger: zerologLogger}
+}
+
+func (lf *ZerologMomentoLoggerFactory) GetLogger(loggerName string) logger.MomentoLogger {
+	return &ZerologMomentoLogger{logger: lf.logger.With().Str(logger.LoggerNameKey, loggerName).Logger()}
+}
+
+func (lf *ZerologMomentoLoggerFactory) String() string {
+	return "ZerologMomentoLoggerFactory{}"
+}
@@ -0,0 +1,13 @@
+package zerolog_logger_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestZerologLogger(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Zerolog Logger Suite")
+}
@@ -0,0 +1,73 @@
+package zerolog_logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/config/logger/zerolog_logger"
+)
+
+// decodeLines decodes the JSON records written by a zerolog logger.
+func decodeLines(buffer *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	decoder := json.NewDecoder(buffer)
+	for decoder.More() {
+		record := map[string]any{}
+		Expect(decoder.Decode(&record)).To(Succeed())
+		records = append(records, record)
+	}
+	return records
+}
+
+var _ = Describe("zerolog-logger", func() {
+	var buffer *bytes.Buffer
+
+	newLogger := func(level zerolog.Level) logger.MomentoLogger {
+		return zerolog_logger.NewZerologMomentoLoggerFactory(zerolog.New(buffer).Level(level)).GetLogger("test")
+	}
+
+	BeforeEach(func() {
+		buffer = &bytes.Buffer{}
+	})
+
+	It("formats messages and records the logger name", func() {
+		newLogger(zerolog.InfoLevel).Info("hello %s", "world")
+
+		records := decodeLines(buffer)
+		Expect(records).To(HaveLen(1))
+		Expect(records[0]).To(HaveKeyWithValue(zerolog.MessageFieldName, "hello world"))
+		Expect(records[0]).To(HaveKeyWithValue(zerolog.LevelFieldName, "info"))
+		Expect(records[0]).To(HaveKeyWithValue(logger.LoggerNameKey, "test"))
+	})
+
+	It("emits fields as zerolog fields", func() {
+		log := logger.With(newLogger(zerolog.InfoLevel), logger.Field{Key: logger.CacheNameKey, Value: "cache"})
+		log.Warn("warning")
+
+		records := decodeLines(buffer)
+		Expect(records).To(HaveLen(1))
+		Expect(records[0]).To(HaveKeyWithValue(logger.CacheNameKey, "cache"))
+	})
+
+	It("logs trace messages at trace level", func() {
+		newLogger(zerolog.TraceLevel).Trace("trace")
+
+		records := decodeLines(buffer)
+		Expect(records).To(HaveLen(1))
+		Expect(records[0]).To(HaveKeyWithValue(zerolog.LevelFieldName, "trace"))
+	})
+
+	It("does not log below the logger's level", func() {
+		log := newLogger(zerolog.WarnLevel)
+		log.Debug("debug")
+		log.Info("info")
+		Expect(buffer.Len()).To(BeZero())
+		log.Error("error")
+		Expect(decodeLines(buffer)).To(HaveLen(1))
+	})
+})
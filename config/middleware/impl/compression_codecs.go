@@ -0,0 +1,128 @@
+package impl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/pierrec/lz4/v4"
+)
+
+// compressionCodec identifies the format of compressed data by its leading magic bytes.
+type compressionCodec string
+
+const (
+	codecNone   compressionCodec = "none"
+	codecGzip   compressionCodec = "gzip"
+	codecZstd   compressionCodec = "zstd"
+	codecSnappy compressionCodec = "snappy"
+	codecLz4    compressionCodec = "lz4"
+)
+
+// The byte sequences that begin a frame of each supported format.
+var (
+	// https://datatracker.ietf.org/doc/html/rfc1952#page-6
+	gzipMagicBytes = []byte{0x1f, 0x8b}
+	// https://datatracker.ietf.org/doc/html/rfc8878#section-3.1.1
+	zstdMagicBytes = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	// The stream identifier chunk of the snappy framing format.
+	// https://github.com/google/snappy/blob/main/framing_format.txt
+	snappyMagicBytes = []byte{0xff, 0x06, 0x00, 0x00, 's', 'N', 'a', 'P', 'p', 'Y'}
+	// https://github.com/lz4/lz4/blob/dev/doc/lz4_Frame_format.md#general-structure-of-lz4-frame-format
+	lz4MagicBytes = []byte{0x04, 0x22, 0x4d, 0x18}
+)
+
+func detectCompressionCodec(data []byte) compressionCodec {
+	switch {
+	case bytes.HasPrefix(data, zstdMagicBytes):
+		return codecZstd
+	case bytes.HasPrefix(data, lz4MagicBytes):
+		return codecLz4
+	case bytes.HasPrefix(data, snappyMagicBytes):
+		return codecSnappy
+	case bytes.HasPrefix(data, gzipMagicBytes):
+		return codecGzip
+	default:
+		return codecNone
+	}
+}
+
+var (
+	defaultZstdDecoder     *zstd.Decoder
+	defaultZstdDecoderErr  error
+	defaultZstdDecoderOnce sync.Once
+)
+
+// getDefaultZstdDecoder returns a shared zstd decoder without dictionaries. It is created on first use
+// so that clients that never read zstd data don't pay for it.
+func getDefaultZstdDecoder() (*zstd.Decoder, error) {
+	defaultZstdDecoderOnce.Do(func() {
+		defaultZstdDecoder, defaultZstdDecoderErr = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	})
+	return defaultZstdDecoder, defaultZstdDecoderErr
+}
+
+// autoDecompressor decompresses data written by any of the built-in compression strategies, choosing the
+// codec by the magic bytes at the start of the data. Data that does not start with known magic bytes is
+// assumed to be uncompressed and is passed through. This lets a cache be migrated from one codec to another
+// without rewriting the values that were stored with the previous codec.
+type autoDecompressor struct {
+	// zstdDecoder is used in place of the shared default decoder when zstd dictionaries are configured.
+	zstdDecoder *zstd.Decoder
+	logger      logger.MomentoLogger
+}
+
+func (d autoDecompressor) Decompress(data []byte) ([]byte, error) {
+	codec := detectCompressionCodec(data)
+	var decompressed []byte
+	var err error
+	switch codec {
+	case codecGzip:
+		reader, headerErr := gzip.NewReader(bytes.NewReader(data))
+		if headerErr != nil {
+			// Only the first two bytes matched, so this may be an uncompressed value.
+			d.logger.Debug("Failed to read gzip header: %v. Data was not compressed, passing through", headerErr)
+			return data, nil
+		}
+		defer reader.Close()
+		decompressed, err = io.ReadAll(reader)
+	case codecZstd:
+		decoder := d.zstdDecoder
+		if decoder == nil {
+			decoder, err = getDefaultZstdDecoder()
+			if err != nil {
+				d.logger.Error("Failed to create zstd decoder: %v", err)
+				return nil, err
+			}
+		}
+		decompressed, err = decoder.DecodeAll(data, nil)
+	case codecSnappy:
+		decompressed, err = io.ReadAll(s2.NewReader(bytes.NewReader(data)))
+	case codecLz4:
+		decompressed, err = io.ReadAll(lz4.NewReader(bytes.NewReader(data)))
+	default:
+		d.logger.Trace("Data is not compressed, passing through")
+		return data, nil
+	}
+	if err != nil {
+		d.logger.Error("Failed to decompress %s data: %v", codec, err)
+		return nil, fmt.Errorf("failed to decompress %s data: %w", codec, err)
+	}
+	d.logger.Trace("Decompressed %s response: %d bytes -> %d bytes", codec, len(data), len(decompressed))
+	return decompressed, nil
+}
+
+// belowMinimumSize reports whether data should be stored uncompressed because it is smaller than the
+// configured minimum size.
+func belowMinimumSize(data []byte, minimumSizeBytes int, log logger.MomentoLogger) bool {
+	if len(data) < minimumSizeBytes {
+		log.Trace("Skipping compression of %d bytes, below the minimum size of %d bytes", len(data), minimumSizeBytes)
+		return true
+	}
+	return false
+}
@@ -5,12 +5,13 @@ import (
 
 	"github.com/momentohq/client-sdk-go/config/compression"
 	"github.com/momentohq/client-sdk-go/config/middleware"
+	pb "github.com/momentohq/client-sdk-go/internal/protos"
 	"github.com/momentohq/client-sdk-go/momento"
 	"github.com/momentohq/client-sdk-go/responses"
 )
 
 // CompressionMiddleware is the base type for a middleware that compresses and decompresses
-// scalar get and set requests and responses.
+// scalar and collection get and set requests and responses.
 type CompressionMiddleware struct {
 	middleware.Middleware
 	compressor compression.CompressionStrategy
@@ -64,10 +65,20 @@ func getValueBytes(value momento.Value) ([]byte, error) {
 	}
 }
 
-// We currently compress only on these scalar write requests:
+// We currently compress on these scalar write requests:
 // Set, SetIfAbsent, SetIfPresent, SetIfEqual, SetIfNotEqual, SetIfAbsentOrEqual, SetIfPresentAndNotEqual,
-// SetWithHash, SetIfPresentAndHashEqual, SetIfPresentAndHashNotEqual, SetIfAbsentOrHashEqual, SetIfAbsentOrHashNotEqual.
+// SetWithHash, SetIfPresentAndHashEqual, SetIfPresentAndHashNotEqual, SetIfAbsentOrHashEqual, SetIfAbsentOrHashNotEqual,
+// SetBatch.
+//
+// And on these collection requests, where dictionary values, list elements and set elements are compressed
+// and wrapped in a self-describing frame (see compression.EncodeFrame):
+// DictionarySetField, DictionarySetFields, ListPushFront, ListPushBack, ListConcatenateFront, ListConcatenateBack,
+// ListRemoveValue, SetAddElement, SetAddElements, SetRemoveElement, SetRemoveElements, SetContainsElements.
+// Set elements are looked up by their compressed form, so the compression strategy must be deterministic.
+//
 // Specify IncludeTypes in CompressionMiddlewareProps if you wish to compress only a subset of these requests.
+// Note that DictionarySetField, SetAddElement and SetRemoveElement are sent as DictionarySetFieldsRequest,
+// SetAddElementsRequest and SetRemoveElementsRequest respectively.
 func (rh *CompressionMiddlewareRequestHandler) OnRequest(req interface{}) (interface{}, error) {
 	// We still need to use a switch statement to be able to access the request objects
 	// as the specific request types in order to access the Value field.
@@ -204,14 +215,102 @@ func (rh *CompressionMiddlewareRequestHandler) OnRequest(req interface{}) (inter
 		}
 		r.Value = momento.Bytes(compressed)
 		return r, nil
+	case *momento.SetBatchRequest:
+		items := make([]momento.BatchSetItem, 0, len(r.Items))
+		for _, item := range r.Items {
+			rawData, err := getValueBytes(item.Value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get value bytes: %v", err)
+			}
+			compressed, err := rh.compressor.Compress(rawData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compress SetBatchRequest: %v", err)
+			}
+			items = append(items, momento.BatchSetItem{Key: item.Key, Value: momento.Bytes(compressed)})
+		}
+		r.Items = items
+		return r, nil
+	case *momento.DictionarySetFieldsRequest:
+		elements := make([]momento.DictionaryElement, 0, len(r.Elements))
+		for _, element := range r.Elements {
+			compressed, err := rh.compressFramed(element.Value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compress DictionarySetFieldsRequest: %v", err)
+			}
+			elements = append(elements, momento.DictionaryElement{Field: element.Field, Value: compressed})
+		}
+		r.Elements = elements
+		return r, nil
+	case *momento.ListPushFrontRequest:
+		compressed, err := rh.compressFramed(r.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress ListPushFrontRequest: %v", err)
+		}
+		r.Value = compressed
+		return r, nil
+	case *momento.ListPushBackRequest:
+		compressed, err := rh.compressFramed(r.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress ListPushBackRequest: %v", err)
+		}
+		r.Value = compressed
+		return r, nil
+	case *momento.ListConcatenateFrontRequest:
+		compressed, err := rh.compressFramedValues(r.Values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress ListConcatenateFrontRequest: %v", err)
+		}
+		r.Values = compressed
+		return r, nil
+	case *momento.ListConcatenateBackRequest:
+		compressed, err := rh.compressFramedValues(r.Values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress ListConcatenateBackRequest: %v", err)
+		}
+		r.Values = compressed
+		return r, nil
+	case *momento.ListRemoveValueRequest:
+		compressed, err := rh.compressFramed(r.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress ListRemoveValueRequest: %v", err)
+		}
+		r.Value = compressed
+		return r, nil
+	case *momento.SetAddElementsRequest:
+		compressed, err := rh.compressFramedValues(r.Elements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress SetAddElementsRequest: %v", err)
+		}
+		r.Elements = compressed
+		return r, nil
+	case *momento.SetRemoveElementsRequest:
+		compressed, err := rh.compressFramedValues(r.Elements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress SetRemoveElementsRequest: %v", err)
+		}
+		r.Elements = compressed
+		return r, nil
+	case *momento.SetContainsElementsRequest:
+		compressed, err := rh.compressFramedValues(r.Elements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress SetContainsElementsRequest: %v", err)
+		}
+		r.Elements = compressed
+		return r, nil
 	default:
 		rh.GetLogger().Info("No action for OnRequest type: %T", req)
 		return req, nil
 	}
 }
 
-// We currently decompress only on these scalar read responses: Get, GetWithHash.
+// We currently decompress on these scalar read responses: Get, GetWithHash, GetBatch.
+//
+// And on these collection read responses, where only framed values are decompressed and any other value
+// is assumed to have been written without compression and is returned unchanged:
+// DictionaryFetch, DictionaryGetField, DictionaryGetFields, ListFetch, ListPopFront, ListPopBack, SetFetch, SetPop.
+//
 // Specify IncludeTypes in CompressionMiddlewareProps if you wish to decompress only a subset of these responses.
+// Note that DictionaryGetField is sent as a DictionaryGetFieldsRequest.
 func (rh *CompressionMiddlewareRequestHandler) OnResponse(resp interface{}) (interface{}, error) {
 	// We still need to use a switch statement to be able to access the response objects
 	// as the specific response types in order to access the Value field.
@@ -230,8 +329,160 @@ func (rh *CompressionMiddlewareRequestHandler) OnResponse(resp interface{}) (int
 			return nil, fmt.Errorf("failed to decompress GetWithHashHit response: %v", err)
 		}
 		return responses.NewGetWithHashHit(decompressed, r.HashByte()), nil
+	case responses.GetBatchSuccess:
+		return rh.decompressGetBatch(r)
+	case *responses.GetBatchSuccess:
+		return rh.decompressGetBatch(*r)
+	case *responses.DictionaryFetchHit:
+		elements := make(map[string][]byte, len(r.ValueMapStringByte()))
+		for field, value := range r.ValueMapStringByte() {
+			decompressed, err := decompressFramed(rh.compressor, value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decompress DictionaryFetchHit response: %v", err)
+			}
+			elements[field] = decompressed
+		}
+		return responses.NewDictionaryFetchHit(elements), nil
+	case *responses.DictionaryGetFieldsHit:
+		var fields [][]byte
+		var items []*pb.XDictionaryGetResponse_XDictionaryGetResponsePart
+		var fieldResponses []responses.DictionaryGetFieldResponse
+		for _, fieldResponse := range r.Responses() {
+			switch fr := fieldResponse.(type) {
+			case *responses.DictionaryGetFieldHit:
+				decompressed, err := decompressFramed(rh.compressor, fr.ValueByte())
+				if err != nil {
+					return nil, fmt.Errorf("failed to decompress DictionaryGetFieldsHit response: %v", err)
+				}
+				fields = append(fields, fr.FieldByte())
+				items = append(items, &pb.XDictionaryGetResponse_XDictionaryGetResponsePart{
+					Result:    pb.ECacheResult_Hit,
+					CacheBody: decompressed,
+				})
+				fieldResponses = append(fieldResponses, responses.NewDictionaryGetFieldHit(fr.FieldByte(), decompressed))
+			case *responses.DictionaryGetFieldMiss:
+				fields = append(fields, fr.FieldByte())
+				items = append(items, &pb.XDictionaryGetResponse_XDictionaryGetResponsePart{
+					Result: pb.ECacheResult_Miss,
+				})
+				fieldResponses = append(fieldResponses, fr)
+			default:
+				return resp, nil
+			}
+		}
+		return responses.NewDictionaryGetFieldsHit(fields, items, fieldResponses), nil
+	case *responses.ListFetchHit:
+		decompressed, err := decompressFramedList(rh.compressor, r.ValueListByte())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress ListFetchHit response: %v", err)
+		}
+		return responses.NewListFetchHit(decompressed), nil
+	case *responses.ListPopFrontHit:
+		decompressed, err := decompressFramed(rh.compressor, r.ValueByte())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress ListPopFrontHit response: %v", err)
+		}
+		return responses.NewListPopFrontHit(decompressed), nil
+	case *responses.ListPopBackHit:
+		decompressed, err := decompressFramed(rh.compressor, r.ValueByte())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress ListPopBackHit response: %v", err)
+		}
+		return responses.NewListPopBackHit(decompressed), nil
+	case *responses.SetFetchHit:
+		decompressed, err := decompressFramedList(rh.compressor, r.ValueByte())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress SetFetchHit response: %v", err)
+		}
+		return responses.NewSetFetchHit(decompressed), nil
+	case *responses.SetPopHit:
+		decompressed, err := decompressFramedList(rh.compressor, r.ValueByte())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress SetPopHit response: %v", err)
+		}
+		return responses.NewSetPopHit(decompressed), nil
 	default:
 		rh.GetLogger().Info("No action for OnResponse type: %T", resp)
 		return resp, nil
 	}
 }
+
+// decompressGetBatch decompresses each hit in a GetBatch response. The keys are not exposed on the
+// response, so they are recovered from the original request.
+func (rh *CompressionMiddlewareRequestHandler) decompressGetBatch(r responses.GetBatchSuccess) (interface{}, error) {
+	request, ok := rh.GetRequest().(*momento.GetBatchRequest)
+	if !ok {
+		return r, nil
+	}
+	keys := make([][]byte, 0, len(request.Keys))
+	for _, key := range request.Keys {
+		keyBytes, err := getValueBytes(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key bytes: %v", err)
+		}
+		keys = append(keys, keyBytes)
+	}
+
+	results := make([]responses.GetResponse, 0, len(r.Results()))
+	for _, result := range r.Results() {
+		switch hit := result.(type) {
+		case *responses.GetHit:
+			decompressed, err := rh.compressor.Decompress(hit.ValueByte())
+			if err != nil {
+				return nil, fmt.Errorf("failed to decompress GetBatchSuccess response: %v", err)
+			}
+			results = append(results, responses.NewGetHit(decompressed))
+		default:
+			results = append(results, result)
+		}
+	}
+	return *responses.NewGetBatchSuccess(results, keys), nil
+}
+
+// compressFramed compresses a collection element and wraps it in a self-describing frame, so that
+// elements written before compression was enabled can still be told apart and read back unchanged.
+func (rh *CompressionMiddlewareRequestHandler) compressFramed(value momento.Value) (momento.Value, error) {
+	rawData, err := getValueBytes(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get value bytes: %v", err)
+	}
+	compressed, err := rh.compressor.Compress(rawData)
+	if err != nil {
+		return nil, err
+	}
+	return momento.Bytes(compression.EncodeFrame(compressed, 0)), nil
+}
+
+func (rh *CompressionMiddlewareRequestHandler) compressFramedValues(values []momento.Value) ([]momento.Value, error) {
+	compressedValues := make([]momento.Value, 0, len(values))
+	for _, value := range values {
+		compressed, err := rh.compressFramed(value)
+		if err != nil {
+			return nil, err
+		}
+		compressedValues = append(compressedValues, compressed)
+	}
+	return compressedValues, nil
+}
+
+// decompressFramed decompresses a value written by compressFramed. Values without a frame header
+// were written without compression and are returned unchanged.
+func decompressFramed(compressor compression.CompressionStrategy, data []byte) ([]byte, error) {
+	payload, _, ok := compression.DecodeFrame(data)
+	if !ok {
+		return data, nil
+	}
+	return compressor.Decompress(payload)
+}
+
+func decompressFramedList(compressor compression.CompressionStrategy, data [][]byte) ([][]byte, error) {
+	decompressedList := make([][]byte, 0, len(data))
+	for _, value := range data {
+		decompressed, err := decompressFramed(compressor, value)
+		if err != nil {
+			return nil, err
+		}
+		decompressedList = append(decompressedList, decompressed)
+	}
+	return decompressedList, nil
+}
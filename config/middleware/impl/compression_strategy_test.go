@@ -0,0 +1,139 @@
+package impl_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/momentohq/client-sdk-go/config/compression"
+	"github.com/momentohq/client-sdk-go/config/middleware/impl"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("compression-strategy", func() {
+	compressible := []byte(strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 20))
+
+	factories := map[string]compression.CompressionStrategyFactory{
+		"gzip":   impl.GzipCompressorFactory{},
+		"zstd":   impl.ZstdCompressorFactory{},
+		"snappy": impl.SnappyCompressorFactory{},
+		"lz4":    impl.Lz4CompressorFactory{},
+	}
+
+	DescribeTable("round trips values at each compression level",
+		func(name string, level compression.CompressionLevel) {
+			strategy := factories[name].NewCompressionStrategy(compression.CompressionStrategyProps{CompressionLevel: level})
+
+			compressed, err := strategy.Compress(compressible)
+			Expect(err).To(BeNil())
+			Expect(len(compressed)).To(BeNumerically("<", len(compressible)))
+
+			decompressed, err := strategy.Decompress(compressed)
+			Expect(err).To(BeNil())
+			Expect(decompressed).To(Equal(compressible))
+		},
+		Entry("gzip default", "gzip", compression.CompressionLevelDefault),
+		Entry("gzip fastest", "gzip", compression.CompressionLevelFastest),
+		Entry("gzip smallest", "gzip", compression.CompressionLevelSmallestSize),
+		Entry("zstd default", "zstd", compression.CompressionLevelDefault),
+		Entry("zstd fastest", "zstd", compression.CompressionLevelFastest),
+		Entry("zstd smallest", "zstd", compression.CompressionLevelSmallestSize),
+		Entry("snappy default", "snappy", compression.CompressionLevelDefault),
+		Entry("snappy smallest", "snappy", compression.CompressionLevelSmallestSize),
+		Entry("lz4 default", "lz4", compression.CompressionLevelDefault),
+		Entry("lz4 smallest", "lz4", compression.CompressionLevelSmallestSize),
+	)
+
+	It("decompresses values written by any built-in codec", func() {
+		for writerName, writerFactory := range factories {
+			compressed, err := writerFactory.NewCompressionStrategy(compression.CompressionStrategyProps{}).Compress(compressible)
+			Expect(err).To(BeNil())
+			for readerName, readerFactory := range factories {
+				decompressed, err := readerFactory.NewCompressionStrategy(compression.CompressionStrategyProps{}).Decompress(compressed)
+				Expect(err).To(BeNil(), fmt.Sprintf("%s reading %s", readerName, writerName))
+				Expect(decompressed).To(Equal(compressible), fmt.Sprintf("%s reading %s", readerName, writerName))
+			}
+		}
+	})
+
+	It("passes through values that were not compressed", func() {
+		for name, factory := range factories {
+			decompressed, err := factory.NewCompressionStrategy(compression.CompressionStrategyProps{}).Decompress(compressible)
+			Expect(err).To(BeNil(), name)
+			Expect(decompressed).To(Equal(compressible), name)
+		}
+	})
+
+	It("does not compress values below the minimum size", func() {
+		for name, factory := range factories {
+			strategy := factory.NewCompressionStrategy(compression.CompressionStrategyProps{MinimumSizeBytes: 64})
+
+			small := []byte("a small value")
+			compressed, err := strategy.Compress(small)
+			Expect(err).To(BeNil(), name)
+			Expect(compressed).To(Equal(small), name)
+
+			compressed, err = strategy.Compress(compressible)
+			Expect(err).To(BeNil(), name)
+			Expect(compressed).ToNot(Equal(compressible), name)
+		}
+	})
+
+	It("compresses small json documents with a trained zstd dictionary", func() {
+		var samples [][]byte
+		for i := 0; i < 500; i++ {
+			sample, err := json.Marshal(map[string]interface{}{
+				"id":     i,
+				"name":   fmt.Sprintf("user-%d", i),
+				"email":  fmt.Sprintf("user-%d@example.com", i),
+				"active": i%2 == 0,
+			})
+			Expect(err).To(BeNil())
+			samples = append(samples, sample)
+		}
+		dictionary, err := impl.TrainZstdDictionary(samples, 4096)
+		Expect(err).To(BeNil())
+
+		withDictionary := impl.ZstdCompressorFactory{Dictionary: dictionary}.NewCompressionStrategy(compression.CompressionStrategyProps{})
+		withoutDictionary := impl.ZstdCompressorFactory{}.NewCompressionStrategy(compression.CompressionStrategyProps{})
+
+		value := []byte(`{"active":true,"email":"user-9999@example.com","id":9999,"name":"user-9999"}`)
+		compressedWithDictionary, err := withDictionary.Compress(value)
+		Expect(err).To(BeNil())
+		compressedWithoutDictionary, err := withoutDictionary.Compress(value)
+		Expect(err).To(BeNil())
+		Expect(len(compressedWithDictionary)).To(BeNumerically("<", len(compressedWithoutDictionary)))
+
+		decompressed, err := withDictionary.Decompress(compressedWithDictionary)
+		Expect(err).To(BeNil())
+		Expect(decompressed).To(Equal(value))
+
+		// A reader configured with the dictionary only for decoding can read the value too.
+		reader := impl.ZstdCompressorFactory{DecoderDictionaries: [][]byte{dictionary}}.NewCompressionStrategy(compression.CompressionStrategyProps{})
+		decompressed, err = reader.Decompress(compressedWithDictionary)
+		Expect(err).To(BeNil())
+		Expect(decompressed).To(Equal(value))
+	})
+
+	It("ignores malformed zstd dictionaries instead of panicking", func() {
+		factory := impl.ZstdCompressorFactory{
+			Dictionary:          []byte("not a dictionary"),
+			DecoderDictionaries: [][]byte{{0x37, 0xa4, 0x30, 0xec}},
+		}
+		Expect(factory.Validate()).To(MatchError(ContainSubstring("invalid zstd dictionary")))
+		Expect(impl.ZstdCompressorFactory{DecoderDictionaries: factory.DecoderDictionaries}.Validate()).To(
+			MatchError(ContainSubstring("invalid zstd decoder dictionary 0")))
+		Expect(impl.ZstdCompressorFactory{}.Validate()).To(Succeed())
+
+		var strategy compression.CompressionStrategy
+		Expect(func() {
+			strategy = factory.NewCompressionStrategy(compression.CompressionStrategyProps{})
+		}).NotTo(Panic())
+		compressed, err := strategy.Compress(compressible)
+		Expect(err).To(BeNil())
+		decompressed, err := strategy.Decompress(compressed)
+		Expect(err).To(BeNil())
+		Expect(decompressed).To(Equal(compressible))
+	})
+})
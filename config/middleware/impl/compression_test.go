@@ -11,6 +11,8 @@ import (
 	"github.com/momentohq/client-sdk-go/config"
 	"github.com/momentohq/client-sdk-go/config/compression"
 	"github.com/momentohq/client-sdk-go/config/logger/momento_default_logger"
+	"github.com/momentohq/client-sdk-go/config/middleware"
+	"github.com/momentohq/client-sdk-go/config/middleware/impl"
 	impl_test_helpers "github.com/momentohq/client-sdk-go/config/middleware/impl/test_helpers"
 	. "github.com/momentohq/client-sdk-go/momento"
 	"github.com/momentohq/client-sdk-go/responses"
@@ -395,4 +397,186 @@ var _ = Describe("gzip-compression-middleware", Label("cache-service"), func() {
 			verifyCompressionFromChannels(compressedDataChannel, decompressedDataChannel, originalSize)
 		})
 	})
+
+	Describe("when compressing collections", func() {
+		newGzipMiddleware := func() middleware.Middleware {
+			return impl.NewGzipCompressionMiddleware(impl.GzipCompressionMiddlewareProps{
+				CompressionStrategyProps: compression.CompressionStrategyProps{
+					CompressionLevel: compression.CompressionLevelDefault,
+					Logger:           momento_default_logger.NewDefaultMomentoLoggerFactory(momento_default_logger.TRACE).GetLogger("gzip-test"),
+				},
+			})
+		}
+
+		It("should compress dictionary values", func() {
+			createCacheClient(config.LaptopLatest().AddMiddleware(newGzipMiddleware()))
+
+			value1 := getCompressableString()
+			value2 := getCompressableString()
+			_, err := cacheClient.DictionarySetFields(testCtx, &DictionarySetFieldsRequest{
+				CacheName:      cacheName,
+				DictionaryName: "dictionary",
+				Elements: DictionaryElementsFromMapStringString(map[string]string{
+					"field1": value1,
+					"field2": value2,
+				}),
+			})
+			Expect(err).To(BeNil())
+
+			fetchResp, err := cacheClient.DictionaryFetch(testCtx, &DictionaryFetchRequest{
+				CacheName:      cacheName,
+				DictionaryName: "dictionary",
+			})
+			Expect(err).To(BeNil())
+			Expect(fetchResp).To(BeAssignableToTypeOf(&responses.DictionaryFetchHit{}))
+			Expect(fetchResp.(*responses.DictionaryFetchHit).ValueMap()).To(Equal(map[string]string{
+				"field1": value1,
+				"field2": value2,
+			}))
+
+			getFieldsResp, err := cacheClient.DictionaryGetFields(testCtx, &DictionaryGetFieldsRequest{
+				CacheName:      cacheName,
+				DictionaryName: "dictionary",
+				Fields:         []Value{String("field1"), String("missing")},
+			})
+			Expect(err).To(BeNil())
+			Expect(getFieldsResp).To(BeAssignableToTypeOf(&responses.DictionaryGetFieldsHit{}))
+			Expect(getFieldsResp.(*responses.DictionaryGetFieldsHit).ValueMap()).To(Equal(map[string]string{
+				"field1": value1,
+			}))
+
+			getFieldResp, err := cacheClient.DictionaryGetField(testCtx, &DictionaryGetFieldRequest{
+				CacheName:      cacheName,
+				DictionaryName: "dictionary",
+				Field:          String("field2"),
+			})
+			Expect(err).To(BeNil())
+			Expect(getFieldResp).To(BeAssignableToTypeOf(&responses.DictionaryGetFieldHit{}))
+			Expect(getFieldResp.(*responses.DictionaryGetFieldHit).ValueString()).To(Equal(value2))
+		})
+
+		It("should compress list elements", func() {
+			createCacheClient(config.LaptopLatest().AddMiddleware(newGzipMiddleware()))
+
+			front := getCompressableString()
+			middle := []string{getCompressableString(), getCompressableString()}
+			back := getCompressableString()
+			_, err := cacheClient.ListConcatenateBack(testCtx, &ListConcatenateBackRequest{
+				CacheName: cacheName,
+				ListName:  "list",
+				Values:    []Value{String(middle[0]), String(middle[1])},
+			})
+			Expect(err).To(BeNil())
+			_, err = cacheClient.ListPushFront(testCtx, &ListPushFrontRequest{
+				CacheName: cacheName,
+				ListName:  "list",
+				Value:     String(front),
+			})
+			Expect(err).To(BeNil())
+			_, err = cacheClient.ListPushBack(testCtx, &ListPushBackRequest{
+				CacheName: cacheName,
+				ListName:  "list",
+				Value:     String(back),
+			})
+			Expect(err).To(BeNil())
+
+			fetchResp, err := cacheClient.ListFetch(testCtx, &ListFetchRequest{
+				CacheName: cacheName,
+				ListName:  "list",
+			})
+			Expect(err).To(BeNil())
+			Expect(fetchResp).To(BeAssignableToTypeOf(&responses.ListFetchHit{}))
+			Expect(fetchResp.(*responses.ListFetchHit).ValueList()).To(Equal([]string{front, middle[0], middle[1], back}))
+
+			popResp, err := cacheClient.ListPopFront(testCtx, &ListPopFrontRequest{
+				CacheName: cacheName,
+				ListName:  "list",
+			})
+			Expect(err).To(BeNil())
+			Expect(popResp).To(BeAssignableToTypeOf(&responses.ListPopFrontHit{}))
+			Expect(popResp.(*responses.ListPopFrontHit).ValueString()).To(Equal(front))
+
+			_, err = cacheClient.ListRemoveValue(testCtx, &ListRemoveValueRequest{
+				CacheName: cacheName,
+				ListName:  "list",
+				Value:     String(back),
+			})
+			Expect(err).To(BeNil())
+
+			popResp2, err := cacheClient.ListPopBack(testCtx, &ListPopBackRequest{
+				CacheName: cacheName,
+				ListName:  "list",
+			})
+			Expect(err).To(BeNil())
+			Expect(popResp2).To(BeAssignableToTypeOf(&responses.ListPopBackHit{}))
+			Expect(popResp2.(*responses.ListPopBackHit).ValueString()).To(Equal(middle[1]))
+		})
+
+		It("should compress set elements", func() {
+			createCacheClient(config.LaptopLatest().AddMiddleware(newGzipMiddleware()))
+
+			element1 := getCompressableString()
+			element2 := getCompressableString()
+			_, err := cacheClient.SetAddElements(testCtx, &SetAddElementsRequest{
+				CacheName: cacheName,
+				SetName:   "set",
+				Elements:  []Value{String(element1), String(element2)},
+			})
+			Expect(err).To(BeNil())
+
+			containsResp, err := cacheClient.SetContainsElements(testCtx, &SetContainsElementsRequest{
+				CacheName: cacheName,
+				SetName:   "set",
+				Elements:  []Value{String(element1), String("missing")},
+			})
+			Expect(err).To(BeNil())
+			Expect(containsResp).To(BeAssignableToTypeOf(&responses.SetContainsElementsHit{}))
+			Expect(containsResp.(*responses.SetContainsElementsHit).ContainsElements()).To(Equal([]bool{true, false}))
+
+			_, err = cacheClient.SetRemoveElement(testCtx, &SetRemoveElementRequest{
+				CacheName: cacheName,
+				SetName:   "set",
+				Element:   String(element1),
+			})
+			Expect(err).To(BeNil())
+
+			fetchResp, err := cacheClient.SetFetch(testCtx, &SetFetchRequest{
+				CacheName: cacheName,
+				SetName:   "set",
+			})
+			Expect(err).To(BeNil())
+			Expect(fetchResp).To(BeAssignableToTypeOf(&responses.SetFetchHit{}))
+			Expect(fetchResp.(*responses.SetFetchHit).ValueString()).To(ConsistOf(element2))
+		})
+
+		It("should pass through collection elements that were not compressed", func() {
+			createCacheClient(config.LaptopLatest())
+			value := getCompressableString()
+			_, err := cacheClient.ListPushBack(testCtx, &ListPushBackRequest{
+				CacheName: cacheName,
+				ListName:  "list",
+				Value:     String(value),
+			})
+			Expect(err).To(BeNil())
+
+			//lint:ignore SA1019 // Still supporting FromEnvironmentVariable for backwards compatibility
+			credentialProvider, err := auth.FromEnvironmentVariable("V1_API_KEY")
+			Expect(err).To(BeNil())
+			compressingClient, err := NewCacheClient(
+				config.LaptopLatest().AddMiddleware(newGzipMiddleware()),
+				credentialProvider,
+				time.Second*60,
+			)
+			Expect(err).To(BeNil())
+			defer compressingClient.Close()
+
+			fetchResp, err := compressingClient.ListFetch(testCtx, &ListFetchRequest{
+				CacheName: cacheName,
+				ListName:  "list",
+			})
+			Expect(err).To(BeNil())
+			Expect(fetchResp).To(BeAssignableToTypeOf(&responses.ListFetchHit{}))
+			Expect(fetchResp.(*responses.ListFetchHit).ValueList()).To(Equal([]string{value}))
+		})
+	})
 })
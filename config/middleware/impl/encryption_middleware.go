@@ -0,0 +1,476 @@
+package impl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/momentohq/client-sdk-go/config/encryption"
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/config/middleware"
+	pb "github.com/momentohq/client-sdk-go/internal/protos"
+	"github.com/momentohq/client-sdk-go/momento"
+	"github.com/momentohq/client-sdk-go/responses"
+)
+
+// EncryptionMiddleware encrypts values on the client with AES-GCM before they are sent to Momento, and
+// decrypts them when they are read back. Each encrypted value records the id of the key it was encrypted
+// with, so keys can be rotated without rewriting the values already in the cache.
+//
+// When combined with a CompressionMiddleware, add the compression middleware first so that values are
+// compressed before they are encrypted; encrypted data does not compress.
+type EncryptionMiddleware struct {
+	middleware.Middleware
+	encryptor              *encryption.Encryptor
+	keyHashingSecret       []byte
+	allowUnencryptedValues bool
+}
+
+type EncryptionMiddlewareProps struct {
+	IncludeTypes []interface{}
+	Logger       logger.MomentoLogger
+	// KeyProvider supplies the keys used to encrypt and decrypt values. Required.
+	KeyProvider encryption.KeyProvider
+	// KeyHashingSecret, if set, is used to replace the key of every scalar item with its HMAC-SHA256, so
+	// that the keys stored in Momento reveal nothing about the data. Lookups still work because the same
+	// key always hashes to the same value. Unlike the encryption keys, this secret cannot be rotated
+	// without making every existing item unreachable. Collection names are not hashed.
+	KeyHashingSecret []byte
+	// AllowUnencryptedValues lets the middleware read values that were written without encryption, which
+	// is needed while migrating an existing cache. By default such values are rejected with an
+	// EncryptionIntegrityError, since anyone able to write to the cache could otherwise inject them.
+	AllowUnencryptedValues bool
+}
+
+// NewEncryptionMiddleware creates a new encryption middleware. It returns an InvalidArgumentError if no
+// KeyProvider is given.
+// Example usage:
+//
+//	keys, err := encryption.NewInMemoryKeyProvider(encryption.DataKey{Id: "2024-06", Material: key})
+//	if err != nil {
+//		panic(err)
+//	}
+//	encryptionMiddleware, err := impl.NewEncryptionMiddleware(impl.EncryptionMiddlewareProps{
+//		KeyProvider: keys,
+//	})
+func NewEncryptionMiddleware(props EncryptionMiddlewareProps) (middleware.Middleware, error) {
+	if props.KeyProvider == nil {
+		return nil, momento.NewMomentoError(momento.InvalidArgumentError, "a key provider must be provided", nil)
+	}
+	if props.Logger == nil {
+		props.Logger = logger.NewNoopMomentoLoggerFactory().GetLogger("encryption-middleware")
+	}
+	mw := middleware.NewMiddleware(middleware.Props{
+		Logger:       props.Logger,
+		IncludeTypes: props.IncludeTypes,
+	})
+	return &EncryptionMiddleware{
+		Middleware:             mw,
+		encryptor:              encryption.NewEncryptor(props.KeyProvider),
+		keyHashingSecret:       props.KeyHashingSecret,
+		allowUnencryptedValues: props.AllowUnencryptedValues,
+	}, nil
+}
+
+func (mw *EncryptionMiddleware) GetRequestHandler(
+	baseHandler middleware.RequestHandler,
+) (middleware.RequestHandler, error) {
+	return &EncryptionMiddlewareRequestHandler{
+		RequestHandler:         baseHandler,
+		encryptor:              mw.encryptor,
+		keyHashingSecret:       mw.keyHashingSecret,
+		allowUnencryptedValues: mw.allowUnencryptedValues,
+	}, nil
+}
+
+type EncryptionMiddlewareRequestHandler struct {
+	middleware.RequestHandler
+	encryptor              *encryption.Encryptor
+	keyHashingSecret       []byte
+	allowUnencryptedValues bool
+	// batchKeys holds the original keys of a GetBatch request when keys are hashed, so that the
+	// response can be returned with the keys the caller asked for.
+	batchKeys [][]byte
+}
+
+// We currently encrypt the values of these scalar write requests:
+// Set, SetIfAbsent, SetIfPresent, SetWithHash, SetIfPresentAndHashEqual, SetIfPresentAndHashNotEqual,
+// SetIfAbsentOrHashEqual, SetIfAbsentOrHashNotEqual, SetBatch.
+//
+// And on these collection requests:
+// DictionarySetField, DictionarySetFields, ListPushFront, ListPushBack, ListConcatenateFront, ListConcatenateBack,
+// ListRemoveValue, SetAddElement, SetAddElements, SetRemoveElement, SetRemoveElements, SetContainsElements.
+//
+// Scalar and dictionary values are encrypted with a random nonce. List and set elements are compared by the
+// server, so they are encrypted deterministically: equal elements encrypted under the same key are visible
+// as equal, and ListRemoveValue, SetRemoveElements and SetContainsElements only match elements written with
+// the current key. SetIfEqual, SetIfNotEqual, SetIfAbsentOrEqual and SetIfPresentAndNotEqual compare the
+// stored value with a plaintext one, which would never match an encrypted value, so they are rejected with
+// an InvalidArgumentError; use the hash based conditional sets instead.
+//
+// Specify IncludeTypes in EncryptionMiddlewareProps if you wish to encrypt only a subset of these requests.
+// Note that DictionarySetField, SetAddElement and SetRemoveElement are sent as DictionarySetFieldsRequest,
+// SetAddElementsRequest and SetRemoveElementsRequest respectively.
+func (rh *EncryptionMiddlewareRequestHandler) OnRequest(req interface{}) (interface{}, error) {
+	req, err := rh.hashKeys(req)
+	if err != nil {
+		return nil, rh.encryptionError(req, err)
+	}
+
+	switch r := req.(type) {
+	case *momento.SetRequest:
+		err = rh.encryptValue(&r.Value, false)
+	case *momento.SetIfAbsentRequest:
+		err = rh.encryptValue(&r.Value, false)
+	case *momento.SetIfPresentRequest:
+		err = rh.encryptValue(&r.Value, false)
+	case *momento.SetIfEqualRequest, *momento.SetIfNotEqualRequest,
+		*momento.SetIfAbsentOrEqualRequest, *momento.SetIfPresentAndNotEqualRequest:
+		return nil, momento.NewMomentoError(
+			momento.InvalidArgumentError,
+			fmt.Sprintf("%T compares the stored value with a value that cannot be encrypted to match it; use the hash based conditional sets instead", r),
+			nil,
+		)
+	case *momento.SetWithHashRequest:
+		err = rh.encryptValue(&r.Value, false)
+	case *momento.SetIfPresentAndHashEqualRequest:
+		err = rh.encryptValue(&r.Value, false)
+	case *momento.SetIfPresentAndHashNotEqualRequest:
+		err = rh.encryptValue(&r.Value, false)
+	case *momento.SetIfAbsentOrHashEqualRequest:
+		err = rh.encryptValue(&r.Value, false)
+	case *momento.SetIfAbsentOrHashNotEqualRequest:
+		err = rh.encryptValue(&r.Value, false)
+	case *momento.SetBatchRequest:
+		items := make([]momento.BatchSetItem, len(r.Items))
+		copy(items, r.Items)
+		for i := range items {
+			if err = rh.encryptValue(&items[i].Value, false); err != nil {
+				break
+			}
+		}
+		r.Items = items
+	case *momento.DictionarySetFieldsRequest:
+		elements := make([]momento.DictionaryElement, len(r.Elements))
+		copy(elements, r.Elements)
+		for i := range elements {
+			if err = rh.encryptValue(&elements[i].Value, false); err != nil {
+				break
+			}
+		}
+		r.Elements = elements
+	case *momento.ListPushFrontRequest:
+		err = rh.encryptValue(&r.Value, true)
+	case *momento.ListPushBackRequest:
+		err = rh.encryptValue(&r.Value, true)
+	case *momento.ListConcatenateFrontRequest:
+		r.Values, err = rh.encryptValues(r.Values)
+	case *momento.ListConcatenateBackRequest:
+		r.Values, err = rh.encryptValues(r.Values)
+	case *momento.ListRemoveValueRequest:
+		err = rh.encryptValue(&r.Value, true)
+	case *momento.SetAddElementsRequest:
+		r.Elements, err = rh.encryptValues(r.Elements)
+	case *momento.SetRemoveElementsRequest:
+		r.Elements, err = rh.encryptValues(r.Elements)
+	case *momento.SetContainsElementsRequest:
+		r.Elements, err = rh.encryptValues(r.Elements)
+	default:
+		rh.GetLogger().Info("No action for OnRequest type: %T", req)
+	}
+	if err != nil {
+		return nil, rh.encryptionError(req, err)
+	}
+	return req, nil
+}
+
+// We currently decrypt these scalar read responses: Get, GetWithHash, GetBatch.
+//
+// And these collection read responses:
+// DictionaryFetch, DictionaryGetField, DictionaryGetFields, ListFetch, ListPopFront, ListPopBack, SetFetch, SetPop.
+//
+// A value that fails its integrity check, or was encrypted with a key the KeyProvider does not have, is
+// reported as an EncryptionIntegrityError.
+func (rh *EncryptionMiddlewareRequestHandler) OnResponse(resp interface{}) (interface{}, error) {
+	newResp, err := rh.decryptResponse(resp)
+	if err != nil {
+		return nil, rh.decryptionError(resp, err)
+	}
+	return newResp, nil
+}
+
+func (rh *EncryptionMiddlewareRequestHandler) decryptResponse(resp interface{}) (interface{}, error) {
+	switch r := resp.(type) {
+	case *responses.GetHit:
+		decrypted, err := rh.decrypt(r.ValueByte())
+		if err != nil {
+			return nil, err
+		}
+		return responses.NewGetHit(decrypted), nil
+	case *responses.GetWithHashHit:
+		decrypted, err := rh.decrypt(r.ValueByte())
+		if err != nil {
+			return nil, err
+		}
+		return responses.NewGetWithHashHit(decrypted, r.HashByte()), nil
+	case responses.GetBatchSuccess:
+		return rh.decryptGetBatch(r)
+	case *responses.GetBatchSuccess:
+		return rh.decryptGetBatch(*r)
+	case *responses.DictionaryFetchHit:
+		elements := make(map[string][]byte, len(r.ValueMapStringByte()))
+		for field, value := range r.ValueMapStringByte() {
+			decrypted, err := rh.decrypt(value)
+			if err != nil {
+				return nil, err
+			}
+			elements[field] = decrypted
+		}
+		return responses.NewDictionaryFetchHit(elements), nil
+	case *responses.DictionaryGetFieldsHit:
+		var fields [][]byte
+		var items []*pb.XDictionaryGetResponse_XDictionaryGetResponsePart
+		var fieldResponses []responses.DictionaryGetFieldResponse
+		for _, fieldResponse := range r.Responses() {
+			switch fr := fieldResponse.(type) {
+			case *responses.DictionaryGetFieldHit:
+				decrypted, err := rh.decrypt(fr.ValueByte())
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, fr.FieldByte())
+				items = append(items, &pb.XDictionaryGetResponse_XDictionaryGetResponsePart{
+					Result:    pb.ECacheResult_Hit,
+					CacheBody: decrypted,
+				})
+				fieldResponses = append(fieldResponses, responses.NewDictionaryGetFieldHit(fr.FieldByte(), decrypted))
+			case *responses.DictionaryGetFieldMiss:
+				fields = append(fields, fr.FieldByte())
+				items = append(items, &pb.XDictionaryGetResponse_XDictionaryGetResponsePart{
+					Result: pb.ECacheResult_Miss,
+				})
+				fieldResponses = append(fieldResponses, fr)
+			default:
+				return resp, nil
+			}
+		}
+		return responses.NewDictionaryGetFieldsHit(fields, items, fieldResponses), nil
+	case *responses.ListFetchHit:
+		decrypted, err := rh.decryptList(r.ValueListByte())
+		if err != nil {
+			return nil, err
+		}
+		return responses.NewListFetchHit(decrypted), nil
+	case *responses.ListPopFrontHit:
+		decrypted, err := rh.decrypt(r.ValueByte())
+		if err != nil {
+			return nil, err
+		}
+		return responses.NewListPopFrontHit(decrypted), nil
+	case *responses.ListPopBackHit:
+		decrypted, err := rh.decrypt(r.ValueByte())
+		if err != nil {
+			return nil, err
+		}
+		return responses.NewListPopBackHit(decrypted), nil
+	case *responses.SetFetchHit:
+		decrypted, err := rh.decryptList(r.ValueByte())
+		if err != nil {
+			return nil, err
+		}
+		return responses.NewSetFetchHit(decrypted), nil
+	case *responses.SetPopHit:
+		decrypted, err := rh.decryptList(r.ValueByte())
+		if err != nil {
+			return nil, err
+		}
+		return responses.NewSetPopHit(decrypted), nil
+	default:
+		rh.GetLogger().Info("No action for OnResponse type: %T", resp)
+		return resp, nil
+	}
+}
+
+// decryptGetBatch decrypts each hit in a GetBatch response. The keys are not exposed on the response,
+// so they are recovered from the original request.
+func (rh *EncryptionMiddlewareRequestHandler) decryptGetBatch(r responses.GetBatchSuccess) (interface{}, error) {
+	keys := rh.batchKeys
+	if keys == nil {
+		request, ok := rh.GetRequest().(*momento.GetBatchRequest)
+		if !ok {
+			return r, nil
+		}
+		for _, key := range request.Keys {
+			keyBytes, err := getValueBytes(key)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, keyBytes)
+		}
+	}
+
+	results := make([]responses.GetResponse, 0, len(r.Results()))
+	for _, result := range r.Results() {
+		switch hit := result.(type) {
+		case *responses.GetHit:
+			decrypted, err := rh.decrypt(hit.ValueByte())
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, responses.NewGetHit(decrypted))
+		default:
+			results = append(results, result)
+		}
+	}
+	return *responses.NewGetBatchSuccess(results, keys), nil
+}
+
+// hashKeys returns a copy of req with the item keys replaced by their HMAC, if a key hashing secret is
+// configured. The request is copied so that other middlewares still see the keys the caller used.
+func (rh *EncryptionMiddlewareRequestHandler) hashKeys(req interface{}) (interface{}, error) {
+	if rh.keyHashingSecret == nil {
+		return req, nil
+	}
+	var err error
+	switch r := req.(type) {
+	case *momento.GetBatchRequest:
+		hashed := *r
+		for _, key := range r.Keys {
+			keyBytes, keyErr := getValueBytes(key)
+			if keyErr != nil {
+				return nil, keyErr
+			}
+			rh.batchKeys = append(rh.batchKeys, keyBytes)
+		}
+		hashed.Keys, err = rh.hashValues(r.Keys)
+		return &hashed, err
+	case *momento.KeysExistRequest:
+		hashed := *r
+		hashed.Keys, err = rh.hashValues(r.Keys)
+		return &hashed, err
+	case *momento.SetBatchRequest:
+		hashed := *r
+		hashed.Items = make([]momento.BatchSetItem, len(r.Items))
+		for i, item := range r.Items {
+			hashed.Items[i].Value = item.Value
+			if hashed.Items[i].Key, err = rh.hashValue(item.Key); err != nil {
+				return nil, err
+			}
+		}
+		return &hashed, nil
+	case *momento.IncrementRequest:
+		hashed := *r
+		hashed.Field, err = rh.hashValue(r.Field)
+		return &hashed, err
+	}
+
+	// The remaining scalar requests identify their item with a field named Key.
+	original := reflect.ValueOf(req)
+	if original.Kind() != reflect.Ptr || original.Elem().Kind() != reflect.Struct {
+		return req, nil
+	}
+	keyField := original.Elem().FieldByName("Key")
+	if !keyField.IsValid() || keyField.Type() != reflect.TypeOf((*momento.Key)(nil)).Elem() {
+		return req, nil
+	}
+	hashedKey, err := rh.hashValue(keyField.Interface().(momento.Key))
+	if err != nil {
+		return nil, err
+	}
+	hashed := reflect.New(original.Elem().Type())
+	hashed.Elem().Set(original.Elem())
+	hashed.Elem().FieldByName("Key").Set(reflect.ValueOf(hashedKey))
+	return hashed.Interface(), nil
+}
+
+func (rh *EncryptionMiddlewareRequestHandler) hashValue(value momento.Value) (momento.Value, error) {
+	data, err := getValueBytes(value)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, rh.keyHashingSecret)
+	mac.Write(data)
+	return momento.Bytes(mac.Sum(nil)), nil
+}
+
+func (rh *EncryptionMiddlewareRequestHandler) hashValues(values []momento.Value) ([]momento.Value, error) {
+	hashed := make([]momento.Value, 0, len(values))
+	for _, value := range values {
+		hashedValue, err := rh.hashValue(value)
+		if err != nil {
+			return nil, err
+		}
+		hashed = append(hashed, hashedValue)
+	}
+	return hashed, nil
+}
+
+func (rh *EncryptionMiddlewareRequestHandler) encryptValue(value *momento.Value, deterministic bool) error {
+	data, err := getValueBytes(*value)
+	if err != nil {
+		return err
+	}
+	var encrypted []byte
+	if deterministic {
+		encrypted, err = rh.encryptor.EncryptDeterministic(data, 0)
+	} else {
+		encrypted, err = rh.encryptor.Encrypt(data, 0)
+	}
+	if err != nil {
+		return err
+	}
+	*value = momento.Bytes(encrypted)
+	return nil
+}
+
+// encryptValues encrypts list or set elements, which are always encrypted deterministically.
+func (rh *EncryptionMiddlewareRequestHandler) encryptValues(values []momento.Value) ([]momento.Value, error) {
+	encrypted := make([]momento.Value, len(values))
+	copy(encrypted, values)
+	for i := range encrypted {
+		if err := rh.encryptValue(&encrypted[i], true); err != nil {
+			return nil, err
+		}
+	}
+	return encrypted, nil
+}
+
+func (rh *EncryptionMiddlewareRequestHandler) decrypt(data []byte) ([]byte, error) {
+	if !encryption.IsEncrypted(data) && rh.allowUnencryptedValues {
+		rh.GetLogger().Trace("Data is not encrypted, passing through")
+		return data, nil
+	}
+	decrypted, _, err := rh.encryptor.Decrypt(data)
+	return decrypted, err
+}
+
+func (rh *EncryptionMiddlewareRequestHandler) decryptList(data [][]byte) ([][]byte, error) {
+	decryptedList := make([][]byte, 0, len(data))
+	for _, value := range data {
+		decrypted, err := rh.decrypt(value)
+		if err != nil {
+			return nil, err
+		}
+		decryptedList = append(decryptedList, decrypted)
+	}
+	return decryptedList, nil
+}
+
+func (rh *EncryptionMiddlewareRequestHandler) encryptionError(req interface{}, err error) error {
+	rh.GetLogger().Error("Failed to encrypt %T: %v", req, err)
+	return momento.NewMomentoError(momento.ClientSdkError, fmt.Sprintf("failed to encrypt %T", req), err)
+}
+
+func (rh *EncryptionMiddlewareRequestHandler) decryptionError(resp interface{}, err error) error {
+	rh.GetLogger().Error("Failed to decrypt %T: %v", resp, err)
+	if isIntegrityError(err) {
+		return momento.NewMomentoError(momento.EncryptionIntegrityError, fmt.Sprintf("failed to decrypt %T", resp), err)
+	}
+	return momento.NewMomentoError(momento.ClientSdkError, fmt.Sprintf("failed to decrypt %T", resp), err)
+}
+
+func isIntegrityError(err error) bool {
+	return errors.Is(err, encryption.ErrIntegrityCheckFailed) || errors.Is(err, encryption.ErrKeyNotFound)
+}
@@ -0,0 +1,260 @@
+package impl_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"strings"
+
+	"github.com/momentohq/client-sdk-go/config/encryption"
+	"github.com/momentohq/client-sdk-go/config/middleware"
+	"github.com/momentohq/client-sdk-go/config/middleware/impl"
+	"github.com/momentohq/client-sdk-go/momento"
+	"github.com/momentohq/client-sdk-go/responses"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func newDataKey(id string) encryption.DataKey {
+	material := make([]byte, 32)
+	_, err := rand.Read(material)
+	Expect(err).To(BeNil())
+	return encryption.DataKey{Id: id, Material: material}
+}
+
+// fixedKeyProvider always encrypts with the same key, without validating it as InMemoryKeyProvider does.
+type fixedKeyProvider struct {
+	key encryption.DataKey
+}
+
+func (p fixedKeyProvider) CurrentKey() (encryption.DataKey, error) {
+	return p.key, nil
+}
+
+func (p fixedKeyProvider) GetKey(string) (encryption.DataKey, error) {
+	return p.key, nil
+}
+
+// handleRequest runs a request through the middleware and returns its request handler.
+func handleRequest(mw middleware.Middleware, req interface{}) middleware.RequestHandler {
+	baseHandler, err := mw.GetBaseRequestHandler(req, "request", "cache")
+	Expect(err).To(BeNil())
+	handler, err := mw.GetRequestHandler(baseHandler)
+	Expect(err).To(BeNil())
+	return handler
+}
+
+var _ = Describe("encryption", func() {
+	var keys *encryption.InMemoryKeyProvider
+
+	BeforeEach(func() {
+		var err error
+		keys, err = encryption.NewInMemoryKeyProvider(newDataKey("first"))
+		Expect(err).To(BeNil())
+	})
+
+	It("rejects invalid keys", func() {
+		_, err := encryption.NewInMemoryKeyProvider(encryption.DataKey{Id: "short", Material: []byte("too short")})
+		Expect(err).ToNot(BeNil())
+		_, err = encryption.NewInMemoryKeyProvider(encryption.DataKey{Material: make([]byte, 32)})
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("rejects key ids too long for the envelope", func() {
+		key := newDataKey(strings.Repeat("k", 256))
+		_, err := encryption.NewEncryptor(fixedKeyProvider{key: key}).Encrypt([]byte("value"), 0)
+		Expect(err).ToNot(BeNil())
+
+		key.Id = strings.Repeat("k", 255)
+		encryptor := encryption.NewEncryptor(fixedKeyProvider{key: key})
+		encrypted, err := encryptor.Encrypt([]byte("value"), 0)
+		Expect(err).To(BeNil())
+		decrypted, _, err := encryptor.Decrypt(encrypted)
+		Expect(err).To(BeNil())
+		Expect(decrypted).To(Equal([]byte("value")))
+	})
+
+	It("round trips values and their flags", func() {
+		encryptor := encryption.NewEncryptor(keys)
+		encrypted, err := encryptor.Encrypt([]byte("secret"), encryption.EnvelopeFlagText)
+		Expect(err).To(BeNil())
+		Expect(encryption.IsEncrypted(encrypted)).To(BeTrue())
+		Expect(bytes.Contains(encrypted, []byte("secret"))).To(BeFalse())
+
+		decrypted, flags, err := encryptor.Decrypt(encrypted)
+		Expect(err).To(BeNil())
+		Expect(decrypted).To(Equal([]byte("secret")))
+		Expect(flags).To(Equal(encryption.EnvelopeFlagText))
+	})
+
+	It("encrypts deterministically only when asked", func() {
+		encryptor := encryption.NewEncryptor(keys)
+		first, err := encryptor.Encrypt([]byte("value"), 0)
+		Expect(err).To(BeNil())
+		second, err := encryptor.Encrypt([]byte("value"), 0)
+		Expect(err).To(BeNil())
+		Expect(first).ToNot(Equal(second))
+
+		first, err = encryptor.EncryptDeterministic([]byte("value"), 0)
+		Expect(err).To(BeNil())
+		second, err = encryptor.EncryptDeterministic([]byte("value"), 0)
+		Expect(err).To(BeNil())
+		Expect(first).To(Equal(second))
+	})
+
+	It("decrypts values written with previous keys after rotation", func() {
+		encryptor := encryption.NewEncryptor(keys)
+		encrypted, err := encryptor.Encrypt([]byte("value"), 0)
+		Expect(err).To(BeNil())
+
+		Expect(keys.Rotate(newDataKey("second"))).To(Succeed())
+		rotated, err := encryptor.Encrypt([]byte("value"), 0)
+		Expect(err).To(BeNil())
+		Expect(rotated).To(ContainSubstring("second"))
+
+		decrypted, _, err := encryptor.Decrypt(encrypted)
+		Expect(err).To(BeNil())
+		Expect(decrypted).To(Equal([]byte("value")))
+
+		Expect(keys.RemoveKey("second")).ToNot(Succeed())
+		Expect(keys.RemoveKey("first")).To(Succeed())
+		_, _, err = encryptor.Decrypt(encrypted)
+		Expect(err).To(MatchError(encryption.ErrKeyNotFound))
+	})
+
+	It("detects tampering", func() {
+		encryptor := encryption.NewEncryptor(keys)
+		encrypted, err := encryptor.Encrypt([]byte("value"), 0)
+		Expect(err).To(BeNil())
+
+		tampered := append([]byte{}, encrypted...)
+		tampered[len(tampered)-1] ^= 0x01
+		_, _, err = encryptor.Decrypt(tampered)
+		Expect(err).To(MatchError(encryption.ErrIntegrityCheckFailed))
+
+		// The flags are authenticated too.
+		tampered = append([]byte{}, encrypted...)
+		tampered[4] ^= byte(encryption.EnvelopeFlagText)
+		_, _, err = encryptor.Decrypt(tampered)
+		Expect(err).To(MatchError(encryption.ErrIntegrityCheckFailed))
+	})
+
+	Describe("middleware", func() {
+		var mw middleware.Middleware
+
+		BeforeEach(func() {
+			var err error
+			mw, err = impl.NewEncryptionMiddleware(impl.EncryptionMiddlewareProps{KeyProvider: keys})
+			Expect(err).To(BeNil())
+		})
+
+		It("requires a key provider", func() {
+			_, err := impl.NewEncryptionMiddleware(impl.EncryptionMiddlewareProps{})
+			Expect(err.(momento.MomentoError).Code()).To(Equal(momento.InvalidArgumentError))
+		})
+
+		It("encrypts set requests and decrypts get responses", func() {
+			setRequest := &momento.SetRequest{CacheName: "cache", Key: momento.String("key"), Value: momento.String("value")}
+			newRequest, err := handleRequest(mw, setRequest).OnRequest(setRequest)
+			Expect(err).To(BeNil())
+			stored := []byte(newRequest.(*momento.SetRequest).Value.(momento.Bytes))
+			Expect(encryption.IsEncrypted(stored)).To(BeTrue())
+
+			getRequest := &momento.GetRequest{CacheName: "cache", Key: momento.String("key")}
+			resp, err := handleRequest(mw, getRequest).OnResponse(responses.NewGetHit(stored))
+			Expect(err).To(BeNil())
+			Expect(resp.(*responses.GetHit).ValueString()).To(Equal("value"))
+		})
+
+		It("reports tampered values with a distinct error code", func() {
+			encrypted, err := encryption.NewEncryptor(keys).Encrypt([]byte("value"), 0)
+			Expect(err).To(BeNil())
+			encrypted[len(encrypted)-1] ^= 0x01
+
+			getRequest := &momento.GetRequest{CacheName: "cache", Key: momento.String("key")}
+			_, err = handleRequest(mw, getRequest).OnResponse(responses.NewGetHit(encrypted))
+			Expect(err).ToNot(BeNil())
+			Expect(err.(momento.MomentoError).Code()).To(Equal(momento.EncryptionIntegrityError))
+		})
+
+		It("rejects unencrypted values unless allowed", func() {
+			getRequest := &momento.GetRequest{CacheName: "cache", Key: momento.String("key")}
+			_, err := handleRequest(mw, getRequest).OnResponse(responses.NewGetHit([]byte("plain")))
+			Expect(err.(momento.MomentoError).Code()).To(Equal(momento.EncryptionIntegrityError))
+
+			lenient, err := impl.NewEncryptionMiddleware(impl.EncryptionMiddlewareProps{KeyProvider: keys, AllowUnencryptedValues: true})
+			Expect(err).To(BeNil())
+			resp, err := handleRequest(lenient, getRequest).OnResponse(responses.NewGetHit([]byte("plain")))
+			Expect(err).To(BeNil())
+			Expect(resp.(*responses.GetHit).ValueString()).To(Equal("plain"))
+		})
+
+		It("rejects conditional sets that compare values", func() {
+			for _, request := range []interface{}{
+				&momento.SetIfEqualRequest{CacheName: "cache", Key: momento.String("key"), Value: momento.String("new"), Equal: momento.String("old")},
+				&momento.SetIfNotEqualRequest{CacheName: "cache", Key: momento.String("key"), Value: momento.String("new"), NotEqual: momento.String("old")},
+				&momento.SetIfAbsentOrEqualRequest{CacheName: "cache", Key: momento.String("key"), Value: momento.String("new"), Equal: momento.String("old")},
+				&momento.SetIfPresentAndNotEqualRequest{CacheName: "cache", Key: momento.String("key"), Value: momento.String("new"), NotEqual: momento.String("old")},
+			} {
+				_, err := handleRequest(mw, request).OnRequest(request)
+				Expect(err).ToNot(BeNil())
+				Expect(err.(momento.MomentoError).Code()).To(Equal(momento.InvalidArgumentError))
+			}
+
+			request := &momento.SetIfPresentAndHashEqualRequest{CacheName: "cache", Key: momento.String("key"), Value: momento.String("new")}
+			_, err := handleRequest(mw, request).OnRequest(request)
+			Expect(err).To(BeNil())
+		})
+
+		It("encrypts set elements deterministically", func() {
+			first := &momento.SetAddElementsRequest{CacheName: "cache", SetName: "set", Elements: []momento.Value{momento.String("a")}}
+			second := &momento.SetContainsElementsRequest{CacheName: "cache", SetName: "set", Elements: []momento.Value{momento.String("a")}}
+			_, err := handleRequest(mw, first).OnRequest(first)
+			Expect(err).To(BeNil())
+			_, err = handleRequest(mw, second).OnRequest(second)
+			Expect(err).To(BeNil())
+			Expect(first.Elements[0]).To(Equal(second.Elements[0]))
+		})
+
+		It("hashes keys without changing the caller's request", func() {
+			hashing, err := impl.NewEncryptionMiddleware(impl.EncryptionMiddlewareProps{
+				KeyProvider:      keys,
+				KeyHashingSecret: []byte("secret"),
+			})
+			Expect(err).To(BeNil())
+			getRequest := &momento.GetRequest{CacheName: "cache", Key: momento.String("key")}
+			newRequest, err := handleRequest(hashing, getRequest).OnRequest(getRequest)
+			Expect(err).To(BeNil())
+			Expect(getRequest.Key).To(Equal(momento.String("key")))
+			hashedKey := newRequest.(*momento.GetRequest).Key
+			Expect(hashedKey).ToNot(Equal(momento.String("key")))
+
+			again, err := handleRequest(hashing, getRequest).OnRequest(getRequest)
+			Expect(err).To(BeNil())
+			Expect(again.(*momento.GetRequest).Key).To(Equal(hashedKey))
+		})
+	})
+
+	Describe("topic middleware", func() {
+		It("requires a key provider", func() {
+			_, err := impl.NewTopicEncryptionMiddleware(impl.TopicEncryptionMiddlewareProps{})
+			Expect(err.(momento.MomentoError).Code()).To(Equal(momento.InvalidArgumentError))
+		})
+
+		It("restores the message type and detects tampering", func() {
+			topicMiddleware, err := impl.NewTopicEncryptionMiddleware(impl.TopicEncryptionMiddlewareProps{KeyProvider: keys})
+			Expect(err).To(BeNil())
+			mw := topicMiddleware.(middleware.TopicPayloadMiddleware)
+			published, err := mw.OnPublishPayload("cache", "topic", middleware.TopicPayload{Data: []byte("hello"), IsText: true})
+			Expect(err).To(BeNil())
+			Expect(published.IsText).To(BeFalse())
+
+			received, err := mw.OnSubscriptionPayload("cache", "topic", published)
+			Expect(err).To(BeNil())
+			Expect(received).To(Equal(middleware.TopicPayload{Data: []byte("hello"), IsText: true}))
+
+			published.Data[len(published.Data)-1] ^= 0x01
+			_, err = mw.OnSubscriptionPayload("cache", "topic", published)
+			Expect(err.(momento.MomentoError).Code()).To(Equal(momento.EncryptionIntegrityError))
+		})
+	})
+})
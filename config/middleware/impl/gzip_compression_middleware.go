@@ -3,8 +3,6 @@ package impl
 import (
 	"bytes"
 	"compress/gzip"
-	"encoding/binary"
-	"io"
 
 	"github.com/momentohq/client-sdk-go/config/compression"
 	"github.com/momentohq/client-sdk-go/config/logger"
@@ -28,14 +26,18 @@ func (f GzipCompressorFactory) NewCompressionStrategy(props compression.Compress
 
 	return gzipCompressor{
 		compressionLevel: compressionLevel,
+		minimumSizeBytes: props.MinimumSizeBytes,
 		logger:           props.Logger,
+		decompressor:     autoDecompressor{logger: props.Logger},
 	}
 }
 
 // gzipCompressor implements the CompressionStrategy interface.
 type gzipCompressor struct {
 	compressionLevel int
+	minimumSizeBytes int
 	logger           logger.MomentoLogger
+	decompressor     autoDecompressor
 }
 
 // The byte sequence that begins a gzip compressed data frame.
@@ -43,6 +45,10 @@ type gzipCompressor struct {
 const MAGIC_NUMBER = 0x1f8b
 
 func (c gzipCompressor) Compress(data []byte) ([]byte, error) {
+	if belowMinimumSize(data, c.minimumSizeBytes, c.logger) {
+		return data, nil
+	}
+
 	var buf bytes.Buffer
 	gzWriter, err := gzip.NewWriterLevel(&buf, c.compressionLevel)
 	if err != nil {
@@ -66,35 +72,10 @@ func (c gzipCompressor) Compress(data []byte) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// Decompress decompresses gzip data. Data compressed by the other built-in compression strategies is
+// detected by its magic bytes and decompressed as well, and any other data is passed through unchanged.
 func (c gzipCompressor) Decompress(data []byte) ([]byte, error) {
-	reader, err := gzip.NewReader(bytes.NewReader(data))
-	if err != nil {
-		c.logger.Error("Failed to create gzip reader: %v. Data was not compressed, passing through", err)
-		return data, nil
-	}
-	defer reader.Close()
-
-	if isGzipCompressed(data) {
-		c.logger.Trace("Decompressing gzip compressed data")
-		decompressed, err := io.ReadAll(reader)
-		if err != nil {
-			c.logger.Error("Failed to read decompressed data: %v", err)
-			return nil, err
-		}
-		c.logger.Trace("Decompressed response: %d bytes -> %d bytes", len(data), len(decompressed))
-		return decompressed, nil
-	}
-	c.logger.Trace("Data is not gzip compressed, passing through")
-	return data, nil
-}
-
-func isGzipCompressed(data []byte) bool {
-	if len(data) < 2 {
-		return false
-	}
-	// Extract the first 2 bytes in big endian order to compare
-	// to the magic number.
-	return binary.BigEndian.Uint16(data[:2]) == MAGIC_NUMBER
+	return c.decompressor.Decompress(data)
 }
 
 type GzipCompressionMiddlewareProps struct {
@@ -0,0 +1,98 @@
+package impl
+
+import (
+	"bytes"
+
+	"github.com/momentohq/client-sdk-go/config/compression"
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/config/middleware"
+	"github.com/pierrec/lz4/v4"
+)
+
+// Lz4CompressorFactory implements the CompressionStrategyFactory interface.
+// Values are written in the LZ4 frame format.
+type Lz4CompressorFactory struct{}
+
+func (f Lz4CompressorFactory) NewCompressionStrategy(props compression.CompressionStrategyProps) compression.CompressionStrategy {
+	compressionLevel := lz4.Fast
+	if props.CompressionLevel == compression.CompressionLevelSmallestSize {
+		compressionLevel = lz4.Level9
+	}
+
+	if props.Logger == nil {
+		props.Logger = logger.NewNoopMomentoLoggerFactory().GetLogger("lz4-compression")
+	}
+
+	return lz4Compressor{
+		compressionLevel: compressionLevel,
+		minimumSizeBytes: props.MinimumSizeBytes,
+		logger:           props.Logger,
+		decompressor:     autoDecompressor{logger: props.Logger},
+	}
+}
+
+// lz4Compressor implements the CompressionStrategy interface.
+type lz4Compressor struct {
+	compressionLevel lz4.CompressionLevel
+	minimumSizeBytes int
+	logger           logger.MomentoLogger
+	decompressor     autoDecompressor
+}
+
+func (c lz4Compressor) Compress(data []byte) ([]byte, error) {
+	if belowMinimumSize(data, c.minimumSizeBytes, c.logger) {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	writer := lz4.NewWriter(&buf)
+	err := writer.Apply(lz4.CompressionLevelOption(c.compressionLevel))
+	if err != nil {
+		c.logger.Error("Failed to configure lz4 writer: %v", err)
+		return nil, err
+	}
+
+	_, err = writer.Write(data)
+	if err != nil {
+		c.logger.Error("Failed to write data to lz4 writer: %v", err)
+		return nil, err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		c.logger.Error("Failed to close lz4 writer: %v", err)
+		return nil, err
+	}
+
+	c.logger.Trace("Compressed request: %d bytes -> %d bytes", len(data), buf.Len())
+	return buf.Bytes(), nil
+}
+
+// Decompress decompresses LZ4 data. Data compressed by the other built-in compression strategies is
+// detected by its magic bytes and decompressed as well, and any other data is passed through unchanged.
+func (c lz4Compressor) Decompress(data []byte) ([]byte, error) {
+	return c.decompressor.Decompress(data)
+}
+
+type Lz4CompressionMiddlewareProps struct {
+	IncludeTypes             []interface{}
+	CompressionStrategyProps compression.CompressionStrategyProps
+}
+
+// NewLz4CompressionMiddleware creates a new compression middleware that uses LZ4.
+// Example usage:
+//
+//	compressionMiddleware := impl.NewLz4CompressionMiddleware(impl.Lz4CompressionMiddlewareProps{
+//		CompressionStrategyProps: compression.CompressionStrategyProps{
+//			CompressionLevel: compression.CompressionLevelFastest,
+//			MinimumSizeBytes: 128,
+//		},
+//	})
+func NewLz4CompressionMiddleware(props Lz4CompressionMiddlewareProps) middleware.Middleware {
+	compressionMiddlewareProps := CompressionMiddlewareProps{
+		CompressorFactory:        Lz4CompressorFactory{},
+		CompressionStrategyProps: props.CompressionStrategyProps,
+		IncludeTypes:             props.IncludeTypes,
+	}
+	return NewCompressionMiddleware(compressionMiddlewareProps)
+}
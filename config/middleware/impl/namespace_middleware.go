@@ -0,0 +1,286 @@
+package impl
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/config/middleware"
+	"github.com/momentohq/client-sdk-go/momento"
+	"github.com/momentohq/client-sdk-go/responses"
+)
+
+const defaultNamespaceSeparator = ":"
+
+// collectionNameFields are the request fields that name a collection. Sorted set requests use SetName.
+var collectionNameFields = []string{"DictionaryName", "ListName", "SetName"}
+
+type namespaceContextKey struct{}
+
+// WithNamespace returns a copy of ctx that carries a namespace. Requests made with the returned context
+// are namespaced by a NamespaceMiddleware or TopicNamespaceMiddleware with this value in place of the
+// middleware's static namespace.
+func WithNamespace(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, namespaceContextKey{}, namespace)
+}
+
+// NamespaceFromContext returns the namespace stored in ctx by WithNamespace, or an empty string.
+func NamespaceFromContext(ctx context.Context) string {
+	namespace, _ := ctx.Value(namespaceContextKey{}).(string)
+	return namespace
+}
+
+type NamespaceMiddlewareProps struct {
+	IncludeTypes []interface{}
+	Logger       logger.MomentoLogger
+	// Namespace is the namespace applied to requests whose context does not carry one.
+	Namespace string
+	// NamespaceFromContext returns the namespace for a request from the context passed to the client
+	// method. It takes precedence over Namespace when it returns a non-empty value. Defaults to reading the
+	// value stored by WithNamespace.
+	NamespaceFromContext func(ctx context.Context) string
+	// Separator is placed between the namespace and the name it prefixes. Defaults to ":".
+	Separator string
+	// Enforce rejects requests for which no namespace is available with an InvalidArgumentError, rather
+	// than sending them unprefixed.
+	Enforce bool
+}
+
+// namespaceResolver holds the configuration shared by the cache and topic namespace middlewares.
+type namespaceResolver struct {
+	namespace   string
+	fromContext func(ctx context.Context) string
+	separator   string
+	enforce     bool
+}
+
+func newNamespaceResolver(props NamespaceMiddlewareProps) namespaceResolver {
+	if props.NamespaceFromContext == nil {
+		props.NamespaceFromContext = NamespaceFromContext
+	}
+	if props.Separator == "" {
+		props.Separator = defaultNamespaceSeparator
+	}
+	return namespaceResolver{
+		namespace:   props.Namespace,
+		fromContext: props.NamespaceFromContext,
+		separator:   props.Separator,
+		enforce:     props.Enforce,
+	}
+}
+
+// prefix returns the prefix for a request made with ctx, or an empty string if the request should not be
+// namespaced.
+func (r namespaceResolver) prefix(ctx context.Context) (string, error) {
+	namespace := r.fromContext(ctx)
+	if namespace == "" {
+		namespace = r.namespace
+	}
+	if namespace == "" {
+		if r.enforce {
+			return "", momento.NewMomentoError(momento.InvalidArgumentError, "request has no namespace", nil)
+		}
+		return "", nil
+	}
+	return namespace + r.separator, nil
+}
+
+// NamespaceMiddleware transparently prefixes the keys and collection names of cache requests with a
+// namespace, so that several tenants can share a cache without seeing each other's data. The namespace is
+// either static or taken from the context of each request, see WithNamespace. Keys returned by GetBatch
+// are returned without the prefix.
+//
+// The prefix is applied to item keys, including those of GetBatch, SetBatch, KeysExist and Increment
+// requests, and to dictionary, list, set and sorted set names. Cache names are never prefixed.
+type NamespaceMiddleware struct {
+	middleware.Middleware
+	resolver namespaceResolver
+}
+
+// NewNamespaceMiddleware creates a new namespace middleware.
+// Example usage:
+//
+//	namespaceMiddleware := impl.NewNamespaceMiddleware(impl.NamespaceMiddlewareProps{
+//		Enforce: true,
+//	})
+//	resp, err := client.Get(impl.WithNamespace(ctx, "tenant:123"), &momento.GetRequest{...})
+func NewNamespaceMiddleware(props NamespaceMiddlewareProps) middleware.Middleware {
+	mw := middleware.NewMiddleware(middleware.Props{
+		Logger:       props.Logger,
+		IncludeTypes: props.IncludeTypes,
+	})
+	return &NamespaceMiddleware{
+		Middleware: mw,
+		resolver:   newNamespaceResolver(props),
+	}
+}
+
+func (mw *NamespaceMiddleware) GetRequestHandler(
+	baseHandler middleware.RequestHandler,
+) (middleware.RequestHandler, error) {
+	return mw.GetRequestHandlerWithContext(context.Background(), baseHandler)
+}
+
+func (mw *NamespaceMiddleware) GetRequestHandlerWithContext(
+	ctx context.Context, baseHandler middleware.RequestHandler,
+) (middleware.RequestHandler, error) {
+	prefix, err := mw.resolver.prefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &NamespaceMiddlewareRequestHandler{
+		RequestHandler: baseHandler,
+		prefix:         prefix,
+	}, nil
+}
+
+type NamespaceMiddlewareRequestHandler struct {
+	middleware.RequestHandler
+	prefix string
+}
+
+// OnRequest returns a copy of the request with its keys and collection names prefixed. The request is
+// copied so that the caller's request, and any middleware that ran before this one, still see the names
+// the caller used.
+func (rh *NamespaceMiddlewareRequestHandler) OnRequest(req interface{}) (interface{}, error) {
+	if rh.prefix == "" {
+		return req, nil
+	}
+
+	switch r := req.(type) {
+	case *momento.GetBatchRequest:
+		namespaced := *r
+		namespaced.Keys = rh.prefixValues(r.Keys)
+		return &namespaced, nil
+	case *momento.KeysExistRequest:
+		namespaced := *r
+		namespaced.Keys = rh.prefixValues(r.Keys)
+		return &namespaced, nil
+	case *momento.SetBatchRequest:
+		namespaced := *r
+		namespaced.Items = make([]momento.BatchSetItem, len(r.Items))
+		for i, item := range r.Items {
+			namespaced.Items[i] = momento.BatchSetItem{Key: rh.prefixValue(item.Key), Value: item.Value}
+		}
+		return &namespaced, nil
+	case *momento.IncrementRequest:
+		namespaced := *r
+		namespaced.Field = rh.prefixValue(r.Field)
+		return &namespaced, nil
+	}
+
+	original := reflect.ValueOf(req)
+	if original.Kind() != reflect.Ptr || original.Elem().Kind() != reflect.Struct {
+		rh.GetLogger().Info("No action for OnRequest type: %T", req)
+		return req, nil
+	}
+	namespaced := reflect.New(original.Elem().Type())
+	namespaced.Elem().Set(original.Elem())
+
+	changed := false
+	keyField := namespaced.Elem().FieldByName("Key")
+	if keyField.IsValid() && keyField.Type() == reflect.TypeOf((*momento.Key)(nil)).Elem() && !keyField.IsNil() {
+		keyField.Set(reflect.ValueOf(rh.prefixValue(keyField.Interface().(momento.Key))))
+		changed = true
+	}
+	for _, name := range collectionNameFields {
+		nameField := namespaced.Elem().FieldByName(name)
+		// Empty names are left for the request validation to reject.
+		if nameField.IsValid() && nameField.Kind() == reflect.String && nameField.Len() > 0 {
+			nameField.SetString(rh.prefix + nameField.String())
+			changed = true
+		}
+	}
+	if !changed {
+		rh.GetLogger().Info("No action for OnRequest type: %T", req)
+		return req, nil
+	}
+	return namespaced.Interface(), nil
+}
+
+// OnResponse strips the prefix from the keys of a GetBatch response.
+func (rh *NamespaceMiddlewareRequestHandler) OnResponse(resp interface{}) (interface{}, error) {
+	switch r := resp.(type) {
+	case responses.GetBatchSuccess:
+		return rh.stripGetBatch(r), nil
+	case *responses.GetBatchSuccess:
+		return rh.stripGetBatch(*r), nil
+	default:
+		return resp, nil
+	}
+}
+
+// stripGetBatch rebuilds a GetBatch response with the keys of the request this handler was created for,
+// which are the keys before they were prefixed.
+func (rh *NamespaceMiddlewareRequestHandler) stripGetBatch(r responses.GetBatchSuccess) interface{} {
+	request, ok := rh.GetRequest().(*momento.GetBatchRequest)
+	if !ok || rh.prefix == "" {
+		return r
+	}
+	keys := make([][]byte, 0, len(request.Keys))
+	for _, key := range request.Keys {
+		keyBytes, err := getValueBytes(key)
+		if err != nil {
+			return r
+		}
+		keys = append(keys, keyBytes)
+	}
+	return *responses.NewGetBatchSuccess(r.Results(), keys)
+}
+
+func (rh *NamespaceMiddlewareRequestHandler) prefixValue(value momento.Value) momento.Value {
+	// Empty and unsupported values are left for the request validation to reject.
+	switch v := value.(type) {
+	case momento.String:
+		if len(v) == 0 {
+			return value
+		}
+		return momento.String(rh.prefix + string(v))
+	case momento.Bytes:
+		if len(v) == 0 {
+			return value
+		}
+		return momento.Bytes(append([]byte(rh.prefix), v...))
+	default:
+		return value
+	}
+}
+
+func (rh *NamespaceMiddlewareRequestHandler) prefixValues(values []momento.Value) []momento.Value {
+	prefixed := make([]momento.Value, 0, len(values))
+	for _, value := range values {
+		prefixed = append(prefixed, rh.prefixValue(value))
+	}
+	return prefixed
+}
+
+// TopicNamespaceMiddleware prefixes topic names with a namespace, in the same way as NamespaceMiddleware
+// prefixes cache keys.
+type TopicNamespaceMiddleware struct {
+	middleware.TopicMiddleware
+	resolver namespaceResolver
+}
+
+// NewTopicNamespaceMiddleware creates a new topic namespace middleware. IncludeTypes is ignored.
+// Example usage:
+//
+//	topicsConfig := config.TopicsDefault().AddMiddleware(impl.NewTopicNamespaceMiddleware(impl.NamespaceMiddlewareProps{
+//		Namespace: "tenant:123",
+//	}))
+func NewTopicNamespaceMiddleware(props NamespaceMiddlewareProps) middleware.TopicMiddleware {
+	mw := middleware.NewTopicMiddleware(middleware.Props{
+		Logger: props.Logger,
+	})
+	return &TopicNamespaceMiddleware{
+		TopicMiddleware: mw,
+		resolver:        newNamespaceResolver(props),
+	}
+}
+
+func (mw *TopicNamespaceMiddleware) OnTopicName(ctx context.Context, _ string, topicName string) (string, error) {
+	prefix, err := mw.resolver.prefix(ctx)
+	if err != nil {
+		return "", err
+	}
+	return prefix + topicName, nil
+}
@@ -0,0 +1,90 @@
+package impl_test
+
+import (
+	"context"
+
+	"github.com/momentohq/client-sdk-go/config/middleware"
+	"github.com/momentohq/client-sdk-go/config/middleware/impl"
+	"github.com/momentohq/client-sdk-go/momento"
+	"github.com/momentohq/client-sdk-go/responses"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// namespaceRequest runs a request through a namespace middleware with the given context.
+func namespaceRequest(mw middleware.Middleware, ctx context.Context, req interface{}) (middleware.RequestHandler, interface{}, error) {
+	baseHandler, err := mw.GetBaseRequestHandler(req, "request", "cache")
+	Expect(err).To(BeNil())
+	handler, err := mw.(middleware.ContextMiddleware).GetRequestHandlerWithContext(ctx, baseHandler)
+	if err != nil {
+		return nil, nil, err
+	}
+	newRequest, err := handler.OnRequest(req)
+	return handler, newRequest, err
+}
+
+var _ = Describe("namespace", func() {
+	It("prefixes keys and collection names with a static namespace", func() {
+		mw := impl.NewNamespaceMiddleware(impl.NamespaceMiddlewareProps{Namespace: "tenant"})
+
+		getRequest := &momento.GetRequest{CacheName: "cache", Key: momento.String("key")}
+		_, newRequest, err := namespaceRequest(mw, context.Background(), getRequest)
+		Expect(err).To(BeNil())
+		Expect(newRequest.(*momento.GetRequest).Key).To(Equal(momento.String("tenant:key")))
+		Expect(getRequest.Key).To(Equal(momento.String("key")))
+
+		dictionaryRequest := &momento.DictionaryFetchRequest{CacheName: "cache", DictionaryName: "dictionary"}
+		_, newRequest, err = namespaceRequest(mw, context.Background(), dictionaryRequest)
+		Expect(err).To(BeNil())
+		Expect(newRequest.(*momento.DictionaryFetchRequest).DictionaryName).To(Equal("tenant:dictionary"))
+
+		setBatchRequest := &momento.SetBatchRequest{CacheName: "cache", Items: []momento.BatchSetItem{
+			{Key: momento.Bytes("key"), Value: momento.String("value")},
+		}}
+		_, newRequest, err = namespaceRequest(mw, context.Background(), setBatchRequest)
+		Expect(err).To(BeNil())
+		Expect(newRequest.(*momento.SetBatchRequest).Items[0].Key).To(Equal(momento.Bytes("tenant:key")))
+	})
+
+	It("prefers the namespace from the context", func() {
+		mw := impl.NewNamespaceMiddleware(impl.NamespaceMiddlewareProps{Namespace: "default", Separator: "/"})
+		request := &momento.ListFetchRequest{CacheName: "cache", ListName: "list"}
+		_, newRequest, err := namespaceRequest(mw, impl.WithNamespace(context.Background(), "tenant-123"), request)
+		Expect(err).To(BeNil())
+		Expect(newRequest.(*momento.ListFetchRequest).ListName).To(Equal("tenant-123/list"))
+	})
+
+	It("rejects requests without a namespace when enforced", func() {
+		mw := impl.NewNamespaceMiddleware(impl.NamespaceMiddlewareProps{Enforce: true})
+		request := &momento.GetRequest{CacheName: "cache", Key: momento.String("key")}
+		_, _, err := namespaceRequest(mw, context.Background(), request)
+		Expect(err.(momento.MomentoError).Code()).To(Equal(momento.InvalidArgumentError))
+
+		lenient := impl.NewNamespaceMiddleware(impl.NamespaceMiddlewareProps{})
+		_, newRequest, err := namespaceRequest(lenient, context.Background(), request)
+		Expect(err).To(BeNil())
+		Expect(newRequest).To(Equal(request))
+	})
+
+	It("strips the prefix from GetBatch keys", func() {
+		mw := impl.NewNamespaceMiddleware(impl.NamespaceMiddlewareProps{Namespace: "tenant"})
+		request := &momento.GetBatchRequest{CacheName: "cache", Keys: []momento.Value{momento.String("a"), momento.String("b")}}
+		handler, newRequest, err := namespaceRequest(mw, context.Background(), request)
+		Expect(err).To(BeNil())
+		Expect(newRequest.(*momento.GetBatchRequest).Keys).To(Equal([]momento.Value{momento.String("tenant:a"), momento.String("tenant:b")}))
+
+		resp, err := handler.OnResponse(*responses.NewGetBatchSuccess(
+			[]responses.GetResponse{responses.NewGetHit([]byte("1")), &responses.GetMiss{}},
+			[][]byte{[]byte("tenant:a"), []byte("tenant:b")},
+		))
+		Expect(err).To(BeNil())
+		Expect(resp.(responses.GetBatchSuccess).ValueMap()).To(Equal(map[string]string{"a": "1"}))
+	})
+
+	It("prefixes topic names", func() {
+		mw := impl.NewTopicNamespaceMiddleware(impl.NamespaceMiddlewareProps{Namespace: "tenant"}).(middleware.TopicNameMiddleware)
+		topicName, err := mw.OnTopicName(context.Background(), "cache", "topic")
+		Expect(err).To(BeNil())
+		Expect(topicName).To(Equal("tenant:topic"))
+	})
+})
@@ -0,0 +1,92 @@
+package impl
+
+import (
+	"bytes"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/momentohq/client-sdk-go/config/compression"
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/config/middleware"
+)
+
+// SnappyCompressorFactory implements the CompressionStrategyFactory interface.
+// Values are written in the snappy framing format, which starts with a stream identifier that
+// allows compressed values to be recognized when they are read back.
+type SnappyCompressorFactory struct{}
+
+func (f SnappyCompressorFactory) NewCompressionStrategy(props compression.CompressionStrategyProps) compression.CompressionStrategy {
+	writerOptions := []s2.WriterOption{s2.WriterSnappyCompat(), s2.WriterConcurrency(1)}
+	if props.CompressionLevel == compression.CompressionLevelSmallestSize {
+		writerOptions = append(writerOptions, s2.WriterBetterCompression())
+	}
+
+	if props.Logger == nil {
+		props.Logger = logger.NewNoopMomentoLoggerFactory().GetLogger("snappy-compression")
+	}
+
+	return snappyCompressor{
+		writerOptions:    writerOptions,
+		minimumSizeBytes: props.MinimumSizeBytes,
+		logger:           props.Logger,
+		decompressor:     autoDecompressor{logger: props.Logger},
+	}
+}
+
+// snappyCompressor implements the CompressionStrategy interface.
+type snappyCompressor struct {
+	writerOptions    []s2.WriterOption
+	minimumSizeBytes int
+	logger           logger.MomentoLogger
+	decompressor     autoDecompressor
+}
+
+func (c snappyCompressor) Compress(data []byte) ([]byte, error) {
+	if belowMinimumSize(data, c.minimumSizeBytes, c.logger) {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	writer := s2.NewWriter(&buf, c.writerOptions...)
+	_, err := writer.Write(data)
+	if err != nil {
+		c.logger.Error("Failed to write data to snappy writer: %v", err)
+		return nil, err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		c.logger.Error("Failed to close snappy writer: %v", err)
+		return nil, err
+	}
+
+	c.logger.Trace("Compressed request: %d bytes -> %d bytes", len(data), buf.Len())
+	return buf.Bytes(), nil
+}
+
+// Decompress decompresses snappy data. Data compressed by the other built-in compression strategies is
+// detected by its magic bytes and decompressed as well, and any other data is passed through unchanged.
+func (c snappyCompressor) Decompress(data []byte) ([]byte, error) {
+	return c.decompressor.Decompress(data)
+}
+
+type SnappyCompressionMiddlewareProps struct {
+	IncludeTypes             []interface{}
+	CompressionStrategyProps compression.CompressionStrategyProps
+}
+
+// NewSnappyCompressionMiddleware creates a new compression middleware that uses snappy.
+// Example usage:
+//
+//	compressionMiddleware := impl.NewSnappyCompressionMiddleware(impl.SnappyCompressionMiddlewareProps{
+//		CompressionStrategyProps: compression.CompressionStrategyProps{
+//			MinimumSizeBytes: 128,
+//		},
+//	})
+func NewSnappyCompressionMiddleware(props SnappyCompressionMiddlewareProps) middleware.Middleware {
+	compressionMiddlewareProps := CompressionMiddlewareProps{
+		CompressorFactory:        SnappyCompressorFactory{},
+		CompressionStrategyProps: props.CompressionStrategyProps,
+		IncludeTypes:             props.IncludeTypes,
+	}
+	return NewCompressionMiddleware(compressionMiddlewareProps)
+}
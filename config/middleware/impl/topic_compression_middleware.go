@@ -0,0 +1,89 @@
+package impl
+
+import (
+	"fmt"
+
+	"github.com/momentohq/client-sdk-go/config/compression"
+	"github.com/momentohq/client-sdk-go/config/middleware"
+)
+
+// TopicCompressionMiddleware is a topic middleware that compresses published messages and decompresses
+// messages received on subscriptions. Compressed messages are published as bytes wrapped in a
+// self-describing frame that records whether the original message was a string, so subscribers using
+// this middleware receive the same message type that was published. Messages without a frame header are
+// passed through unchanged, so subscribers can still read messages from publishers without compression.
+// Subscribers that do not use this middleware will receive the framed, compressed bytes.
+type TopicCompressionMiddleware struct {
+	middleware.TopicMiddleware
+	compressor compression.CompressionStrategy
+}
+
+type TopicCompressionMiddlewareProps struct {
+	CompressionStrategyProps compression.CompressionStrategyProps
+	CompressorFactory        compression.CompressionStrategyFactory
+}
+
+func NewTopicCompressionMiddleware(props TopicCompressionMiddlewareProps) middleware.TopicMiddleware {
+	mw := middleware.NewTopicMiddleware(middleware.Props{
+		Logger: props.CompressionStrategyProps.Logger,
+	})
+	compressor := props.CompressorFactory.NewCompressionStrategy(props.CompressionStrategyProps)
+	return &TopicCompressionMiddleware{
+		TopicMiddleware: mw,
+		compressor:      compressor,
+	}
+}
+
+func (mw *TopicCompressionMiddleware) OnPublishPayload(
+	_ string, _ string, payload middleware.TopicPayload,
+) (middleware.TopicPayload, error) {
+	compressed, err := mw.compressor.Compress(payload.Data)
+	if err != nil {
+		return middleware.TopicPayload{}, fmt.Errorf("failed to compress topic message: %v", err)
+	}
+	var flags compression.FrameFlags
+	if payload.IsText {
+		flags |= compression.FrameFlagText
+	}
+	return middleware.TopicPayload{Data: compression.EncodeFrame(compressed, flags)}, nil
+}
+
+func (mw *TopicCompressionMiddleware) OnSubscriptionPayload(
+	_ string, _ string, payload middleware.TopicPayload,
+) (middleware.TopicPayload, error) {
+	if payload.IsText {
+		return payload, nil
+	}
+	compressed, flags, ok := compression.DecodeFrame(payload.Data)
+	if !ok {
+		mw.GetLogger().Trace("Topic message is not framed, passing through")
+		return payload, nil
+	}
+	decompressed, err := mw.compressor.Decompress(compressed)
+	if err != nil {
+		return middleware.TopicPayload{}, fmt.Errorf("failed to decompress topic message: %v", err)
+	}
+	return middleware.TopicPayload{
+		Data:   decompressed,
+		IsText: flags&compression.FrameFlagText != 0,
+	}, nil
+}
+
+type GzipTopicCompressionMiddlewareProps struct {
+	CompressionStrategyProps compression.CompressionStrategyProps
+}
+
+// NewGzipTopicCompressionMiddleware creates a new topic compression middleware that uses gzip.
+// Example usage:
+//
+//	topicsConfig := config.TopicsDefault().AddMiddleware(impl.NewGzipTopicCompressionMiddleware(impl.GzipTopicCompressionMiddlewareProps{
+//		CompressionStrategyProps: compression.CompressionStrategyProps{
+//			CompressionLevel: compression.CompressionLevelFastest,
+//		},
+//	}))
+func NewGzipTopicCompressionMiddleware(props GzipTopicCompressionMiddlewareProps) middleware.TopicMiddleware {
+	return NewTopicCompressionMiddleware(TopicCompressionMiddlewareProps{
+		CompressorFactory:        GzipCompressorFactory{},
+		CompressionStrategyProps: props.CompressionStrategyProps,
+	})
+}
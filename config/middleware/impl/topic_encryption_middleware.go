@@ -0,0 +1,94 @@
+package impl
+
+import (
+	"fmt"
+
+	"github.com/momentohq/client-sdk-go/config/encryption"
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/config/middleware"
+	"github.com/momentohq/client-sdk-go/momento"
+)
+
+// TopicEncryptionMiddleware is a topic middleware that encrypts published messages and decrypts messages
+// received on subscriptions. Encrypted messages are published as bytes, and the envelope records whether
+// the original message was a string so subscribers using this middleware receive the same message type that
+// was published. A message that fails its integrity check is reported as an EncryptionIntegrityError.
+//
+// When combined with a TopicCompressionMiddleware, add the compression middleware first.
+type TopicEncryptionMiddleware struct {
+	middleware.TopicMiddleware
+	encryptor                *encryption.Encryptor
+	allowUnencryptedMessages bool
+}
+
+type TopicEncryptionMiddlewareProps struct {
+	Logger logger.MomentoLogger
+	// KeyProvider supplies the keys used to encrypt and decrypt messages. Required.
+	KeyProvider encryption.KeyProvider
+	// AllowUnencryptedMessages passes through messages from publishers that do not encrypt. By default
+	// such messages are rejected with an EncryptionIntegrityError.
+	AllowUnencryptedMessages bool
+}
+
+// NewTopicEncryptionMiddleware creates a new topic encryption middleware. It returns an
+// InvalidArgumentError if no KeyProvider is given.
+// Example usage:
+//
+//	encryptionMiddleware, err := impl.NewTopicEncryptionMiddleware(impl.TopicEncryptionMiddlewareProps{
+//		KeyProvider: keys,
+//	})
+//	if err != nil {
+//		panic(err)
+//	}
+//	topicsConfig := config.TopicsDefault().AddMiddleware(encryptionMiddleware)
+func NewTopicEncryptionMiddleware(props TopicEncryptionMiddlewareProps) (middleware.TopicMiddleware, error) {
+	if props.KeyProvider == nil {
+		return nil, momento.NewMomentoError(momento.InvalidArgumentError, "a key provider must be provided", nil)
+	}
+	if props.Logger == nil {
+		props.Logger = logger.NewNoopMomentoLoggerFactory().GetLogger("topic-encryption-middleware")
+	}
+	mw := middleware.NewTopicMiddleware(middleware.Props{
+		Logger: props.Logger,
+	})
+	return &TopicEncryptionMiddleware{
+		TopicMiddleware:          mw,
+		encryptor:                encryption.NewEncryptor(props.KeyProvider),
+		allowUnencryptedMessages: props.AllowUnencryptedMessages,
+	}, nil
+}
+
+func (mw *TopicEncryptionMiddleware) OnPublishPayload(
+	_ string, _ string, payload middleware.TopicPayload,
+) (middleware.TopicPayload, error) {
+	var flags encryption.EnvelopeFlags
+	if payload.IsText {
+		flags |= encryption.EnvelopeFlagText
+	}
+	encrypted, err := mw.encryptor.Encrypt(payload.Data, flags)
+	if err != nil {
+		return middleware.TopicPayload{}, fmt.Errorf("failed to encrypt topic message: %v", err)
+	}
+	return middleware.TopicPayload{Data: encrypted}, nil
+}
+
+func (mw *TopicEncryptionMiddleware) OnSubscriptionPayload(
+	_ string, _ string, payload middleware.TopicPayload,
+) (middleware.TopicPayload, error) {
+	if mw.allowUnencryptedMessages && (payload.IsText || !encryption.IsEncrypted(payload.Data)) {
+		mw.GetLogger().Trace("Topic message is not encrypted, passing through")
+		return payload, nil
+	}
+	decrypted, flags, err := mw.encryptor.Decrypt(payload.Data)
+	if err != nil {
+		mw.GetLogger().Error("Failed to decrypt topic message: %v", err)
+		if isIntegrityError(err) {
+			return middleware.TopicPayload{}, momento.NewMomentoError(momento.EncryptionIntegrityError, "failed to decrypt topic message", err)
+		}
+		return middleware.TopicPayload{}, fmt.Errorf("failed to decrypt topic message: %v", err)
+	}
+	return middleware.TopicPayload{
+		Data:   decrypted,
+		IsText: flags&encryption.EnvelopeFlagText != 0,
+	}, nil
+}
@@ -0,0 +1,164 @@
+package impl
+
+import (
+	"fmt"
+
+	"github.com/klauspost/compress/dict"
+	"github.com/klauspost/compress/zstd"
+	"github.com/momentohq/client-sdk-go/config/compression"
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/config/middleware"
+)
+
+// ZstdCompressorFactory implements the CompressionStrategyFactory interface.
+type ZstdCompressorFactory struct {
+	// Dictionary is an optional zstd dictionary, as produced by `zstd --train`, used to compress and
+	// decompress values. Dictionaries greatly improve the compression ratio of small values, such as
+	// JSON documents, that share a common structure.
+	Dictionary []byte
+	// DecoderDictionaries are additional dictionaries that can be used to decompress values, but are
+	// never used to compress them. zstd records the ID of the dictionary used in each frame, so this
+	// can be used to keep reading values written with a previous dictionary while rolling out a new one.
+	DecoderDictionaries [][]byte
+}
+
+// Validate checks that the dictionaries can be loaded by zstd. NewCompressionStrategy logs an error for
+// each dictionary that cannot and does without it, so call Validate to reject them up front instead.
+func (f ZstdCompressorFactory) Validate() error {
+	if f.Dictionary != nil {
+		if err := validateZstdDictionary(f.Dictionary); err != nil {
+			return fmt.Errorf("invalid zstd dictionary: %w", err)
+		}
+	}
+	for i, dictionary := range f.DecoderDictionaries {
+		if err := validateZstdDictionary(dictionary); err != nil {
+			return fmt.Errorf("invalid zstd decoder dictionary %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateZstdDictionary(dictionary []byte) error {
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1), zstd.WithDecoderDicts(dictionary))
+	if err != nil {
+		return err
+	}
+	decoder.Close()
+	return nil
+}
+
+func (f ZstdCompressorFactory) NewCompressionStrategy(props compression.CompressionStrategyProps) compression.CompressionStrategy {
+	encoderLevel := zstd.SpeedDefault
+	if props.CompressionLevel == compression.CompressionLevelFastest {
+		encoderLevel = zstd.SpeedFastest
+	} else if props.CompressionLevel == compression.CompressionLevelSmallestSize {
+		encoderLevel = zstd.SpeedBestCompression
+	}
+
+	if props.Logger == nil {
+		props.Logger = logger.NewNoopMomentoLoggerFactory().GetLogger("zstd-compression")
+	}
+
+	// Malformed dictionaries are left out rather than failing the client, so values are compressed without
+	// a dictionary and values written with a skipped one fail to decompress.
+	encoderOptions := []zstd.EOption{zstd.WithEncoderLevel(encoderLevel)}
+	var decoderDictionaries [][]byte
+	if f.Dictionary != nil {
+		if err := validateZstdDictionary(f.Dictionary); err != nil {
+			props.Logger.Error("Ignoring invalid zstd dictionary: %v", err)
+		} else {
+			encoderOptions = append(encoderOptions, zstd.WithEncoderDict(f.Dictionary))
+			decoderDictionaries = append(decoderDictionaries, f.Dictionary)
+		}
+	}
+	for i, dictionary := range f.DecoderDictionaries {
+		if err := validateZstdDictionary(dictionary); err != nil {
+			props.Logger.Error("Ignoring invalid zstd decoder dictionary %d: %v", i, err)
+			continue
+		}
+		decoderDictionaries = append(decoderDictionaries, dictionary)
+	}
+
+	// Neither can fail once the dictionaries are known to load.
+	encoder, _ := zstd.NewWriter(nil, encoderOptions...)
+	var decoder *zstd.Decoder
+	if len(decoderDictionaries) > 0 {
+		decoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecoderDicts(decoderDictionaries...))
+	}
+
+	return zstdCompressor{
+		encoder:          encoder,
+		minimumSizeBytes: props.MinimumSizeBytes,
+		logger:           props.Logger,
+		decompressor:     autoDecompressor{zstdDecoder: decoder, logger: props.Logger},
+	}
+}
+
+// zstdCompressor implements the CompressionStrategy interface.
+type zstdCompressor struct {
+	encoder          *zstd.Encoder
+	minimumSizeBytes int
+	logger           logger.MomentoLogger
+	decompressor     autoDecompressor
+}
+
+func (c zstdCompressor) Compress(data []byte) ([]byte, error) {
+	if belowMinimumSize(data, c.minimumSizeBytes, c.logger) {
+		return data, nil
+	}
+	compressed := c.encoder.EncodeAll(data, nil)
+	c.logger.Trace("Compressed request: %d bytes -> %d bytes", len(data), len(compressed))
+	return compressed, nil
+}
+
+// Decompress decompresses zstd data. Data compressed by the other built-in compression strategies is
+// detected by its magic bytes and decompressed as well, and any other data is passed through unchanged.
+func (c zstdCompressor) Decompress(data []byte) ([]byte, error) {
+	return c.decompressor.Decompress(data)
+}
+
+// TrainZstdDictionary builds a zstd dictionary of at most maxSizeBytes from a set of sample values, for use
+// as ZstdCompressorFactory.Dictionary. The samples should be representative of the values being cached; a
+// few hundred samples and a dictionary size of a few kilobytes works well for small JSON documents.
+// Dictionaries can also be trained with the zstd command line tool, using `zstd --train`.
+func TrainZstdDictionary(samples [][]byte, maxSizeBytes int) ([]byte, error) {
+	return dict.BuildZstdDict(samples, dict.Options{
+		MaxDictSize:    maxSizeBytes,
+		HashBytes:      6,
+		ZstdDictCompat: true,
+	})
+}
+
+type ZstdCompressionMiddlewareProps struct {
+	IncludeTypes             []interface{}
+	CompressionStrategyProps compression.CompressionStrategyProps
+	// Dictionary is an optional zstd dictionary used to compress and decompress values.
+	// See ZstdCompressorFactory.
+	Dictionary []byte
+	// DecoderDictionaries are optional zstd dictionaries used only to decompress values.
+	// See ZstdCompressorFactory.
+	DecoderDictionaries [][]byte
+}
+
+// NewZstdCompressionMiddleware creates a new compression middleware that uses zstd. Invalid dictionaries
+// are logged and ignored; use ZstdCompressorFactory.Validate to check them beforehand.
+// Example usage:
+//
+//	compressionMiddleware := impl.NewZstdCompressionMiddleware(impl.ZstdCompressionMiddlewareProps{
+//		CompressionStrategyProps: compression.CompressionStrategyProps{
+//			CompressionLevel: compression.CompressionLevelDefault,
+//			MinimumSizeBytes: 128,
+//		},
+//		Dictionary: trainedDictionary,
+//	})
+func NewZstdCompressionMiddleware(props ZstdCompressionMiddlewareProps) middleware.Middleware {
+	compressionMiddlewareProps := CompressionMiddlewareProps{
+		CompressorFactory: ZstdCompressorFactory{
+			Dictionary:          props.Dictionary,
+			DecoderDictionaries: props.DecoderDictionaries,
+		},
+		CompressionStrategyProps: props.CompressionStrategyProps,
+		IncludeTypes:             props.IncludeTypes,
+	}
+	return NewCompressionMiddleware(compressionMiddlewareProps)
+}
@@ -27,6 +27,14 @@ type InterceptorCallbackMiddleware interface {
 	OnInterceptorRequest(ctx context.Context, method string)
 }
 
+// ContextMiddleware is a Middleware whose request handlers need the context passed to the client method,
+// for example to read per-request values stored with context.WithValue. When a middleware implements this
+// interface, GetRequestHandlerWithContext is called in place of GetRequestHandler.
+type ContextMiddleware interface {
+	Middleware
+	GetRequestHandlerWithContext(ctx context.Context, baseRequestHandler RequestHandler) (RequestHandler, error)
+}
+
 type Props struct {
 	Logger       logger.MomentoLogger
 	IncludeTypes []interface{}
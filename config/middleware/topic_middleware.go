@@ -1,6 +1,10 @@
 package middleware
 
-import "github.com/momentohq/client-sdk-go/config/logger"
+import (
+	"context"
+
+	"github.com/momentohq/client-sdk-go/config/logger"
+)
 
 type topicMiddleware struct {
 	logger logger.MomentoLogger
@@ -49,3 +53,30 @@ type TopicEventCallbackMiddleware interface {
 	TopicMiddleware
 	OnTopicEvent(cacheName string, method string, event TopicSubscriptionEventType)
 }
+
+// TopicPayload is the content of a topic message as it is sent to or received from the server.
+type TopicPayload struct {
+	// Data is the message content.
+	Data []byte
+	// IsText is true if the message is sent as a utf-8 string rather than as raw bytes.
+	IsText bool
+}
+
+// TopicPayloadMiddleware is a TopicMiddleware that can transform topic messages. OnPublishPayload is
+// called with the message before it is published, and OnSubscriptionPayload is called with each message
+// received on a subscription before it is returned to the caller. Payload middlewares are applied in
+// order on publish and in reverse order on subscribe. Returning an error halts the publish, or surfaces
+// the error to the caller of TopicSubscription.Item or TopicSubscription.Event.
+type TopicPayloadMiddleware interface {
+	TopicMiddleware
+	OnPublishPayload(cacheName string, topicName string, payload TopicPayload) (TopicPayload, error)
+	OnSubscriptionPayload(cacheName string, topicName string, payload TopicPayload) (TopicPayload, error)
+}
+
+// TopicNameMiddleware is a TopicMiddleware that can change the name of the topic a message is published to
+// or a subscription is made on. OnTopicName is called with the context passed to Publish or Subscribe, and
+// name middlewares are applied in order. Returning an error halts the publish or subscribe.
+type TopicNameMiddleware interface {
+	TopicMiddleware
+	OnTopicName(ctx context.Context, cacheName string, topicName string) (string, error)
+}
@@ -72,6 +72,12 @@ var retryableRequestMethods = map[string]bool{
 	"/cache_client.pubsub.Pubsub/Subscribe": true,
 }
 
+// IsIdempotentMethod reports whether the grpc method is idempotent, meaning that sending it more than once
+// has the same effect as sending it once. Only idempotent methods are retried or hedged.
+func IsIdempotentMethod(grpcMethod string) bool {
+	return retryableRequestMethods[grpcMethod]
+}
+
 // DefaultEligibilityStrategy is the default strategy for determining if a request is eligible for retry.
 type DefaultEligibilityStrategy struct{}
 
@@ -112,16 +112,17 @@ func (r *exponentialBackoffRetryStrategy) WithGrowthFactor(growthFactor int) Str
 }
 
 func (r *exponentialBackoffRetryStrategy) DetermineWhenToRetry(props StrategyProps) *int {
+	log := attemptLogger(r.log, props)
 	// attempt is 0-based, so we subtract 1 to get the correct attempt number
 	attempt := props.AttemptNumber - 1
-	r.log.Debug(
+	log.Debug(
 		"Determining whether request is eligible for retry; status code: %s, "+
 			"request type: %s, attemptNumber: %d",
 		props.GrpcStatusCode, props.GrpcMethod, attempt,
 	)
 
 	if !r.eligibilityStrategy.IsEligibleForRetry(props) {
-		r.log.Debug("Request is not eligible for retry.")
+		log.Debug("Request is not eligible for retry.")
 		return nil
 	}
 
@@ -129,7 +130,7 @@ func (r *exponentialBackoffRetryStrategy) DetermineWhenToRetry(props StrategyPro
 	previousBaseDelay := r.computePreviousBaseDelay(baseDelay)
 	maxDelay := previousBaseDelay * 3
 	jitteredDelay := randInRange(baseDelay, maxDelay)
-	r.log.Debug("attempt #%d, base delay: %d, previous base delay: %d, max delay: %d, jittered delay: %d",
+	log.Debug("attempt #%d, base delay: %d, previous base delay: %d, max delay: %d, jittered delay: %d",
 		attempt, baseDelay, previousBaseDelay, maxDelay, jitteredDelay)
 	return &jitteredDelay
 }
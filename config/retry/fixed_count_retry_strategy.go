@@ -65,15 +65,16 @@ func (r *fixedCountRetryStrategy) WithEligibilityStrategy(s EligibilityStrategy)
 }
 
 func (r *fixedCountRetryStrategy) DetermineWhenToRetry(props StrategyProps) *int {
+	log := attemptLogger(r.log, props)
 	if !r.eligibilityStrategy.IsEligibleForRetry(props) {
-		r.log.Debug(
+		log.Debug(
 			"Request is not retryable: [method: %s, status: %s]", props.GrpcMethod, props.GrpcStatusCode.String(),
 		)
 		return nil
 	}
 
 	if props.AttemptNumber > r.maxAttempts {
-		r.log.Debug(
+		log.Debug(
 			"Exceeded max retry attempts; not retrying: [method: %s, status: %s, attempt_count: %s, max_attempts: %s]",
 			props.GrpcMethod,
 			props.GrpcStatusCode.String(),
@@ -83,7 +84,7 @@ func (r *fixedCountRetryStrategy) DetermineWhenToRetry(props StrategyProps) *int
 		return nil
 	}
 
-	r.log.Debug(
+	log.Debug(
 		"Determined request is retryable; retrying now: [method: %s, status: %s, attempt_count: %s, max_attempts: %s]",
 		props.GrpcMethod,
 		props.GrpcStatusCode.String(),
@@ -109,7 +109,8 @@ func (r *fixedTimeoutRetryStrategy) CalculateRetryDeadline(overallDeadline time.
 }
 
 func (r *fixedTimeoutRetryStrategy) DetermineWhenToRetry(props StrategyProps) *int {
-	r.log.Debug(
+	log := attemptLogger(r.log, props)
+	log.Debug(
 		"Determining whether request is eligible for retry; status code: %s, "+
 			"request type: %s, attemptNumber: %d",
 		props.GrpcStatusCode, props.GrpcMethod, props.AttemptNumber,
@@ -119,7 +120,7 @@ func (r *fixedTimeoutRetryStrategy) DetermineWhenToRetry(props StrategyProps) *i
 	// we should reset the deadline and retry.
 	if props.AttemptNumber > 0 && props.GrpcStatusCode == codes.DeadlineExceeded && props.OverallDeadline.After(time.Now()) {
 		timeoutWithJitter := addJitter(r.retryDelayIntervalMillis)
-		r.log.Debug(
+		log.Debug(
 			"Determined request is retryable; retrying after %d ms: [method: %s, status: %s, attempt: %d]",
 			timeoutWithJitter,
 			props.GrpcMethod,
@@ -130,7 +131,7 @@ func (r *fixedTimeoutRetryStrategy) DetermineWhenToRetry(props StrategyProps) *i
 	}
 
 	if !r.eligibilityStrategy.IsEligibleForRetry(props) {
-		r.log.Debug(
+		log.Debug(
 			"Request is not retryable: [method: %s, status: %s]", props.GrpcMethod, props.GrpcStatusCode.String(),
 		)
 		return nil
@@ -138,7 +139,7 @@ func (r *fixedTimeoutRetryStrategy) DetermineWhenToRetry(props StrategyProps) *i
 
 	timeoutWithJitter := addJitter(r.retryDelayIntervalMillis)
 
-	r.log.Debug(
+	log.Debug(
 		"Determined request is retryable; retrying after %d ms: [method: %s, status: %s, attempt: %d]",
 		timeoutWithJitter,
 		props.GrpcMethod,
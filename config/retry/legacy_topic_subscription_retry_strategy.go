@@ -29,7 +29,8 @@ func (r *legacyTopicSubscriptionRetryStrategy) WithRetryMs(ms int) Strategy {
 }
 
 func (r *legacyTopicSubscriptionRetryStrategy) DetermineWhenToRetry(props StrategyProps) *int {
-	r.log.Debug(
+	log := attemptLogger(r.log, props)
+	log.Debug(
 		"Always retry strategy returning %d ms for [method: %s, status: %s]",
 		*r.retryMs,
 		props.GrpcMethod,
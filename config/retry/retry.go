@@ -1,8 +1,10 @@
 package retry
 
 import (
+	"context"
 	"time"
 
+	"github.com/momentohq/client-sdk-go/config/logger"
 	"google.golang.org/grpc/codes"
 )
 
@@ -14,7 +16,43 @@ type StrategyProps struct {
 	// OverallDeadline is the overall deadline for the request. It is currently only used
 	// by the FixedTimeoutRetryStrategy to determine when to stop retrying.
 	OverallDeadline time.Time
+
+	// RetryAfter is the delay the server asked for before the request is retried, taken from the retry-after
+	// or retry-after-ms trailer of the failed attempt. It is zero if the server did not supply one. The retry
+	// interceptor never retries sooner than RetryAfter, whatever delay the strategy returns.
+	RetryAfter time.Duration
+
+	// OnRetry, if set, is called by the retry interceptor each time a retry is scheduled. It is taken from
+	// the context of the request, see WithOnRetry.
+	OnRetry func(event RetryEvent)
+}
+
+// RetryEvent describes a retry scheduled by the retry interceptor.
+type RetryEvent struct {
+	GrpcStatusCode codes.Code
+	GrpcMethod     string
+	// AttemptNumber is the number of the attempt that failed.
+	AttemptNumber int
+	// Delay is how long the interceptor waits before the next attempt.
+	Delay time.Duration
+	// RetryAfter is the delay the server asked for, or zero if it did not supply one.
+	RetryAfter time.Duration
+}
+
+type onRetryContextKey struct{}
+
+// WithOnRetry returns a copy of ctx that carries a retry observer. It is called each time a request made
+// with the returned context is retried.
+func WithOnRetry(ctx context.Context, onRetry func(event RetryEvent)) context.Context {
+	return context.WithValue(ctx, onRetryContextKey{}, onRetry)
+}
+
+// OnRetryFromContext returns the retry observer stored in ctx by WithOnRetry, or nil.
+func OnRetryFromContext(ctx context.Context) func(event RetryEvent) {
+	onRetry, _ := ctx.Value(onRetryContextKey{}).(func(event RetryEvent))
+	return onRetry
 }
+
 type Strategy interface {
 	// DetermineWhenToRetry Determines whether a grpc call can be retried and how long to wait before that retry.
 	//
@@ -40,3 +78,29 @@ type OverrideDeadlineRetryStrategy interface {
 	// Returns nil if there is no adjustment to the deadline.
 	CalculateNewOverallDeadline() time.Time
 }
+
+// SuccessAwareRetryStrategy is a strategy that tracks successful requests, such as one limited by a RetryBudget.
+type SuccessAwareRetryStrategy interface {
+	Strategy
+
+	// OnSuccess is called by the retry interceptor each time an attempt succeeds.
+	OnSuccess(grpcMethod string)
+}
+
+// DropAwareRetryStrategy is a strategy that tracks the retries it allowed but that were not made, such as one
+// that spends a RetryBudget on each retry.
+type DropAwareRetryStrategy interface {
+	Strategy
+
+	// OnRetryDropped is called by the retry interceptor when it does not make a retry that DetermineWhenToRetry
+	// allowed, because the delay the server asked for would pass the deadline or the request was cancelled.
+	OnRetryDropped(grpcMethod string)
+}
+
+// attemptLogger returns a logger that attaches the request name and attempt number of a retry decision.
+func attemptLogger(log logger.MomentoLogger, props StrategyProps) logger.MomentoLogger {
+	return logger.With(log,
+		logger.Field{Key: logger.RequestNameKey, Value: props.GrpcMethod},
+		logger.Field{Key: logger.AttemptKey, Value: props.AttemptNumber},
+	)
+}
@@ -0,0 +1,188 @@
+package retry
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config/logger"
+)
+
+const (
+	DefaultRetryBudgetRatio     = 0.1
+	DefaultRetryBudgetMaxTokens = 100
+)
+
+// RetryBudgetProps configures a RetryBudget. The zero value of each field selects its default.
+type RetryBudgetProps struct {
+	// Ratio is the number of retries earned by each successful request, which caps retries as a fraction of
+	// traffic. Defaults to 0.1, allowing one retry for every ten successful requests.
+	Ratio float64
+	// MaxTokens is the number of retries that can be saved up while requests are succeeding, and is also the
+	// initial balance. Defaults to 100.
+	MaxTokens float64
+}
+
+// RetryBudget is a token bucket that limits retries to a fraction of successful requests, so that a partial
+// outage does not multiply the load on the service by the number of attempts. Each retry spends a token and
+// each successful request earns Ratio of one. A budget may be shared by several strategies and clients to
+// cap their combined retries. It is safe for concurrent use.
+type RetryBudget struct {
+	props  RetryBudgetProps
+	mutex  sync.Mutex
+	tokens float64
+}
+
+// NewRetryBudget returns a full RetryBudget with the given props.
+func NewRetryBudget(props RetryBudgetProps) *RetryBudget {
+	if props.Ratio <= 0 {
+		props.Ratio = DefaultRetryBudgetRatio
+	}
+	if props.MaxTokens <= 0 {
+		props.MaxTokens = DefaultRetryBudgetMaxTokens
+	}
+	return &RetryBudget{props: props, tokens: props.MaxTokens}
+}
+
+// Deposit records a successful request.
+func (b *RetryBudget) Deposit() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.tokens += b.props.Ratio
+	if b.tokens > b.props.MaxTokens {
+		b.tokens = b.props.MaxTokens
+	}
+}
+
+// TryWithdraw spends a token for a retry, and returns false if the budget is exhausted.
+func (b *RetryBudget) TryWithdraw() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Refund returns a token spent on a retry that was not made.
+func (b *RetryBudget) Refund() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.tokens++
+	if b.tokens > b.props.MaxTokens {
+		b.tokens = b.props.MaxTokens
+	}
+}
+
+// Tokens returns the number of retries currently available.
+func (b *RetryBudget) Tokens() float64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.tokens
+}
+
+func (b *RetryBudget) String() string {
+	return fmt.Sprintf("RetryBudget{Ratio: %v, MaxTokens: %v}", b.props.Ratio, b.props.MaxTokens)
+}
+
+type RetryBudgetStrategyProps struct {
+	LoggerFactory logger.MomentoLoggerFactory
+	// Strategy decides whether and when each request is retried, subject to the budget.
+	Strategy Strategy
+	// Budget is spent by retries. Pass the same budget to several strategies to share it between them.
+	// Defaults to a new budget with default props.
+	Budget *RetryBudget
+}
+
+type retryBudgetStrategy struct {
+	strategy Strategy
+	budget   *RetryBudget
+	log      logger.MomentoLogger
+}
+
+// NewRetryBudgetStrategy returns a strategy that retries requests as its wrapped strategy does, for as long
+// as the retry budget allows. The returned strategy adjusts deadlines in the same way as the wrapped one.
+// Example usage:
+//
+//	budget := retry.NewRetryBudget(retry.RetryBudgetProps{Ratio: 0.2})
+//	strategy := retry.NewRetryBudgetStrategy(retry.RetryBudgetStrategyProps{
+//		Strategy: retry.NewFixedCountRetryStrategy(retry.FixedCountRetryStrategyProps{}),
+//		Budget:   budget,
+//	})
+//	myConfig := config.InRegionLatest().WithRetryStrategy(strategy)
+func NewRetryBudgetStrategy(props RetryBudgetStrategyProps) Strategy {
+	var log logger.MomentoLogger
+	if props.LoggerFactory != nil {
+		log = props.LoggerFactory.GetLogger("retry-budget-strategy")
+	} else {
+		log = logger.NewNoopMomentoLoggerFactory().GetLogger("retry-budget-strategy")
+	}
+	budget := props.Budget
+	if budget == nil {
+		budget = NewRetryBudget(RetryBudgetProps{})
+	}
+
+	strategy := &retryBudgetStrategy{
+		strategy: props.Strategy,
+		budget:   budget,
+		log:      log,
+	}
+	switch props.Strategy.(type) {
+	case OverrideDeadlineRetryStrategy:
+		return &overrideDeadlineRetryBudgetStrategy{strategy}
+	case DeadlineAwareRetryStrategy:
+		return &deadlineAwareRetryBudgetStrategy{strategy}
+	default:
+		return strategy
+	}
+}
+
+func (r *retryBudgetStrategy) DetermineWhenToRetry(props StrategyProps) *int {
+	retryBackoffTime := r.strategy.DetermineWhenToRetry(props)
+	if retryBackoffTime == nil {
+		return nil
+	}
+	if !r.budget.TryWithdraw() {
+		attemptLogger(r.log, props).Debug(
+			"Retry budget exhausted; not retrying: [method: %s, status: %s]",
+			props.GrpcMethod, props.GrpcStatusCode.String(),
+		)
+		return nil
+	}
+	return retryBackoffTime
+}
+
+func (r *retryBudgetStrategy) OnSuccess(grpcMethod string) {
+	r.budget.Deposit()
+	if successAware, ok := r.strategy.(SuccessAwareRetryStrategy); ok {
+		successAware.OnSuccess(grpcMethod)
+	}
+}
+
+func (r *retryBudgetStrategy) OnRetryDropped(grpcMethod string) {
+	r.budget.Refund()
+	if dropAware, ok := r.strategy.(DropAwareRetryStrategy); ok {
+		dropAware.OnRetryDropped(grpcMethod)
+	}
+}
+
+func (r *retryBudgetStrategy) String() string {
+	return fmt.Sprintf("RetryBudgetStrategy{strategy: %v, budget: %v}", r.strategy, r.budget)
+}
+
+type deadlineAwareRetryBudgetStrategy struct {
+	*retryBudgetStrategy
+}
+
+func (r *deadlineAwareRetryBudgetStrategy) CalculateRetryDeadline(overallDeadline time.Time) time.Time {
+	return r.strategy.(DeadlineAwareRetryStrategy).CalculateRetryDeadline(overallDeadline)
+}
+
+type overrideDeadlineRetryBudgetStrategy struct {
+	*retryBudgetStrategy
+}
+
+func (r *overrideDeadlineRetryBudgetStrategy) CalculateNewOverallDeadline() time.Time {
+	return r.strategy.(OverrideDeadlineRetryStrategy).CalculateNewOverallDeadline()
+}
@@ -0,0 +1,105 @@
+package retry_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config/retry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+)
+
+var retryableProps = retry.StrategyProps{
+	GrpcStatusCode: codes.Unavailable,
+	GrpcMethod:     "/cache_client.Scs/Get",
+	AttemptNumber:  1,
+}
+
+var _ = Describe("retry-budget", func() {
+	It("refills from successes up to its maximum", func() {
+		budget := retry.NewRetryBudget(retry.RetryBudgetProps{Ratio: 0.5, MaxTokens: 2})
+		Expect(budget.TryWithdraw()).To(BeTrue())
+		Expect(budget.TryWithdraw()).To(BeTrue())
+		Expect(budget.TryWithdraw()).To(BeFalse())
+
+		budget.Deposit()
+		Expect(budget.TryWithdraw()).To(BeFalse())
+		budget.Deposit()
+		Expect(budget.TryWithdraw()).To(BeTrue())
+
+		for i := 0; i < 10; i++ {
+			budget.Deposit()
+		}
+		Expect(budget.Tokens()).To(Equal(2.0))
+	})
+
+	It("stops retrying when the shared budget is exhausted", func() {
+		budget := retry.NewRetryBudget(retry.RetryBudgetProps{Ratio: 1, MaxTokens: 1})
+		first := retry.NewRetryBudgetStrategy(retry.RetryBudgetStrategyProps{
+			Strategy: retry.NewFixedCountRetryStrategy(retry.FixedCountRetryStrategyProps{}),
+			Budget:   budget,
+		})
+		second := retry.NewRetryBudgetStrategy(retry.RetryBudgetStrategyProps{
+			Strategy: retry.NewFixedCountRetryStrategy(retry.FixedCountRetryStrategyProps{}),
+			Budget:   budget,
+		})
+
+		Expect(first.DetermineWhenToRetry(retryableProps)).ToNot(BeNil())
+		Expect(second.DetermineWhenToRetry(retryableProps)).To(BeNil())
+
+		second.(retry.SuccessAwareRetryStrategy).OnSuccess(retryableProps.GrpcMethod)
+		Expect(first.DetermineWhenToRetry(retryableProps)).ToNot(BeNil())
+	})
+
+	It("does not spend the budget on requests that are not retried", func() {
+		budget := retry.NewRetryBudget(retry.RetryBudgetProps{MaxTokens: 1})
+		strategy := retry.NewRetryBudgetStrategy(retry.RetryBudgetStrategyProps{
+			Strategy: retry.NewNeverRetryStrategy(),
+			Budget:   budget,
+		})
+		Expect(strategy.DetermineWhenToRetry(retryableProps)).To(BeNil())
+		Expect(budget.Tokens()).To(Equal(1.0))
+	})
+
+	It("refunds retries that are not made", func() {
+		budget := retry.NewRetryBudget(retry.RetryBudgetProps{MaxTokens: 1})
+		strategy := retry.NewRetryBudgetStrategy(retry.RetryBudgetStrategyProps{
+			Strategy: retry.NewFixedCountRetryStrategy(retry.FixedCountRetryStrategyProps{}),
+			Budget:   budget,
+		})
+		Expect(strategy.DetermineWhenToRetry(retryableProps)).ToNot(BeNil())
+		Expect(budget.Tokens()).To(BeZero())
+
+		strategy.(retry.DropAwareRetryStrategy).OnRetryDropped(retryableProps.GrpcMethod)
+		Expect(budget.Tokens()).To(Equal(1.0))
+		budget.Refund()
+		Expect(budget.Tokens()).To(Equal(1.0))
+	})
+
+	It("keeps the deadline behavior of the wrapped strategy", func() {
+		strategy := retry.NewRetryBudgetStrategy(retry.RetryBudgetStrategyProps{
+			Strategy: retry.NewFixedTimeoutRetryStrategy(retry.FixedTimeoutRetryStrategyProps{}),
+		})
+		_, ok := strategy.(retry.DeadlineAwareRetryStrategy)
+		Expect(ok).To(BeTrue())
+
+		strategy = retry.NewRetryBudgetStrategy(retry.RetryBudgetStrategyProps{
+			Strategy: retry.NewFixedCountRetryStrategy(retry.FixedCountRetryStrategyProps{}),
+		})
+		_, ok = strategy.(retry.DeadlineAwareRetryStrategy)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("stores a retry observer in the context", func() {
+		var events []retry.RetryEvent
+		ctx := retry.WithOnRetry(context.Background(), func(event retry.RetryEvent) {
+			events = append(events, event)
+		})
+		Expect(retry.OnRetryFromContext(context.Background())).To(BeNil())
+		onRetry := retry.OnRetryFromContext(ctx)
+		Expect(onRetry).ToNot(BeNil())
+		onRetry(retry.RetryEvent{Delay: time.Millisecond})
+		Expect(events).To(HaveLen(1))
+	})
+})
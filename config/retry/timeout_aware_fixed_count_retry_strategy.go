@@ -97,16 +97,17 @@ func (r *timeoutAwareFixedCountRetryStrategy) WithEligibilityStrategy(s Eligibil
 }
 
 func (r *timeoutAwareFixedCountRetryStrategy) DetermineWhenToRetry(props StrategyProps) *int {
+	log := attemptLogger(r.log, props)
 	fmt.Printf("\noverall deadline in DetermineWhenToRetry: %v\n", props.OverallDeadline.Local())
 	if !r.eligibilityStrategy.IsEligibleForRetry(props) {
-		r.log.Debug(
+		log.Debug(
 			"Request is not retryable: [method: %s, status: %s]", props.GrpcMethod, props.GrpcStatusCode.String(),
 		)
 		return nil
 	}
 
 	if props.AttemptNumber > r.maxAttempts {
-		r.log.Debug(
+		log.Debug(
 			"Exceeded max retry attempts; not retrying: [method: %s, status: %s, attempt_count: %s, max_attempts: %s]",
 			props.GrpcMethod,
 			props.GrpcStatusCode.String(),
@@ -116,7 +117,7 @@ func (r *timeoutAwareFixedCountRetryStrategy) DetermineWhenToRetry(props Strateg
 		return nil
 	}
 
-	r.log.Debug(
+	log.Debug(
 		"\nDetermined request is retryable; retrying now: [method: %s, status: %s, attempt_count: %s, max_attempts: %s]",
 		props.GrpcMethod,
 		props.GrpcStatusCode.String(),
@@ -1,6 +1,13 @@
 package config
 
-import "time"
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"time"
+
+	"google.golang.org/grpc"
+)
 
 // The maximum number of concurrent streams that can be created on a single gRPC channel.
 const MAX_CONCURRENT_STREAMS_PER_CHANNEL int = 100
@@ -105,4 +112,62 @@ type TopicsGrpcConfiguration interface {
 	// for unary operations. Each GRPC connection can multiplex 100 concurrent publish requests.
 	// Defaults to 4.
 	WithNumUnaryGrpcChannels(numUnaryGrpcChannels uint32) TopicsGrpcConfiguration
+
+	// GetTlsConfig returns the TLS configuration used for secure endpoints, or nil to use the default.
+	GetTlsConfig() *tls.Config
+
+	// GetRootCAs returns the root certificate authorities trusted for secure endpoints, or nil to use
+	// the TLS configuration's, or else the host's.
+	GetRootCAs() *x509.CertPool
+
+	// GetClientCertificates returns the certificates presented to servers that request mutual TLS.
+	GetClientCertificates() []tls.Certificate
+
+	// GetProxyUrl returns the HTTP or HTTPS proxy connections are tunneled through with CONNECT, or nil to
+	// use the proxy from the HTTPS_PROXY environment variable, if any.
+	GetProxyUrl() *url.URL
+
+	// GetDialer returns the dialer that opens connections, or nil to use the default.
+	GetDialer() GrpcDialer
+
+	// GetDialOptions returns additional grpc.DialOptions applied after all others.
+	GetDialOptions() []grpc.DialOption
+
+	// GetTlsServerName returns the server name sent for SNI to the endpoint, a host and port, or "" to send
+	// the cache endpoint's host name.
+	GetTlsServerName(endpoint string) string
+
+	// WithTlsConfig Copy constructor for overriding the TLS configuration of secure endpoints. Its ServerName
+	// is ignored, as the endpoints may need different ones; use WithTlsServerName instead. Returns a new
+	// TopicsGrpcConfiguration with the specified TLS configuration.
+	WithTlsConfig(tlsConfig *tls.Config) TopicsGrpcConfiguration
+
+	// WithTlsServerName Copy constructor for overriding the server name sent for SNI to the endpoint with the
+	// given host name, e.g. when connecting through a private link endpoint. Endpoints without a server name
+	// of their own are sent the cache endpoint's host name. Returns a new TopicsGrpcConfiguration with the specified
+	// server name.
+	WithTlsServerName(host string, serverName string) TopicsGrpcConfiguration
+
+	// WithRootCAs Copy constructor for overriding the root certificate authorities trusted for secure
+	// endpoints, e.g. to trust a corporate proxy's certificate authority. Returns a new TopicsGrpcConfiguration
+	// with the specified root certificate authorities.
+	WithRootCAs(rootCAs *x509.CertPool) TopicsGrpcConfiguration
+
+	// WithClientCertificates Copy constructor for setting the certificates presented for mutual TLS.
+	// Returns a new TopicsGrpcConfiguration with the specified client certificates.
+	WithClientCertificates(certificates ...tls.Certificate) TopicsGrpcConfiguration
+
+	// WithProxyUrl Copy constructor for tunneling connections through an HTTP or HTTPS proxy with CONNECT.
+	// Credentials in the URL's user info are sent to the proxy with basic authentication. Returns a new
+	// TopicsGrpcConfiguration with the specified proxy.
+	WithProxyUrl(proxyUrl *url.URL) TopicsGrpcConfiguration
+
+	// WithDialer Copy constructor for overriding how connections are opened. When a proxy is also set, the
+	// dialer opens the connection to the proxy. Returns a new TopicsGrpcConfiguration with the specified dialer.
+	WithDialer(dialer GrpcDialer) TopicsGrpcConfiguration
+
+	// WithDialOptions Copy constructor for appending grpc.DialOptions, applied after those derived from the
+	// rest of the configuration so that they take precedence. This is an escape hatch for grpc settings that
+	// have no dedicated option; use it with care. Returns a new TopicsGrpcConfiguration with the specified options.
+	WithDialOptions(options ...grpc.DialOption) TopicsGrpcConfiguration
 }
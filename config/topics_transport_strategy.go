@@ -1,8 +1,13 @@
 package config
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net/url"
 	"time"
+
+	"google.golang.org/grpc"
 )
 
 type TopicsTransportStrategyProps struct {
@@ -47,6 +52,7 @@ type TopicsTransportStrategy interface {
 }
 
 type TopicsStaticGrpcConfiguration struct {
+	grpcDialSettings
 	client_timeout              time.Duration
 	keepAlivePermitWithoutCalls bool
 	keepAliveTimeout            time.Duration
@@ -128,6 +134,7 @@ func (s *TopicsStaticGrpcConfiguration) WithClientTimeout(client_timeout time.Du
 		maxReceiveMessageLength:     s.maxReceiveMessageLength,
 		numStreamGrpcChannels:       s.numStreamGrpcChannels,
 		numUnaryGrpcChannels:        s.numUnaryGrpcChannels,
+		grpcDialSettings:            s.grpcDialSettings,
 	}
 }
 
@@ -141,6 +148,7 @@ func (s *TopicsStaticGrpcConfiguration) WithKeepAlivePermitWithoutCalls(keepAliv
 		maxReceiveMessageLength:     s.maxReceiveMessageLength,
 		numStreamGrpcChannels:       s.numStreamGrpcChannels,
 		numUnaryGrpcChannels:        s.numUnaryGrpcChannels,
+		grpcDialSettings:            s.grpcDialSettings,
 	}
 }
 
@@ -154,6 +162,7 @@ func (s *TopicsStaticGrpcConfiguration) WithKeepAliveTimeout(keepAliveTimeout ti
 		maxReceiveMessageLength:     s.maxReceiveMessageLength,
 		numStreamGrpcChannels:       s.numStreamGrpcChannels,
 		numUnaryGrpcChannels:        s.numUnaryGrpcChannels,
+		grpcDialSettings:            s.grpcDialSettings,
 	}
 }
 
@@ -167,6 +176,7 @@ func (s *TopicsStaticGrpcConfiguration) WithKeepAliveTime(keepAliveTime time.Dur
 		maxReceiveMessageLength:     s.maxReceiveMessageLength,
 		numStreamGrpcChannels:       s.numStreamGrpcChannels,
 		numUnaryGrpcChannels:        s.numUnaryGrpcChannels,
+		grpcDialSettings:            s.grpcDialSettings,
 	}
 }
 
@@ -180,6 +190,7 @@ func (s *TopicsStaticGrpcConfiguration) WithKeepAliveDisabled() TopicsGrpcConfig
 		maxReceiveMessageLength:     s.maxReceiveMessageLength,
 		numStreamGrpcChannels:       s.numStreamGrpcChannels,
 		numUnaryGrpcChannels:        s.numUnaryGrpcChannels,
+		grpcDialSettings:            s.grpcDialSettings,
 	}
 }
 
@@ -193,6 +204,7 @@ func (s *TopicsStaticGrpcConfiguration) WithNumStreamGrpcChannels(numStreamGrpcC
 		maxReceiveMessageLength:     s.maxReceiveMessageLength,
 		numStreamGrpcChannels:       numStreamGrpcChannels,
 		numUnaryGrpcChannels:        s.numUnaryGrpcChannels,
+		grpcDialSettings:            s.grpcDialSettings,
 	}
 }
 
@@ -206,13 +218,56 @@ func (s *TopicsStaticGrpcConfiguration) WithNumUnaryGrpcChannels(numUnaryGrpcCha
 		maxReceiveMessageLength:     s.maxReceiveMessageLength,
 		numStreamGrpcChannels:       s.numStreamGrpcChannels,
 		numUnaryGrpcChannels:        numUnaryGrpcChannels,
+		grpcDialSettings:            s.grpcDialSettings,
 	}
 }
 
+func (s *TopicsStaticGrpcConfiguration) WithTlsConfig(tlsConfig *tls.Config) TopicsGrpcConfiguration {
+	c := *s
+	c.tlsConfig = tlsConfig
+	return &c
+}
+
+func (s *TopicsStaticGrpcConfiguration) WithTlsServerName(host string, serverName string) TopicsGrpcConfiguration {
+	c := *s
+	c.grpcDialSettings = s.grpcDialSettings.withTlsServerName(host, serverName)
+	return &c
+}
+
+func (s *TopicsStaticGrpcConfiguration) WithRootCAs(rootCAs *x509.CertPool) TopicsGrpcConfiguration {
+	c := *s
+	c.rootCAs = rootCAs
+	return &c
+}
+
+func (s *TopicsStaticGrpcConfiguration) WithClientCertificates(certificates ...tls.Certificate) TopicsGrpcConfiguration {
+	c := *s
+	c.clientCertificates = certificates
+	return &c
+}
+
+func (s *TopicsStaticGrpcConfiguration) WithProxyUrl(proxyUrl *url.URL) TopicsGrpcConfiguration {
+	c := *s
+	c.proxyUrl = proxyUrl
+	return &c
+}
+
+func (s *TopicsStaticGrpcConfiguration) WithDialer(dialer GrpcDialer) TopicsGrpcConfiguration {
+	c := *s
+	c.dialer = dialer
+	return &c
+}
+
+func (s *TopicsStaticGrpcConfiguration) WithDialOptions(options ...grpc.DialOption) TopicsGrpcConfiguration {
+	c := *s
+	c.dialOptions = append(append([]grpc.DialOption{}, s.dialOptions...), options...)
+	return &c
+}
+
 func (s *TopicsStaticGrpcConfiguration) String() string {
 	return fmt.Sprintf(
-		"TopicsGrpcConfiguration{client_timeout=%v, keepAlivePermitWithoutCalls=%v, keepAliveTimeout=%v, keepAliveTime=%v, maxSendMessageLength=%v, maxReceiveMessageLength=%v, numStreamGrpcChannels=%v, numUnaryGrpcChannels=%v}",
-		s.client_timeout, s.keepAlivePermitWithoutCalls, s.keepAliveTimeout, s.keepAliveTime, s.maxSendMessageLength, s.maxReceiveMessageLength, s.numStreamGrpcChannels, s.numUnaryGrpcChannels,
+		"TopicsGrpcConfiguration{client_timeout=%v, keepAlivePermitWithoutCalls=%v, keepAliveTimeout=%v, keepAliveTime=%v, maxSendMessageLength=%v, maxReceiveMessageLength=%v, numStreamGrpcChannels=%v, numUnaryGrpcChannels=%v, %s}",
+		s.client_timeout, s.keepAlivePermitWithoutCalls, s.keepAliveTimeout, s.keepAliveTime, s.maxSendMessageLength, s.maxReceiveMessageLength, s.numStreamGrpcChannels, s.numUnaryGrpcChannels, s.grpcDialSettings.String(),
 	)
 }
 
@@ -1,8 +1,13 @@
 package config
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net/url"
 	"time"
+
+	"google.golang.org/grpc"
 )
 
 type TransportStrategyProps struct {
@@ -37,6 +42,7 @@ const DEFAULT_KEEPALIVE_TIME = 5000 * time.Millisecond
 const DEFAULT_KEEPALIVE_TIMEOUT = 1000 * time.Millisecond
 
 type StaticGrpcConfiguration struct {
+	grpcDialSettings
 	deadline                    time.Duration
 	keepAlivePermitWithoutCalls bool
 	keepAliveTimeout            time.Duration
@@ -104,6 +110,7 @@ func (s *StaticGrpcConfiguration) WithDeadline(deadline time.Duration) GrpcConfi
 		keepAliveTime:               s.keepAliveTime,
 		maxSendMessageLength:        s.maxSendMessageLength,
 		maxReceiveMessageLength:     s.maxReceiveMessageLength,
+		grpcDialSettings:            s.grpcDialSettings,
 	}
 }
 
@@ -115,6 +122,7 @@ func (s *StaticGrpcConfiguration) WithKeepAlivePermitWithoutCalls(keepAlivePermi
 		keepAliveTime:               s.keepAliveTime,
 		maxSendMessageLength:        s.maxSendMessageLength,
 		maxReceiveMessageLength:     s.maxReceiveMessageLength,
+		grpcDialSettings:            s.grpcDialSettings,
 	}
 }
 
@@ -126,6 +134,7 @@ func (s *StaticGrpcConfiguration) WithKeepAliveTimeout(keepAliveTimeout time.Dur
 		keepAliveTime:               s.keepAliveTime,
 		maxSendMessageLength:        s.maxSendMessageLength,
 		maxReceiveMessageLength:     s.maxReceiveMessageLength,
+		grpcDialSettings:            s.grpcDialSettings,
 	}
 }
 
@@ -137,6 +146,7 @@ func (s *StaticGrpcConfiguration) WithKeepAliveTime(keepAliveTime time.Duration)
 		keepAliveTime:               keepAliveTime,
 		maxSendMessageLength:        s.maxSendMessageLength,
 		maxReceiveMessageLength:     s.maxReceiveMessageLength,
+		grpcDialSettings:            s.grpcDialSettings,
 	}
 }
 
@@ -148,12 +158,55 @@ func (s *StaticGrpcConfiguration) WithKeepAliveDisabled() GrpcConfiguration {
 		keepAliveTime:               0,
 		maxSendMessageLength:        s.maxSendMessageLength,
 		maxReceiveMessageLength:     s.maxReceiveMessageLength,
+		grpcDialSettings:            s.grpcDialSettings,
 	}
 }
 
+func (s *StaticGrpcConfiguration) WithTlsConfig(tlsConfig *tls.Config) GrpcConfiguration {
+	c := *s
+	c.tlsConfig = tlsConfig
+	return &c
+}
+
+func (s *StaticGrpcConfiguration) WithTlsServerName(host string, serverName string) GrpcConfiguration {
+	c := *s
+	c.grpcDialSettings = s.grpcDialSettings.withTlsServerName(host, serverName)
+	return &c
+}
+
+func (s *StaticGrpcConfiguration) WithRootCAs(rootCAs *x509.CertPool) GrpcConfiguration {
+	c := *s
+	c.rootCAs = rootCAs
+	return &c
+}
+
+func (s *StaticGrpcConfiguration) WithClientCertificates(certificates ...tls.Certificate) GrpcConfiguration {
+	c := *s
+	c.clientCertificates = certificates
+	return &c
+}
+
+func (s *StaticGrpcConfiguration) WithProxyUrl(proxyUrl *url.URL) GrpcConfiguration {
+	c := *s
+	c.proxyUrl = proxyUrl
+	return &c
+}
+
+func (s *StaticGrpcConfiguration) WithDialer(dialer GrpcDialer) GrpcConfiguration {
+	c := *s
+	c.dialer = dialer
+	return &c
+}
+
+func (s *StaticGrpcConfiguration) WithDialOptions(options ...grpc.DialOption) GrpcConfiguration {
+	c := *s
+	c.dialOptions = append(append([]grpc.DialOption{}, s.dialOptions...), options...)
+	return &c
+}
+
 func (s *StaticGrpcConfiguration) String() string {
-	return fmt.Sprintf("GrpcConfiguration{deadline=%v, keepAlivePermitWithoutCalls=%v, keepAliveTimeout=%v, keepAliveTime=%v, maxSendMessageLength=%v, maxReceiveMessageLength=%v}",
-		s.deadline, s.keepAlivePermitWithoutCalls, s.keepAliveTimeout, s.keepAliveTime, s.maxSendMessageLength, s.maxReceiveMessageLength)
+	return fmt.Sprintf("GrpcConfiguration{deadline=%v, keepAlivePermitWithoutCalls=%v, keepAliveTimeout=%v, keepAliveTime=%v, maxSendMessageLength=%v, maxReceiveMessageLength=%v, %s}",
+		s.deadline, s.keepAlivePermitWithoutCalls, s.keepAliveTimeout, s.keepAliveTime, s.maxSendMessageLength, s.maxReceiveMessageLength, s.grpcDialSettings.String())
 }
 
 type StaticTransportStrategy struct {
@@ -1,6 +1,7 @@
 package grpcmanagers
 
 import (
+	"github.com/momentohq/client-sdk-go/auth"
 	"github.com/momentohq/client-sdk-go/internal/interceptor"
 	"github.com/momentohq/client-sdk-go/internal/models"
 	"github.com/momentohq/client-sdk-go/internal/momentoerrors"
@@ -8,33 +9,32 @@ import (
 )
 
 type AuthGrpcManager struct {
-	Conn      *grpc.ClientConn
-	AuthToken string
+	clientConn
+	CredentialProvider auth.CredentialProvider
 }
 
 func NewAuthGrpcManager(request *models.AuthGrpcManagerRequest) (*AuthGrpcManager, momentoerrors.MomentoSvcErr) {
 	endpoint := request.CredentialProvider.GetControlEndpoint()
-	authToken := request.CredentialProvider.GetAuthToken()
 
 	headerInterceptors := []grpc.UnaryClientInterceptor{
-		interceptor.AddAuthHeadersInterceptor(authToken),
+		interceptor.AddAuthHeadersInterceptor(request.CredentialProvider),
 	}
 
-	conn, err := grpc.NewClient(
-		endpoint,
-		AllDialOptions(
-			request.GrpcConfiguration,
-			request.CredentialProvider.IsControlEndpointSecure(),
-			request.CredentialProvider,
-			grpc.WithChainUnaryInterceptor(headerInterceptors...),
-		)...,
-	)
+	conn, err := newClientConn(connRequest{
+		channels:           request.Channels,
+		endpoint:           endpoint,
+		target:             DialTarget(request.GrpcConfiguration, endpoint),
+		grpcConfig:         request.GrpcConfiguration,
+		secure:             request.CredentialProvider.IsControlEndpointSecure(),
+		credentialProvider: request.CredentialProvider,
+		unaryInterceptors:  headerInterceptors,
+	})
 	if err != nil {
-		return nil, momentoerrors.ConvertSvcErr(err)
+		return nil, err
 	}
-	return &AuthGrpcManager{Conn: conn, AuthToken: authToken}, nil
+	return &AuthGrpcManager{clientConn: conn, CredentialProvider: request.CredentialProvider}, nil
 }
 
 func (authManager *AuthGrpcManager) Close() momentoerrors.MomentoSvcErr {
-	return momentoerrors.ConvertSvcErr(authManager.Conn.Close())
+	return authManager.release()
 }
@@ -0,0 +1,224 @@
+// Package channelpool shares gRPC connections between the clients created by one Momento client.
+package channelpool
+
+import (
+	"errors"
+	"sync"
+
+	"google.golang.org/grpc"
+)
+
+// ErrPoolClosed is returned when a connection is leased from a closed pool.
+var ErrPoolClosed = errors.New("the connection pool is closed")
+
+// Pool holds the gRPC connections shared by several clients. Connections are identified by a key that
+// describes everything they were dialed with, so that only clients that would have dialed identical
+// connections share them. A connection is closed when its last lease is released.
+type Pool struct {
+	mutex  sync.Mutex
+	closed bool
+	// shared holds the connections that can still be leased, by key.
+	shared map[string][]*entry
+	// open holds every open connection, including discarded ones that are still leased.
+	open map[*entry]struct{}
+}
+
+type entry struct {
+	key      string
+	endpoint string
+	conn     *grpc.ClientConn
+	leases   int
+}
+
+// Stats describes the connections of a pool.
+type Stats struct {
+	// Connections is the number of open connections, by endpoint.
+	Connections map[string]int
+	// Leases is the number of client channels using the connections. It is greater than the number of
+	// connections when connections are shared.
+	Leases int
+}
+
+// NewPool returns an empty pool.
+func NewPool() *Pool {
+	return &Pool{
+		shared: make(map[string][]*entry),
+		open:   make(map[*entry]struct{}),
+	}
+}
+
+// NewGroup returns a group to lease the connections of one client through. It returns nil for a nil pool,
+// which dials connections for each client alone.
+func (p *Pool) NewGroup() *Group {
+	if p == nil {
+		return nil
+	}
+	return &Group{pool: p, held: make(map[*entry]struct{})}
+}
+
+// NewExclusiveGroup returns a group whose connections are never shared, for channels that would hold up
+// other clients' requests, such as ones carrying long-lived streams. The pool still counts and closes
+// them. It returns nil for a nil pool.
+func (p *Pool) NewExclusiveGroup() *Group {
+	group := p.NewGroup()
+	if group != nil {
+		group.exclusive = true
+	}
+	return group
+}
+
+// Stats returns the open connections and their leases.
+func (p *Pool) Stats() Stats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	stats := Stats{Connections: make(map[string]int)}
+	for e := range p.open {
+		stats.Connections[e.endpoint]++
+		stats.Leases += e.leases
+	}
+	return stats
+}
+
+// Close closes every open connection, whether or not it is still leased, and fails later leases. It
+// returns the first error closing a connection.
+func (p *Pool) Close() error {
+	p.mutex.Lock()
+	open := p.open
+	p.closed = true
+	p.shared = make(map[string][]*entry)
+	p.open = make(map[*entry]struct{})
+	p.mutex.Unlock()
+
+	var firstErr error
+	for e := range open {
+		if err := e.conn.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Group leases connections for one client. The channels of a client are meant to spread its requests
+// over several connections, so a group never holds the same connection twice.
+type Group struct {
+	pool      *Pool
+	exclusive bool
+	mutex     sync.Mutex
+	held      map[*entry]struct{}
+}
+
+// Lease returns a connection dialed with the settings described by key, sharing the least leased one
+// the group does not hold yet, or else dialing one with dial.
+func (g *Group) Lease(key string, endpoint string, dial func() (*grpc.ClientConn, error)) (*Lease, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	p := g.pool
+
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return nil, ErrPoolClosed
+	}
+	var best *entry
+	if !g.exclusive {
+		for _, e := range p.shared[key] {
+			if _, ok := g.held[e]; ok {
+				continue
+			}
+			if best == nil || e.leases < best.leases {
+				best = e
+			}
+		}
+	}
+	if best != nil {
+		best.leases++
+		g.held[best] = struct{}{}
+		p.mutex.Unlock()
+		return &Lease{group: g, entry: best}, nil
+	}
+	p.mutex.Unlock()
+
+	// Dial without holding the pool's lock. The group's lock keeps the client from dialing the same
+	// connection twice, while other clients may dial their own.
+	conn, err := dial()
+	if err != nil {
+		return nil, err
+	}
+	e := &entry{key: key, endpoint: endpoint, conn: conn, leases: 1}
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		_ = conn.Close()
+		return nil, ErrPoolClosed
+	}
+	if !g.exclusive {
+		p.shared[key] = append(p.shared[key], e)
+	}
+	p.open[e] = struct{}{}
+	p.mutex.Unlock()
+	g.held[e] = struct{}{}
+	return &Lease{group: g, entry: e}, nil
+}
+
+// Lease is a client channel's use of a pooled connection.
+type Lease struct {
+	group *Group
+	entry *entry
+	once  sync.Once
+}
+
+// Conn returns the leased connection.
+func (l *Lease) Conn() *grpc.ClientConn {
+	return l.entry.conn
+}
+
+// Discard stops the connection from being leased again, for instance because it has stalled, so that
+// the next lease dials a fresh one. The connection stays open until its current leases are released.
+func (l *Lease) Discard() {
+	p := l.group.pool
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.unshare(l.entry)
+}
+
+// Release ends the lease, closing the connection if it was the last one.
+func (l *Lease) Release() error {
+	var err error
+	l.once.Do(func() {
+		g := l.group
+		g.mutex.Lock()
+		delete(g.held, l.entry)
+		g.mutex.Unlock()
+
+		p := g.pool
+		p.mutex.Lock()
+		l.entry.leases--
+		_, open := p.open[l.entry]
+		last := open && l.entry.leases == 0
+		if last {
+			p.unshare(l.entry)
+			delete(p.open, l.entry)
+		}
+		p.mutex.Unlock()
+		if last {
+			err = l.entry.conn.Close()
+		}
+	})
+	return err
+}
+
+// unshare removes a connection from the ones that can be leased. The caller must hold the lock.
+func (p *Pool) unshare(e *entry) {
+	entries := p.shared[e.key]
+	for i, candidate := range entries {
+		if candidate == e {
+			entries = append(entries[:i:i], entries[i+1:]...)
+			break
+		}
+	}
+	if len(entries) == 0 {
+		delete(p.shared, e.key)
+	} else {
+		p.shared[e.key] = entries
+	}
+}
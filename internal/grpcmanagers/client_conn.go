@@ -0,0 +1,158 @@
+package grpcmanagers
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/momentohq/client-sdk-go/auth"
+	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/internal/grpcmanagers/channelpool"
+	"github.com/momentohq/client-sdk-go/internal/momentoerrors"
+)
+
+// clientConn is the connection of a gRPC manager. When the manager leases it from a channel pool, the
+// connection may be shared with other clients, so it is dialed without interceptors and Channel applies
+// the manager's own.
+type clientConn struct {
+	// Conn is the connection requests are sent on.
+	Conn *grpc.ClientConn
+	// Channel sends requests on Conn through the manager's interceptors. gRPC service clients are
+	// created with it.
+	Channel grpc.ClientConnInterface
+	lease   *channelpool.Lease
+}
+
+// connRequest describes the connection a manager needs.
+type connRequest struct {
+	// channels leases the connection from a channel pool, or is nil to dial a connection of its own.
+	channels           *channelpool.Group
+	endpoint           string
+	target             string
+	grpcConfig         config.IGrpcConfiguration
+	secure             bool
+	credentialProvider auth.CredentialProvider
+	unaryInterceptors  []grpc.UnaryClientInterceptor
+	streamInterceptors []grpc.StreamClientInterceptor
+}
+
+func newClientConn(request connRequest) (clientConn, momentoerrors.MomentoSvcErr) {
+	key := sharingKey(request)
+	if request.channels == nil || key == "" {
+		conn, err := grpc.NewClient(
+			request.target,
+			AllDialOptions(
+				request.grpcConfig,
+				request.endpoint,
+				request.secure,
+				request.credentialProvider,
+				grpc.WithChainUnaryInterceptor(request.unaryInterceptors...),
+				grpc.WithChainStreamInterceptor(request.streamInterceptors...),
+			)...,
+		)
+		if err != nil {
+			return clientConn{}, momentoerrors.ConvertSvcErr(err)
+		}
+		return clientConn{Conn: conn, Channel: conn}, nil
+	}
+
+	lease, err := request.channels.Lease(key, request.endpoint, func() (*grpc.ClientConn, error) {
+		return grpc.NewClient(
+			request.target,
+			AllDialOptions(request.grpcConfig, request.endpoint, request.secure, request.credentialProvider)...,
+		)
+	})
+	if err != nil {
+		return clientConn{}, momentoerrors.ConvertSvcErr(err)
+	}
+	return clientConn{
+		Conn: lease.Conn(),
+		Channel: &interceptedChannel{
+			conn:               lease.Conn(),
+			unaryInterceptors:  request.unaryInterceptors,
+			streamInterceptors: request.streamInterceptors,
+		},
+		lease: lease,
+	}, nil
+}
+
+// sharingKey describes every setting the connection is dialed with, so that only connections that would be
+// dialed identically are shared. It is empty if the connection cannot be shared because it is configured
+// with a dialer or dial options that cannot be compared.
+func sharingKey(request connRequest) string {
+	grpcConfig := request.grpcConfig
+	if grpcConfig.GetDialer() != nil || len(grpcConfig.GetDialOptions()) > 0 {
+		return ""
+	}
+	proxyUrl := ""
+	if proxy := grpcConfig.GetProxyUrl(); proxy != nil {
+		proxyUrl = proxy.String()
+	}
+	serverName := ""
+	if request.secure {
+		serverName = tlsServerName(grpcConfig, request.endpoint, request.credentialProvider)
+	}
+	certificates := grpcConfig.GetClientCertificates()
+	return fmt.Sprintf(
+		"%s secure=%t server=%s tls=%p roots=%p certs=%p/%d proxy=%s recv=%d send=%d keepalive=%t/%s/%s",
+		request.target, request.secure, serverName,
+		grpcConfig.GetTlsConfig(), grpcConfig.GetRootCAs(), certificates, len(certificates), proxyUrl,
+		grpcConfig.GetMaxReceiveMessageLength(), grpcConfig.GetMaxSendMessageLength(),
+		grpcConfig.GetKeepAlivePermitWithoutCalls(), grpcConfig.GetKeepAliveTime(), grpcConfig.GetKeepAliveTimeout(),
+	)
+}
+
+// Discard keeps a leased connection from being leased again, for instance because it has stalled. It does
+// nothing if the connection is not leased from a channel pool.
+func (c *clientConn) Discard() {
+	if c.lease != nil {
+		c.lease.Discard()
+	}
+}
+
+// release closes the connection, or ends its lease if it is leased from a channel pool.
+func (c *clientConn) release() momentoerrors.MomentoSvcErr {
+	if c.lease != nil {
+		return momentoerrors.ConvertSvcErr(c.lease.Release())
+	}
+	return momentoerrors.ConvertSvcErr(c.Conn.Close())
+}
+
+// interceptedChannel applies a manager's interceptors to the requests it sends on a shared connection, in
+// the order grpc.WithChainUnaryInterceptor and grpc.WithChainStreamInterceptor would.
+type interceptedChannel struct {
+	conn               *grpc.ClientConn
+	unaryInterceptors  []grpc.UnaryClientInterceptor
+	streamInterceptors []grpc.StreamClientInterceptor
+}
+
+func (c *interceptedChannel) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	return c.invoke(0)(ctx, method, args, reply, c.conn, opts...)
+}
+
+func (c *interceptedChannel) invoke(i int) grpc.UnaryInvoker {
+	if i == len(c.unaryInterceptors) {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return cc.Invoke(ctx, method, req, reply, opts...)
+		}
+	}
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.unaryInterceptors[i](ctx, method, req, reply, cc, c.invoke(i+1), opts...)
+	}
+}
+
+func (c *interceptedChannel) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return c.stream(0)(ctx, desc, c.conn, method, opts...)
+}
+
+func (c *interceptedChannel) stream(i int) grpc.Streamer {
+	if i == len(c.streamInterceptors) {
+		return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return cc.NewStream(ctx, desc, method, opts...)
+		}
+	}
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return c.streamInterceptors[i](ctx, desc, cc, method, c.stream(i+1), opts...)
+	}
+}
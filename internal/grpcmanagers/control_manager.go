@@ -10,35 +10,34 @@ import (
 )
 
 type ScsControlGrpcManager struct {
-	Conn *grpc.ClientConn
+	clientConn
 }
 
 func NewScsControlGrpcManager(request *models.ControlGrpcManagerRequest) (*ScsControlGrpcManager, momentoerrors.MomentoSvcErr) {
-	authToken := request.CredentialProvider.GetAuthToken()
 	endpoint := request.CredentialProvider.GetControlEndpoint()
 
 	// Override grpc config to disable keepalives
 	controlConfig := config.NewStaticGrpcConfiguration(&config.GrpcConfigurationProps{}).WithKeepAliveDisabled()
 
 	headerInterceptors := []grpc.UnaryClientInterceptor{
-		interceptor.AddAuthHeadersInterceptor(authToken),
+		interceptor.AddAuthHeadersInterceptor(request.CredentialProvider),
 	}
 
-	conn, err := grpc.NewClient(
-		endpoint,
-		AllDialOptions(
-			controlConfig,
-			request.CredentialProvider.IsControlEndpointSecure(),
-			request.CredentialProvider,
-			grpc.WithChainUnaryInterceptor(headerInterceptors...),
-		)...,
-	)
+	conn, err := newClientConn(connRequest{
+		channels:           request.Channels,
+		endpoint:           endpoint,
+		target:             DialTarget(request.GrpcConfiguration, endpoint),
+		grpcConfig:         controlConfig,
+		secure:             request.CredentialProvider.IsControlEndpointSecure(),
+		credentialProvider: request.CredentialProvider,
+		unaryInterceptors:  headerInterceptors,
+	})
 	if err != nil {
-		return nil, momentoerrors.ConvertSvcErr(err)
+		return nil, err
 	}
-	return &ScsControlGrpcManager{Conn: conn}, nil
+	return &ScsControlGrpcManager{clientConn: conn}, nil
 }
 
 func (controlManager *ScsControlGrpcManager) Close() momentoerrors.MomentoSvcErr {
-	return momentoerrors.ConvertSvcErr(controlManager.Conn.Close())
+	return controlManager.release()
 }
@@ -13,12 +13,11 @@ import (
 )
 
 type DataGrpcManager struct {
-	Conn *grpc.ClientConn
+	clientConn
 }
 
 func NewUnaryDataGrpcManager(request *models.DataGrpcManagerRequest) (*DataGrpcManager, momentoerrors.MomentoSvcErr) {
 	endpoint := request.CredentialProvider.GetCacheEndpoint()
-	authToken := request.CredentialProvider.GetAuthToken()
 
 	// Check the middleware list for an "InterceptorCallbackMiddleware" and use the OnInterceptorRequest callback method
 	// if it is found. This is currently used for testing purposes only by the MomentoLocalMiddleware, but it could be
@@ -36,30 +35,27 @@ func NewUnaryDataGrpcManager(request *models.DataGrpcManagerRequest) (*DataGrpcM
 	headerInterceptors := []grpc.UnaryClientInterceptor{
 		interceptor.AddUnaryRetryInterceptor(request.RetryStrategy, onRequestCallback, request.GrpcConfiguration.GetDeadline()),
 		interceptor.AddReadConcernHeaderInterceptor(request.ReadConcern),
-		interceptor.AddAuthHeadersInterceptor(authToken),
+		interceptor.AddAuthHeadersInterceptor(request.CredentialProvider),
 	}
 
-	var conn *grpc.ClientConn
-	var err error
-	conn, err = grpc.NewClient(
-		endpoint,
-		AllDialOptions(
-			request.GrpcConfiguration,
-			request.CredentialProvider.IsCacheEndpointSecure(),
-			request.CredentialProvider,
-			grpc.WithChainUnaryInterceptor(headerInterceptors...),
-			grpc.WithChainStreamInterceptor(interceptor.AddStreamHeaderInterceptor(authToken)),
-		)...,
-	)
-
+	conn, err := newClientConn(connRequest{
+		channels:           request.Channels,
+		endpoint:           endpoint,
+		target:             DialTarget(request.GrpcConfiguration, endpoint),
+		grpcConfig:         request.GrpcConfiguration,
+		secure:             request.CredentialProvider.IsCacheEndpointSecure(),
+		credentialProvider: request.CredentialProvider,
+		unaryInterceptors:  headerInterceptors,
+		streamInterceptors: []grpc.StreamClientInterceptor{interceptor.AddStreamHeaderInterceptor(request.CredentialProvider)},
+	})
 	if err != nil {
-		return nil, momentoerrors.ConvertSvcErr(err)
+		return nil, err
 	}
-	return &DataGrpcManager{Conn: conn}, nil
+	return &DataGrpcManager{clientConn: conn}, nil
 }
 
 func (dataManager *DataGrpcManager) Close() momentoerrors.MomentoSvcErr {
-	return momentoerrors.ConvertSvcErr(dataManager.Conn.Close())
+	return dataManager.release()
 }
 
 func (dataManager *DataGrpcManager) Connect(ctx context.Context) error {
@@ -1,7 +1,9 @@
 package grpcmanagers
 
 import (
+	"context"
 	"crypto/tls"
+	"net"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -30,19 +32,75 @@ func GrpcChannelOptionsFromGrpcConfig(grpcConfig config.IGrpcConfiguration) []gr
 	}
 }
 
-func TransportCredentialsChannelOption(secureEndpoint bool, credentialProvider auth.CredentialProvider) grpc.DialOption {
+func TransportCredentialsChannelOption(grpcConfig config.IGrpcConfiguration, endpoint string, secureEndpoint bool, credentialProvider auth.CredentialProvider) grpc.DialOption {
 	if !secureEndpoint {
 		return grpc.WithTransportCredentials(insecure.NewCredentials())
 	}
-	config := &tls.Config{
-		InsecureSkipVerify: false,
-		ServerName:         credentialProvider.GetCacheTlsHostname(),
+	return grpc.WithTransportCredentials(credentials.NewTLS(endpointTlsConfig(grpcConfig, endpoint, credentialProvider)))
+}
+
+// endpointTlsConfig returns the TLS configuration for connections to the endpoint. It is merged in order
+// from the configured tls.Config, the endpoint's server name, the root certificate authorities and the
+// client certificates, each overriding the former.
+func endpointTlsConfig(grpcConfig config.IGrpcConfiguration, endpoint string, credentialProvider auth.CredentialProvider) *tls.Config {
+	tlsConfig := &tls.Config{}
+	if custom := grpcConfig.GetTlsConfig(); custom != nil {
+		tlsConfig = custom.Clone()
+	}
+	tlsConfig.InsecureSkipVerify = false
+	tlsConfig.ServerName = tlsServerName(grpcConfig, endpoint, credentialProvider)
+	if rootCAs := grpcConfig.GetRootCAs(); rootCAs != nil {
+		tlsConfig.RootCAs = rootCAs
+	}
+	if certificates := grpcConfig.GetClientCertificates(); len(certificates) > 0 {
+		tlsConfig.Certificates = append(append([]tls.Certificate{}, tlsConfig.Certificates...), certificates...)
 	}
-	return grpc.WithTransportCredentials(credentials.NewTLS(config))
+	return tlsConfig
 }
 
-func AllDialOptions(grpcConfig config.IGrpcConfiguration, secureEndpoint bool, credentialProvider auth.CredentialProvider, options ...grpc.DialOption) []grpc.DialOption {
-	options = append(options, TransportCredentialsChannelOption(secureEndpoint, credentialProvider))
+// tlsServerName returns the server name sent to the endpoint for SNI.
+func tlsServerName(grpcConfig config.IGrpcConfiguration, endpoint string, credentialProvider auth.CredentialProvider) string {
+	if serverName := grpcConfig.GetTlsServerName(endpoint); serverName != "" {
+		return serverName
+	}
+	return credentialProvider.GetCacheTlsHostname()
+}
+
+// DialerChannelOption returns the option that opens connections with the configured dialer and proxy, or nil
+// if neither is configured.
+func DialerChannelOption(grpcConfig config.IGrpcConfiguration) grpc.DialOption {
+	dialer := grpcConfig.GetDialer()
+	if proxyUrl := grpcConfig.GetProxyUrl(); proxyUrl != nil {
+		if dialer == nil {
+			var netDialer net.Dialer
+			dialer = func(ctx context.Context, address string) (net.Conn, error) {
+				return netDialer.DialContext(ctx, "tcp", address)
+			}
+		}
+		dialer = proxyDialer(proxyUrl, grpcConfig.GetRootCAs(), dialer)
+	}
+	if dialer == nil {
+		return nil
+	}
+	return grpc.WithContextDialer(dialer)
+}
+
+// DialTarget returns the target to create a client for the endpoint with. When connections are opened by a
+// dialer or proxy, the endpoint is passed to it unresolved, so that proxies and dialers receive host names.
+func DialTarget(grpcConfig config.IGrpcConfiguration, endpoint string) string {
+	if grpcConfig.GetDialer() != nil || grpcConfig.GetProxyUrl() != nil {
+		return "passthrough:///" + endpoint
+	}
+	return endpoint
+}
+
+func AllDialOptions(grpcConfig config.IGrpcConfiguration, endpoint string, secureEndpoint bool, credentialProvider auth.CredentialProvider, options ...grpc.DialOption) []grpc.DialOption {
+	options = append(options, TransportCredentialsChannelOption(grpcConfig, endpoint, secureEndpoint, credentialProvider))
 	options = append(options, GrpcChannelOptionsFromGrpcConfig(grpcConfig)...)
+	if dialerOption := DialerChannelOption(grpcConfig); dialerOption != nil {
+		options = append(options, dialerOption)
+	}
+	// User supplied options come last, so that they take precedence.
+	options = append(options, grpcConfig.GetDialOptions()...)
 	return options
 }
@@ -0,0 +1,118 @@
+package grpcmanagers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/momentohq/client-sdk-go/auth"
+	"github.com/momentohq/client-sdk-go/config"
+)
+
+// tlsHostnameProvider is a credential provider with the given cache TLS host name.
+type tlsHostnameProvider struct {
+	auth.CredentialProvider
+	hostname string
+}
+
+func (p tlsHostnameProvider) GetCacheTlsHostname() string {
+	return p.hostname
+}
+
+var _ = Describe("grpc-channel-options", func() {
+	const (
+		cacheEndpoint   = "cache.private.example.com:443"
+		controlEndpoint = "control.private.example.com:443"
+		tokenEndpoint   = "token.private.example.com:443"
+	)
+
+	var (
+		grpcConfig config.GrpcConfiguration
+		provider   auth.CredentialProvider
+	)
+
+	BeforeEach(func() {
+		grpcConfig = config.NewStaticGrpcConfiguration(&config.GrpcConfigurationProps{})
+		provider = tlsHostnameProvider{hostname: "cache.example.com"}
+	})
+
+	It("verifies secure endpoints as the cache host by default", func() {
+		tlsConfig := endpointTlsConfig(grpcConfig, cacheEndpoint, provider)
+		Expect(tlsConfig.ServerName).To(Equal("cache.example.com"))
+		Expect(tlsConfig.InsecureSkipVerify).To(BeFalse())
+		Expect(tlsConfig.RootCAs).To(BeNil())
+		Expect(tlsConfig.Certificates).To(BeEmpty())
+	})
+
+	It("merges the TLS settings over the configured tls.Config", func() {
+		customRoots := x509.NewCertPool()
+		custom := &tls.Config{
+			ServerName:         "ignored.example.com",
+			InsecureSkipVerify: true,
+			MinVersion:         tls.VersionTLS13,
+			RootCAs:            customRoots,
+			Certificates:       []tls.Certificate{{Certificate: [][]byte{[]byte("custom")}}},
+		}
+		grpcConfig = grpcConfig.WithTlsConfig(custom)
+
+		tlsConfig := endpointTlsConfig(grpcConfig, cacheEndpoint, provider)
+		Expect(tlsConfig.MinVersion).To(Equal(uint16(tls.VersionTLS13)))
+		Expect(tlsConfig.ServerName).To(Equal("cache.example.com"))
+		Expect(tlsConfig.InsecureSkipVerify).To(BeFalse())
+		Expect(tlsConfig.RootCAs).To(BeIdenticalTo(customRoots))
+
+		roots := x509.NewCertPool()
+		grpcConfig = grpcConfig.
+			WithRootCAs(roots).
+			WithClientCertificates(tls.Certificate{Certificate: [][]byte{[]byte("client")}})
+		tlsConfig = endpointTlsConfig(grpcConfig, cacheEndpoint, provider)
+		Expect(tlsConfig.RootCAs).To(BeIdenticalTo(roots))
+		Expect(tlsConfig.Certificates).To(Equal([]tls.Certificate{
+			{Certificate: [][]byte{[]byte("custom")}},
+			{Certificate: [][]byte{[]byte("client")}},
+		}))
+
+		// The configured tls.Config is left untouched.
+		Expect(custom.ServerName).To(Equal("ignored.example.com"))
+		Expect(custom.InsecureSkipVerify).To(BeTrue())
+		Expect(custom.Certificates).To(HaveLen(1))
+	})
+
+	It("sends each endpoint its own server name", func() {
+		base := grpcConfig.WithTlsServerName("cache.private.example.com", "cache.sni.example.com")
+		grpcConfig = base.WithTlsServerName("control.private.example.com", "control.sni.example.com")
+
+		Expect(endpointTlsConfig(grpcConfig, cacheEndpoint, provider).ServerName).To(Equal("cache.sni.example.com"))
+		Expect(endpointTlsConfig(grpcConfig, controlEndpoint, provider).ServerName).To(Equal("control.sni.example.com"))
+		Expect(endpointTlsConfig(grpcConfig, tokenEndpoint, provider).ServerName).To(Equal("cache.example.com"))
+		// Copies do not share server names.
+		Expect(base.GetTlsServerName(controlEndpoint)).To(BeEmpty())
+
+		// Connections with different server names are not shared.
+		Expect(sharingKey(connRequest{
+			endpoint: cacheEndpoint, target: cacheEndpoint, grpcConfig: grpcConfig, secure: true, credentialProvider: provider,
+		})).To(ContainSubstring("server=cache.sni.example.com"))
+	})
+
+	It("dials endpoints directly unless a dialer or proxy is configured", func() {
+		Expect(DialerChannelOption(grpcConfig)).To(BeNil())
+		Expect(DialTarget(grpcConfig, cacheEndpoint)).To(Equal(cacheEndpoint))
+
+		proxyUrl, err := url.Parse("http://proxy.example.com:3128")
+		Expect(err).To(BeNil())
+		proxied := grpcConfig.WithProxyUrl(proxyUrl)
+		Expect(DialerChannelOption(proxied)).NotTo(BeNil())
+		Expect(DialTarget(proxied, cacheEndpoint)).To(Equal("passthrough:///" + cacheEndpoint))
+
+		dialed := grpcConfig.WithDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return nil, nil
+		})
+		Expect(DialerChannelOption(dialed)).NotTo(BeNil())
+		Expect(DialTarget(dialed, cacheEndpoint)).To(Equal("passthrough:///" + cacheEndpoint))
+	})
+})
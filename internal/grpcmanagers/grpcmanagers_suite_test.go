@@ -0,0 +1,13 @@
+package grpcmanagers
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGrpcManagers(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Grpc Managers Suite")
+}
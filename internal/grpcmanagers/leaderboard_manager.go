@@ -9,32 +9,31 @@ import (
 )
 
 type LeaderboardGrpcManager struct {
-	Conn *grpc.ClientConn
+	clientConn
 }
 
 func NewLeaderboardGrpcManager(request *models.LeaderboardGrpcManagerRequest) (*LeaderboardGrpcManager, momentoerrors.MomentoSvcErr) {
 	endpoint := request.CredentialProvider.GetCacheEndpoint()
-	authToken := request.CredentialProvider.GetAuthToken()
 
 	headerInterceptors := []grpc.UnaryClientInterceptor{
-		interceptor.AddAuthHeadersInterceptor(authToken),
+		interceptor.AddAuthHeadersInterceptor(request.CredentialProvider),
 	}
 
-	conn, err := grpc.NewClient(
-		endpoint,
-		AllDialOptions(
-			request.GrpcConfiguration,
-			request.CredentialProvider.IsCacheEndpointSecure(),
-			request.CredentialProvider,
-			grpc.WithChainUnaryInterceptor(headerInterceptors...),
-		)...,
-	)
+	conn, err := newClientConn(connRequest{
+		channels:           request.Channels,
+		endpoint:           endpoint,
+		target:             DialTarget(request.GrpcConfiguration, endpoint),
+		grpcConfig:         request.GrpcConfiguration,
+		secure:             request.CredentialProvider.IsCacheEndpointSecure(),
+		credentialProvider: request.CredentialProvider,
+		unaryInterceptors:  headerInterceptors,
+	})
 	if err != nil {
-		return nil, momentoerrors.ConvertSvcErr(err)
+		return nil, err
 	}
-	return &LeaderboardGrpcManager{Conn: conn}, nil
+	return &LeaderboardGrpcManager{clientConn: conn}, nil
 }
 
 func (grpcManager *LeaderboardGrpcManager) Close() momentoerrors.MomentoSvcErr {
-	return momentoerrors.ConvertSvcErr(grpcManager.Conn.Close())
+	return grpcManager.release()
 }
@@ -9,32 +9,31 @@ import (
 )
 
 type PingGrpcManager struct {
-	Conn *grpc.ClientConn
+	clientConn
 }
 
 func NewPingGrpcManager(request *models.PingGrpcManagerRequest) (*PingGrpcManager, momentoerrors.MomentoSvcErr) {
 	endpoint := request.CredentialProvider.GetCacheEndpoint()
-	authToken := request.CredentialProvider.GetAuthToken()
 
 	headerInterceptors := []grpc.UnaryClientInterceptor{
-		interceptor.AddAuthHeadersInterceptor(authToken),
+		interceptor.AddAuthHeadersInterceptor(request.CredentialProvider),
 	}
 
-	conn, err := grpc.NewClient(
-		endpoint,
-		AllDialOptions(
-			request.GrpcConfiguration,
-			request.CredentialProvider.IsCacheEndpointSecure(),
-			request.CredentialProvider,
-			grpc.WithChainUnaryInterceptor(headerInterceptors...),
-		)...,
-	)
+	conn, err := newClientConn(connRequest{
+		channels:           request.Channels,
+		endpoint:           endpoint,
+		target:             DialTarget(request.GrpcConfiguration, endpoint),
+		grpcConfig:         request.GrpcConfiguration,
+		secure:             request.CredentialProvider.IsCacheEndpointSecure(),
+		credentialProvider: request.CredentialProvider,
+		unaryInterceptors:  headerInterceptors,
+	})
 	if err != nil {
-		return nil, momentoerrors.ConvertSvcErr(err)
+		return nil, err
 	}
-	return &PingGrpcManager{Conn: conn}, nil
+	return &PingGrpcManager{clientConn: conn}, nil
 }
 
 func (pingManager *PingGrpcManager) Close() momentoerrors.MomentoSvcErr {
-	return momentoerrors.ConvertSvcErr(pingManager.Conn.Close())
+	return pingManager.release()
 }
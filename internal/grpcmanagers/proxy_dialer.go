@@ -0,0 +1,97 @@
+package grpcmanagers
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	b64 "encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config"
+)
+
+// proxyDialer returns a dialer that tunnels connections through an HTTP or HTTPS proxy with CONNECT. The
+// connection to the proxy is opened with dial. Connections to an HTTPS proxy are verified against rootCAs, or
+// the host's root certificate authorities if it is nil.
+func proxyDialer(proxyUrl *url.URL, rootCAs *x509.CertPool, dial config.GrpcDialer) config.GrpcDialer {
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		proxyAddress := proxyUrl.Host
+		if proxyUrl.Port() == "" {
+			port := "80"
+			if proxyUrl.Scheme == "https" {
+				port = "443"
+			}
+			proxyAddress = net.JoinHostPort(proxyUrl.Hostname(), port)
+		}
+		conn, err := dial(ctx, proxyAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to proxy %s: %w", proxyAddress, err)
+		}
+		if proxyUrl.Scheme == "https" {
+			tlsConn := tls.Client(conn, &tls.Config{ServerName: proxyUrl.Hostname(), RootCAs: rootCAs})
+			if err := tlsConn.HandshakeContext(ctx); err != nil {
+				conn.Close()
+				return nil, fmt.Errorf("failed TLS handshake with proxy %s: %w", proxyAddress, err)
+			}
+			conn = tlsConn
+		}
+
+		tunnel, err := connectThroughProxy(ctx, conn, proxyUrl, address)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return tunnel, nil
+	}
+}
+
+func connectThroughProxy(ctx context.Context, conn net.Conn, proxyUrl *url.URL, address string) (net.Conn, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+		defer conn.SetDeadline(time.Time{})
+	}
+
+	request := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Host: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if user := proxyUrl.User; user != nil {
+		password, _ := user.Password()
+		credentials := b64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		request.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := request.Write(conn); err != nil {
+		return nil, fmt.Errorf("failed to send CONNECT to proxy: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CONNECT response from proxy: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf("proxy refused CONNECT to %s: %s", address, response.Status)
+	}
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
+
+// bufferedConn reads through the reader the proxy's response was read with, as it may have buffered data
+// sent by the server after the response.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
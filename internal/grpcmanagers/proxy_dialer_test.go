@@ -0,0 +1,170 @@
+package grpcmanagers
+
+import (
+	"context"
+	"crypto/x509"
+	b64 "encoding/base64"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// connectRequest is a CONNECT request received by a fake proxy.
+type connectRequest struct {
+	address       string
+	authorization string
+}
+
+// connectProxy answers CONNECT requests that carry the authorization, or any if it is empty, and echoes
+// what is sent through the tunnel. It sends a greeting right after its response, in the same write, as a
+// server that speaks first would.
+func connectProxy(authorization string, requests chan<- connectRequest) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- connectRequest{address: r.Host, authorization: r.Header.Get("Proxy-Authorization")}
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if authorization != "" && r.Header.Get("Proxy-Authorization") != authorization {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		conn, buffered, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if _, err := conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\ngreeting")); err != nil {
+			return
+		}
+		_, _ = io.Copy(conn, buffered)
+	})
+}
+
+// tcpDialer dials addresses over TCP and records them.
+func tcpDialer(addresses chan<- string) func(ctx context.Context, address string) (net.Conn, error) {
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		addresses <- address
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "tcp", address)
+	}
+}
+
+var _ = Describe("proxy-dialer", func() {
+	const target = "cache.example.com:443"
+
+	var (
+		requests  chan connectRequest
+		addresses chan string
+		ctx       context.Context
+	)
+
+	// expectTunnel expects the connection to be tunneled to the fake proxy's echo.
+	expectTunnel := func(conn net.Conn) {
+		Expect(conn.SetDeadline(time.Now().Add(time.Second))).To(Succeed())
+		greeting := make([]byte, len("greeting"))
+		_, err := io.ReadFull(conn, greeting)
+		Expect(err).To(BeNil())
+		Expect(string(greeting)).To(Equal("greeting"))
+
+		_, err = conn.Write([]byte("ping"))
+		Expect(err).To(BeNil())
+		echo := make([]byte, len("ping"))
+		_, err = io.ReadFull(conn, echo)
+		Expect(err).To(BeNil())
+		Expect(string(echo)).To(Equal("ping"))
+	}
+
+	BeforeEach(func() {
+		requests = make(chan connectRequest, 10)
+		addresses = make(chan string, 10)
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+		DeferCleanup(cancel)
+	})
+
+	It("tunnels connections through the proxy with CONNECT", func() {
+		proxy := httptest.NewServer(connectProxy("", requests))
+		DeferCleanup(proxy.Close)
+		proxyUrl, err := url.Parse(proxy.URL)
+		Expect(err).To(BeNil())
+
+		conn, err := proxyDialer(proxyUrl, nil, tcpDialer(addresses))(ctx, target)
+		Expect(err).To(BeNil())
+		defer conn.Close()
+		// The greeting was read along with the proxy's response, and must still reach the caller.
+		expectTunnel(conn)
+
+		Expect(<-addresses).To(Equal(proxyUrl.Host))
+		request := <-requests
+		Expect(request.address).To(Equal(target))
+		Expect(request.authorization).To(BeEmpty())
+	})
+
+	It("authenticates with the credentials in the proxy's URL", func() {
+		authorization := "Basic " + b64.StdEncoding.EncodeToString([]byte("user:p@ss"))
+		proxy := httptest.NewServer(connectProxy(authorization, requests))
+		DeferCleanup(proxy.Close)
+		proxyUrl, err := url.Parse(proxy.URL)
+		Expect(err).To(BeNil())
+		proxyUrl.User = url.UserPassword("user", "p@ss")
+
+		conn, err := proxyDialer(proxyUrl, nil, tcpDialer(addresses))(ctx, target)
+		Expect(err).To(BeNil())
+		defer conn.Close()
+		expectTunnel(conn)
+		Expect((<-requests).authorization).To(Equal(authorization))
+	})
+
+	It("fails when the proxy refuses to CONNECT", func() {
+		proxy := httptest.NewServer(connectProxy("Basic secret", requests))
+		DeferCleanup(proxy.Close)
+		proxyUrl, err := url.Parse(proxy.URL)
+		Expect(err).To(BeNil())
+
+		_, err = proxyDialer(proxyUrl, nil, tcpDialer(addresses))(ctx, target)
+		Expect(err).To(MatchError(ContainSubstring("407")))
+	})
+
+	It("verifies HTTPS proxies against the root certificate authorities", func() {
+		proxy := httptest.NewTLSServer(connectProxy("", requests))
+		DeferCleanup(proxy.Close)
+		proxyUrl, err := url.Parse(proxy.URL)
+		Expect(err).To(BeNil())
+
+		_, err = proxyDialer(proxyUrl, x509.NewCertPool(), tcpDialer(addresses))(ctx, target)
+		Expect(err).To(MatchError(ContainSubstring("failed TLS handshake with proxy")))
+
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(proxy.Certificate())
+		conn, err := proxyDialer(proxyUrl, rootCAs, tcpDialer(addresses))(ctx, target)
+		Expect(err).To(BeNil())
+		defer conn.Close()
+		expectTunnel(conn)
+	})
+
+	It("connects to the proxy's default port", func() {
+		refused := errors.New("refused")
+		dial := func(_ context.Context, address string) (net.Conn, error) {
+			addresses <- address
+			return nil, refused
+		}
+		for proxy, address := range map[string]string{
+			"http://proxy.example.com":  "proxy.example.com:80",
+			"https://proxy.example.com": "proxy.example.com:443",
+		} {
+			proxyUrl, err := url.Parse(proxy)
+			Expect(err).To(BeNil())
+			_, err = proxyDialer(proxyUrl, nil, dial)(ctx, target)
+			Expect(errors.Is(err, refused)).To(BeTrue())
+			Expect(<-addresses).To(Equal(address))
+		}
+	})
+})
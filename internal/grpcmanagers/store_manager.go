@@ -8,33 +8,31 @@ import (
 )
 
 type StoreGrpcManager struct {
-	Conn *grpc.ClientConn
+	clientConn
 }
 
 func NewStoreGrpcManager(request *models.StoreGrpcManagerRequest) (*StoreGrpcManager, momentoerrors.MomentoSvcErr) {
 	endpoint := request.CredentialProvider.GetStorageEndpoint()
-	authToken := request.CredentialProvider.GetAuthToken()
 
 	headerInterceptors := []grpc.UnaryClientInterceptor{
-		interceptor.AddAuthHeadersInterceptor(authToken),
+		interceptor.AddAuthHeadersInterceptor(request.CredentialProvider),
 	}
 
-	conn, err := grpc.NewClient(
-		endpoint,
-		AllDialOptions(
-			request.GrpcConfiguration,
-			request.CredentialProvider.IsStorageEndpointSecure(),
-			request.CredentialProvider,
-			grpc.WithChainUnaryInterceptor(headerInterceptors...),
-		)...,
-	)
-
+	conn, err := newClientConn(connRequest{
+		channels:           request.Channels,
+		endpoint:           endpoint,
+		target:             DialTarget(request.GrpcConfiguration, endpoint),
+		grpcConfig:         request.GrpcConfiguration,
+		secure:             request.CredentialProvider.IsStorageEndpointSecure(),
+		credentialProvider: request.CredentialProvider,
+		unaryInterceptors:  headerInterceptors,
+	})
 	if err != nil {
-		return nil, momentoerrors.ConvertSvcErr(err)
+		return nil, err
 	}
-	return &StoreGrpcManager{Conn: conn}, nil
+	return &StoreGrpcManager{clientConn: conn}, nil
 }
 
 func (grpcManager *StoreGrpcManager) Close() momentoerrors.MomentoSvcErr {
-	return momentoerrors.ConvertSvcErr(grpcManager.Conn.Close())
+	return grpcManager.release()
 }
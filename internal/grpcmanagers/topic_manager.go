@@ -11,40 +11,38 @@ import (
 )
 
 type TopicGrpcManager struct {
-	Conn                   *grpc.ClientConn
+	clientConn
 	StreamClient           pb.PubsubClient
 	NumActiveSubscriptions atomic.Int64
 }
 
 func NewStreamTopicGrpcManager(request *models.TopicStreamGrpcManagerRequest) (*TopicGrpcManager, momentoerrors.MomentoSvcErr) {
 	endpoint := request.CredentialProvider.GetCacheEndpoint()
-	authToken := request.CredentialProvider.GetAuthToken()
 
 	headerInterceptors := []grpc.StreamClientInterceptor{
-		interceptor.AddStreamHeaderInterceptor(authToken),
+		interceptor.AddStreamHeaderInterceptor(request.CredentialProvider),
 	}
 
-	conn, err := grpc.NewClient(
-		endpoint,
-		AllDialOptions(
-			request.GrpcConfiguration,
-			request.CredentialProvider.IsCacheEndpointSecure(),
-			request.CredentialProvider,
-			grpc.WithChainStreamInterceptor(headerInterceptors...),
-			grpc.WithChainUnaryInterceptor(interceptor.AddAuthHeadersInterceptor(authToken)),
-		)...,
-	)
-
+	conn, err := newClientConn(connRequest{
+		channels:           request.Channels,
+		endpoint:           endpoint,
+		target:             DialTarget(request.GrpcConfiguration, endpoint),
+		grpcConfig:         request.GrpcConfiguration,
+		secure:             request.CredentialProvider.IsCacheEndpointSecure(),
+		credentialProvider: request.CredentialProvider,
+		unaryInterceptors:  []grpc.UnaryClientInterceptor{interceptor.AddAuthHeadersInterceptor(request.CredentialProvider)},
+		streamInterceptors: headerInterceptors,
+	})
 	if err != nil {
-		return nil, momentoerrors.ConvertSvcErr(err)
+		return nil, err
 	}
 	return &TopicGrpcManager{
-		Conn:         conn,
-		StreamClient: pb.NewPubsubClient(conn),
+		clientConn:   conn,
+		StreamClient: pb.NewPubsubClient(conn.Channel),
 	}, nil
 }
 
 func (topicManager *TopicGrpcManager) Close() momentoerrors.MomentoSvcErr {
 	topicManager.NumActiveSubscriptions.Store(0)
-	return momentoerrors.ConvertSvcErr(topicManager.Conn.Close())
+	return topicManager.release()
 }
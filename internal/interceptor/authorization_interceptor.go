@@ -3,26 +3,36 @@ package interceptor
 import (
 	"context"
 
+	"github.com/momentohq/client-sdk-go/auth"
 	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/internal/models"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
-func AddAuthHeadersInterceptor(authToken string) func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+// AddAuthHeadersInterceptor returns a unary interceptor that authorizes each call with the current auth token of
+// the credential provider, so that rotated credentials are used without reconnecting.
+func AddAuthHeadersInterceptor(credentialProvider auth.CredentialProvider) func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-		return invoker(metadata.AppendToOutgoingContext(ctx, "authorization", authToken), method, req, reply, cc, opts...)
+		return invoker(metadata.AppendToOutgoingContext(ctx, "authorization", credentialProvider.GetAuthToken()), method, req, reply, cc, opts...)
 	}
 }
 
 func AddReadConcernHeaderInterceptor(readConcern config.ReadConcern) func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-		return invoker(metadata.AppendToOutgoingContext(ctx, "read-concern", string(readConcern)), method, req, reply, cc, opts...)
+		requestReadConcern := readConcern
+		if overrides, ok := models.RequestOverridesFromContext(ctx); ok && overrides.ReadConcern != "" {
+			requestReadConcern = overrides.ReadConcern
+		}
+		return invoker(metadata.AppendToOutgoingContext(ctx, "read-concern", string(requestReadConcern)), method, req, reply, cc, opts...)
 	}
 }
 
-func AddStreamHeaderInterceptor(authToken string) func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+// AddStreamHeaderInterceptor returns a stream interceptor that authorizes each new stream with the current auth
+// token of the credential provider. Streams that are already open keep the token they were opened with.
+func AddStreamHeaderInterceptor(credentialProvider auth.CredentialProvider) func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
 	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
-		return streamer(metadata.AppendToOutgoingContext(ctx, "authorization", authToken), desc, cc, method)
+		return streamer(metadata.AppendToOutgoingContext(ctx, "authorization", credentialProvider.GetAuthToken()), desc, cc, method)
 	}
 }
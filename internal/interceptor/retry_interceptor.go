@@ -2,9 +2,11 @@ package interceptor
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/momentohq/client-sdk-go/config/retry"
+	"github.com/momentohq/client-sdk-go/internal/models"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -16,6 +18,12 @@ func AddUnaryRetryInterceptor(s retry.Strategy, onRequest func(context.Context,
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 		attempt := 1
 
+		// A retry strategy passed with the request takes precedence over the configured one.
+		s := s
+		if overrides, ok := models.RequestOverridesFromContext(ctx); ok && overrides.RetryStrategy != nil {
+			s = overrides.RetryStrategy
+		}
+
 		// Make note of the overall deadline using the context.
 		// If for some reason the context has no deadline, use the client timeout.
 		var overallDeadline time.Time
@@ -60,10 +68,14 @@ func AddUnaryRetryInterceptor(s retry.Strategy, onRequest func(context.Context,
 				retryCtx = metadata.NewOutgoingContext(ctxWithRetryDeadline, md)
 			}
 
-			// Execute api call
-			lastErr := invoker(retryCtx, method, req, reply, cc, opts...)
+			// Execute api call, capturing the trailer for any retry-after hint
+			var trailer metadata.MD
+			lastErr := invoker(retryCtx, method, req, reply, cc, append(opts, grpc.Trailer(&trailer))...)
 			if lastErr == nil {
 				// Success no error returned stop interceptor
+				if successAwareStrategy, ok := s.(retry.SuccessAwareRetryStrategy); ok {
+					successAwareStrategy.OnSuccess(method)
+				}
 				return nil
 			}
 
@@ -73,27 +85,81 @@ func AddUnaryRetryInterceptor(s retry.Strategy, onRequest func(context.Context,
 			}
 
 			// Check retry eligibility based off last error received
-			retryBackoffTime := s.DetermineWhenToRetry(retry.StrategyProps{
+			props := retry.StrategyProps{
 				GrpcStatusCode:  status.Code(lastErr),
 				GrpcMethod:      method,
 				AttemptNumber:   attempt,
 				OverallDeadline: overallDeadline,
-			})
+				RetryAfter:      retryAfterFromTrailer(trailer),
+				OnRetry:         retry.OnRetryFromContext(ctx),
+			}
+			retryBackoffTime := s.DetermineWhenToRetry(props)
 
 			if retryBackoffTime == nil {
 				// If nil backoff time don't retry just return last error received
 				return lastErr
 			}
 
-			// Sleep for recommended time interval and increment attempts before trying again
-			if *retryBackoffTime > 0 {
-				time.Sleep(time.Duration(*retryBackoffTime) * time.Millisecond)
+			// Never retry sooner than the server asked, and don't wait for a retry that would start after the
+			// overall deadline.
+			delay := time.Duration(*retryBackoffTime) * time.Millisecond
+			if props.RetryAfter > delay {
+				delay = props.RetryAfter
+				if time.Now().Add(delay).After(overallDeadline) {
+					onRetryDropped(s, method)
+					return lastErr
+				}
+			}
+			if props.OnRetry != nil {
+				props.OnRetry(retry.RetryEvent{
+					GrpcStatusCode: props.GrpcStatusCode,
+					GrpcMethod:     method,
+					AttemptNumber:  attempt,
+					Delay:          delay,
+					RetryAfter:     props.RetryAfter,
+				})
+			}
+
+			// Wait for recommended time interval, unless the request is cancelled first, and increment attempts
+			// before trying again
+			if delay > 0 {
+				timer := time.NewTimer(delay)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					onRetryDropped(s, method)
+					return lastErr
+				}
 			}
 			attempt++
 		}
 	}
 }
 
+// onRetryDropped tells the strategy that a retry it allowed will not be made.
+func onRetryDropped(s retry.Strategy, method string) {
+	if dropAwareStrategy, ok := s.(retry.DropAwareRetryStrategy); ok {
+		dropAwareStrategy.OnRetryDropped(method)
+	}
+}
+
+// retryAfterFromTrailer returns the retry delay requested by the server in a retry-after-ms trailer, in
+// milliseconds, or a retry-after trailer, in seconds. It returns zero if neither is present or valid.
+func retryAfterFromTrailer(trailer metadata.MD) time.Duration {
+	if values := trailer.Get("retry-after-ms"); len(values) > 0 {
+		if millis, err := strconv.ParseFloat(values[0], 64); err == nil && millis > 0 {
+			return time.Duration(millis * float64(time.Millisecond))
+		}
+	}
+	if values := trailer.Get("retry-after"); len(values) > 0 {
+		if seconds, err := strconv.ParseFloat(values[0], 64); err == nil && seconds > 0 {
+			return time.Duration(seconds * float64(time.Second))
+		}
+	}
+	return 0
+}
+
 type wrappedStream struct {
 	grpc.ClientStream
 }
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/config/retry"
+)
+
+// RequestOverrides are per-request settings that take precedence over the client configuration. Zero
+// fields leave the configured setting in place.
+type RequestOverrides struct {
+	Timeout       time.Duration
+	RetryStrategy retry.Strategy
+	ReadConcern   config.ReadConcern
+	Metadata      map[string]string
+}
+
+type requestOverridesContextKey struct{}
+
+// WithRequestOverrides returns a copy of ctx that carries overrides, merged with any overrides already in ctx.
+func WithRequestOverrides(ctx context.Context, overrides RequestOverrides) context.Context {
+	if existing, ok := RequestOverridesFromContext(ctx); ok {
+		if overrides.Timeout == 0 {
+			overrides.Timeout = existing.Timeout
+		}
+		if overrides.RetryStrategy == nil {
+			overrides.RetryStrategy = existing.RetryStrategy
+		}
+		if overrides.ReadConcern == "" {
+			overrides.ReadConcern = existing.ReadConcern
+		}
+		metadata := make(map[string]string, len(existing.Metadata)+len(overrides.Metadata))
+		for key, value := range existing.Metadata {
+			metadata[key] = value
+		}
+		for key, value := range overrides.Metadata {
+			metadata[key] = value
+		}
+		overrides.Metadata = metadata
+	}
+	return context.WithValue(ctx, requestOverridesContextKey{}, overrides)
+}
+
+// RequestOverridesFromContext returns the overrides stored in ctx by WithRequestOverrides.
+func RequestOverridesFromContext(ctx context.Context) (RequestOverrides, bool) {
+	overrides, ok := ctx.Value(requestOverridesContextKey{}).(RequestOverrides)
+	return overrides, ok
+}
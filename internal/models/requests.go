@@ -10,12 +10,14 @@ import (
 	"github.com/momentohq/client-sdk-go/auth"
 	"github.com/momentohq/client-sdk-go/config"
 	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/internal/grpcmanagers/channelpool"
 )
 
 type ControlGrpcManagerRequest struct {
 	CredentialProvider auth.CredentialProvider
 	RetryStrategy      retry.Strategy
 	GrpcConfiguration  config.GrpcConfiguration
+	Channels           *channelpool.Group
 }
 
 type DataGrpcManagerRequest struct {
@@ -24,16 +26,19 @@ type DataGrpcManagerRequest struct {
 	ReadConcern        config.ReadConcern
 	GrpcConfiguration  config.GrpcConfiguration
 	Middleware         []middleware.Middleware
+	Channels           *channelpool.Group
 }
 
 type TokenGrpcManagerRequest struct {
 	CredentialProvider auth.CredentialProvider
 	GrpcConfiguration  config.GrpcConfiguration
+	Channels           *channelpool.Group
 }
 
 type AuthGrpcManagerRequest struct {
 	CredentialProvider auth.CredentialProvider
 	GrpcConfiguration  config.GrpcConfiguration
+	Channels           *channelpool.Group
 }
 
 type DataStreamGrpcManagerRequest struct {
@@ -44,21 +49,25 @@ type DataStreamGrpcManagerRequest struct {
 type TopicStreamGrpcManagerRequest struct {
 	CredentialProvider auth.CredentialProvider
 	GrpcConfiguration  config.TopicsGrpcConfiguration
+	Channels           *channelpool.Group
 }
 
 type PingGrpcManagerRequest struct {
 	CredentialProvider auth.CredentialProvider
 	GrpcConfiguration  config.GrpcConfiguration
+	Channels           *channelpool.Group
 }
 
 type LeaderboardGrpcManagerRequest struct {
 	CredentialProvider auth.CredentialProvider
 	GrpcConfiguration  config.GrpcConfiguration
+	Channels           *channelpool.Group
 }
 
 type StoreGrpcManagerRequest struct {
 	CredentialProvider auth.CredentialProvider
 	GrpcConfiguration  config.GrpcConfiguration
+	Channels           *channelpool.Group
 }
 
 type LocalDataGrpcManagerRequest struct {
@@ -80,18 +89,21 @@ type ListCachesRequest struct {
 type ControlClientRequest struct {
 	Configuration      config.Configuration
 	CredentialProvider auth.CredentialProvider
+	Channels           *channelpool.Group
 }
 
 type DataClientRequest struct {
 	Configuration      config.Configuration
 	CredentialProvider auth.CredentialProvider
 	DefaultTtl         time.Duration
+	Channels           *channelpool.Group
 }
 
 type PubSubClientRequest struct {
 	TopicsConfiguration config.TopicsConfiguration
 	CredentialProvider  auth.CredentialProvider
 	Log                 logger.MomentoLogger
+	ChannelPool         *channelpool.Pool
 }
 
 type TopicClientRequest struct {
@@ -101,28 +113,35 @@ type TopicClientRequest struct {
 
 type TokenClientRequest struct {
 	CredentialProvider auth.CredentialProvider
+	GrpcConfiguration  config.GrpcConfiguration
 	Log                logger.MomentoLogger
+	Channels           *channelpool.Group
 }
 
 type AuthClientRequest struct {
 	CredentialProvider auth.CredentialProvider
+	GrpcConfiguration  config.GrpcConfiguration
 	Log                logger.MomentoLogger
+	Channels           *channelpool.Group
 }
 
 type PingClientRequest struct {
 	Configuration      config.Configuration
 	CredentialProvider auth.CredentialProvider
+	Channels           *channelpool.Group
 }
 
 type LeaderboardClientRequest struct {
 	Configuration      config.LeaderboardConfiguration
 	CredentialProvider auth.CredentialProvider
+	Channels           *channelpool.Group
 }
 
 type StorageDataClientRequest struct {
 	CredentialProvider auth.CredentialProvider
 	Configuration      config.StorageConfiguration
 	Log                logger.MomentoLogger
+	Channels           *channelpool.Group
 }
 
 type CreateStoreRequest struct {
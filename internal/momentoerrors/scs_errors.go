@@ -76,6 +76,12 @@ const (
 	ConnectionError = "ConnectionError"
 	// ClientResourceExhausted occurs when a client resource (such as memory or number of concurrent grpc streams) is exhausted.
 	ClientResourceExhaustedError = "ClientResourceExhaustedError"
+	// EncryptionIntegrityError occurs when an encrypted value could not be authenticated.
+	EncryptionIntegrityError = "EncryptionIntegrityError"
+	// CircuitOpenError occurs when a request is rejected because the client's circuit breaker is open.
+	CircuitOpenError = "CircuitOpenError"
+	// ClientClosedError occurs when a request is made on a client that is shutting down or closed.
+	ClientClosedError = "ClientClosedError"
 )
 
 // ConvertSvcErr converts gRPC error to MomentoSvcErr.
@@ -25,11 +25,12 @@ func NewScsControlClient(request *models.ControlClientRequest) (*ScsControlClien
 		CredentialProvider: request.CredentialProvider,
 		RetryStrategy:      request.Configuration.GetRetryStrategy(),
 		GrpcConfiguration:  request.Configuration.GetTransportStrategy().GetGrpcConfig(),
+		Channels:           request.Channels,
 	})
 	if err != nil {
 		return nil, momentoerrors.ConvertSvcErr(err)
 	}
-	return &ScsControlClient{grpcManager: controlManager, grpcClient: pb.NewScsControlClient(controlManager.Conn)}, nil
+	return &ScsControlClient{grpcManager: controlManager, grpcClient: pb.NewScsControlClient(controlManager.Channel)}, nil
 }
 
 func (client *ScsControlClient) Close() momentoerrors.MomentoSvcErr {
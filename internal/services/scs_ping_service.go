@@ -21,6 +21,7 @@ func NewScsPingClient(request *models.PingClientRequest) (*ScsPingClient, moment
 	pingManager, err := grpcmanagers.NewPingGrpcManager(&models.PingGrpcManagerRequest{
 		CredentialProvider: request.CredentialProvider,
 		GrpcConfiguration:  request.Configuration.GetTransportStrategy().GetGrpcConfig(),
+		Channels:           request.Channels,
 	})
 	if err != nil {
 		return nil, err
@@ -28,7 +29,7 @@ func NewScsPingClient(request *models.PingClientRequest) (*ScsPingClient, moment
 	return &ScsPingClient{
 		requestTimeout: request.Configuration.GetClientSideTimeout(),
 		grpcManager:    pingManager,
-		grpcClient:     pb.NewPingClient(pingManager.Conn),
+		grpcClient:     pb.NewPingClient(pingManager.Channel),
 	}, nil
 }
 
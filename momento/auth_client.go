@@ -8,6 +8,7 @@ import (
 	"github.com/momentohq/client-sdk-go/config"
 	"github.com/momentohq/client-sdk-go/config/logger"
 	"github.com/momentohq/client-sdk-go/internal"
+	"github.com/momentohq/client-sdk-go/internal/grpcmanagers/channelpool"
 	"github.com/momentohq/client-sdk-go/internal/models"
 	"github.com/momentohq/client-sdk-go/internal/momentoerrors"
 	responses "github.com/momentohq/client-sdk-go/responses/auth"
@@ -27,18 +28,27 @@ type defaultAuthClient struct {
 	tokenClient        *tokenClient
 	authClient         *authClient
 	log                logger.MomentoLogger
+	operations         *operationTracker
 }
 
 // NewAuthClient returns a new AuthClient with provided configuration and credential provider arguments.
 func NewAuthClient(authConfiguration config.AuthConfiguration, credentialProvider auth.CredentialProvider) (AuthClient, error) {
+	return newAuthClientWithChannels(authConfiguration, credentialProvider, nil)
+}
+
+// newAuthClientWithChannels creates an auth client whose connections are leased from channels, if it is not nil.
+func newAuthClientWithChannels(authConfiguration config.AuthConfiguration, credentialProvider auth.CredentialProvider, channels *channelpool.Pool) (AuthClient, error) {
 	client := &defaultAuthClient{
 		credentialProvider: credentialProvider,
 		log:                authConfiguration.GetLoggerFactory().GetLogger("auth-client"),
+		operations:         newOperationTracker("auth client"),
 	}
 
 	tokenClient, err := newTokenClient(&models.TokenClientRequest{
 		CredentialProvider: credentialProvider,
+		GrpcConfiguration:  authConfiguration.GetTransportStrategy().GetGrpcConfig(),
 		Log:                authConfiguration.GetLoggerFactory().GetLogger("token-client"),
+		Channels:           channels.NewGroup(),
 	})
 	if err != nil {
 		return nil, convertMomentoSvcErrorToCustomerError(momentoerrors.ConvertSvcErr(err))
@@ -46,7 +56,9 @@ func NewAuthClient(authConfiguration config.AuthConfiguration, credentialProvide
 
 	authClient, err := newAuthClient(&models.AuthClientRequest{
 		CredentialProvider: credentialProvider,
+		GrpcConfiguration:  authConfiguration.GetTransportStrategy().GetGrpcConfig(),
 		Log:                authConfiguration.GetLoggerFactory().GetLogger("auth-client"),
+		Channels:           channels.NewGroup(),
 	})
 	if err != nil {
 		return nil, convertMomentoSvcErrorToCustomerError(momentoerrors.ConvertSvcErr(err))
@@ -59,6 +71,11 @@ func NewAuthClient(authConfiguration config.AuthConfiguration, credentialProvide
 }
 
 func (c defaultAuthClient) GenerateDisposableToken(ctx context.Context, request *GenerateDisposableTokenRequest) (responses.GenerateDisposableTokenResponse, error) {
+	end, trackErr := c.operations.begin("GenerateDisposableToken")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	if err := utils.ValidateDisposableTokenExpiry(request.ExpiresIn); err != nil {
 		return nil, convertMomentoSvcErrorToCustomerError(err)
 	}
@@ -75,6 +92,11 @@ func (c defaultAuthClient) GenerateDisposableToken(ctx context.Context, request
 }
 
 func (c defaultAuthClient) GenerateApiKey(ctx context.Context, request *GenerateApiKeyRequest) (responses.GenerateApiKeyResponse, error) {
+	end, trackErr := c.operations.begin("GenerateApiKey")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	if err := utils.ValidateApiKeyExpiry(request.ExpiresIn); err != nil {
 		return nil, convertMomentoSvcErrorToCustomerError(err)
 	}
@@ -91,6 +113,11 @@ func (c defaultAuthClient) GenerateApiKey(ctx context.Context, request *Generate
 }
 
 func (c defaultAuthClient) RefreshApiKey(ctx context.Context, request *RefreshApiKeyRequest) (responses.RefreshApiKeyResponse, error) {
+	end, trackErr := c.operations.begin("RefreshApiKey")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	requestMetadata := internal.CreateMetadata(ctx, internal.Auth)
 
 	refreshResp, err := c.authClient.RefreshApiKey(requestMetadata, request)
@@ -102,6 +129,17 @@ func (c defaultAuthClient) RefreshApiKey(ctx context.Context, request *RefreshAp
 	return refreshResp, nil
 }
 
+func (c defaultAuthClient) Shutdown(ctx context.Context) (ShutdownSummary, error) {
+	c.log.Info("Shutting down auth client")
+	summary, err := c.operations.shutdown(ctx)
+	if err != nil {
+		c.log.Warn("Auth client shut down before its requests finished: %s", summary)
+	}
+	c.Close()
+	return summary, err
+}
+
 func (c defaultAuthClient) Close() {
+	c.operations.reject()
 	defer c.tokenClient.close()
 }
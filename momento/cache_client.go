@@ -5,22 +5,24 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"sync/atomic"
 	"time"
 
 	"github.com/momentohq/client-sdk-go/config/logger"
 
+	"github.com/momentohq/client-sdk-go/internal/grpcmanagers/channelpool"
 	"github.com/momentohq/client-sdk-go/internal/models"
 	"github.com/momentohq/client-sdk-go/internal/momentoerrors"
 	"github.com/momentohq/client-sdk-go/internal/services"
 
 	"github.com/momentohq/client-sdk-go/auth"
 	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/config/circuitbreaker"
+	"github.com/momentohq/client-sdk-go/config/health"
+	"github.com/momentohq/client-sdk-go/config/hedging"
+	"github.com/momentohq/client-sdk-go/config/retry"
 	"github.com/momentohq/client-sdk-go/responses"
 )
 
-var dataClientCount atomic.Uint64
-
 type CacheClient interface {
 	Logger() logger.MomentoLogger
 
@@ -177,14 +179,38 @@ type CacheClient interface {
 	Close()
 }
 
+// HealthReporter is implemented by cache clients that report their health, which every cache client of
+// this package does. It is kept out of CacheClient so that its other implementations, such as mocks, need
+// not implement it; use Health to get the health of any cache client.
+type HealthReporter interface {
+	// Health returns the health of the client's gRPC channels as last checked by the health monitor. The
+	// state is unknown if the health monitor is not configured.
+	Health() health.Health
+}
+
+// Health returns the health of the client if it is a HealthReporter, or else an unknown state.
+func Health(client CacheClient) health.Health {
+	if reporter, ok := client.(HealthReporter); ok {
+		return reporter.Health()
+	}
+	return health.Health{State: health.StateUnknown}
+}
+
 // defaultScsClient represents all information needed for momento client to enable cache control and data operations.
 type defaultScsClient struct {
 	defaultCache       string
 	logger             logger.MomentoLogger
 	credentialProvider auth.CredentialProvider
 	controlClient      *services.ScsControlClient
-	dataClients        []*scsDataClient
+	dataClients        *dataClientPool
 	pingClient         *services.ScsPingClient
+	// hedger decides when reads are hedged. Nil if hedging is disabled.
+	hedger *hedging.Hedger
+	// getBatcher batches concurrent Get requests. Nil if automatic batching is disabled.
+	getBatcher *getBatcher
+	operations *operationTracker
+	// healthMonitor checks the data clients in the background. Nil if it is disabled.
+	healthMonitor *health.Monitor
 }
 
 type CacheClientProps struct {
@@ -215,7 +241,9 @@ func validateEagerConnectionTimeout(timeout time.Duration) momentoerrors.Momento
 	return nil
 }
 
-func commonCacheClient(props CacheClientProps) (CacheClient, error) {
+// commonCacheClient creates a cache client. Its connections are leased from channels, if it is not nil, and
+// otherwise dialed for the client alone.
+func commonCacheClient(props CacheClientProps, channels *channelpool.Pool) (CacheClient, error) {
 	logger := props.Configuration.GetLoggerFactory().GetLogger("CacheClient")
 	logger.Info("Creating cache client with settings: %s", props)
 
@@ -225,11 +253,13 @@ func commonCacheClient(props CacheClientProps) (CacheClient, error) {
 	client := &defaultScsClient{
 		logger:             logger,
 		credentialProvider: props.CredentialProvider,
+		operations:         newOperationTracker("cache client"),
 	}
 
 	controlClient, err := services.NewScsControlClient(&models.ControlClientRequest{
 		CredentialProvider: props.CredentialProvider,
 		Configuration:      props.Configuration,
+		Channels:           channels.NewGroup(),
 	})
 	if err != nil {
 		return nil, convertMomentoSvcErrorToCustomerError(momentoerrors.ConvertSvcErr(err))
@@ -252,14 +282,37 @@ func commonCacheClient(props CacheClientProps) (CacheClient, error) {
 	if numChannels < 1 {
 		numChannels = 1
 	}
-	dataClients := make([]*scsDataClient, 0)
 
-	for i := 0; uint32(i) < numChannels; i++ {
+	// The circuit breaker is created per client, so clients sharing a configuration track their circuits independently.
+	var breaker *circuitbreaker.CircuitBreaker
+	if configuredProps := props.Configuration.GetCircuitBreakerProps(); configuredProps != nil {
+		breakerProps := *configuredProps
+		if breakerProps.Logger == nil {
+			breakerProps.Logger = props.Configuration.GetLoggerFactory().GetLogger("circuit-breaker")
+		}
+		breaker = circuitbreaker.New(breakerProps)
+	}
+
+	// The data clients lease their connections as one group, so that each has a connection of its own.
+	dataChannels := channels.NewGroup()
+	newDataClient := func() (*scsDataClient, momentoerrors.MomentoSvcErr) {
 		dataClient, err := newScsDataClient(&models.DataClientRequest{
 			CredentialProvider: props.CredentialProvider,
 			Configuration:      props.Configuration,
 			DefaultTtl:         props.DefaultTtl,
+			Channels:           dataChannels,
 		}, props.EagerConnectTimeout)
+		if err != nil {
+			return nil, err
+		}
+		dataClient.circuitBreaker = breaker
+		dataClient.operations = client.operations
+		return dataClient, nil
+	}
+
+	dataClients := make([]*scsDataClient, 0)
+	for i := 0; uint32(i) < numChannels; i++ {
+		dataClient, err := newDataClient()
 		if err != nil {
 			return nil, convertMomentoSvcErrorToCustomerError(momentoerrors.ConvertSvcErr(err))
 		}
@@ -277,13 +330,44 @@ func commonCacheClient(props CacheClientProps) (CacheClient, error) {
 	pingClient, err := services.NewScsPingClient(&models.PingClientRequest{
 		Configuration:      props.Configuration,
 		CredentialProvider: props.CredentialProvider,
+		Channels:           channels.NewGroup(),
 	})
 	if err != nil {
 		return nil, convertMomentoSvcErrorToCustomerError(momentoerrors.ConvertSvcErr(err))
 	}
 
 	client.defaultCache = props.CacheName
-	client.dataClients = dataClients
+	client.dataClients = newDataClientPool(
+		dataClients,
+		props.Configuration.GetChannelSelectionProps(),
+		newDataClient,
+		props.Configuration.GetLoggerFactory().GetLogger("data-client-pool"),
+	)
+	if hedgingProps := props.Configuration.GetHedgingProps(); hedgingProps != nil {
+		if len(dataClients) < 2 {
+			logger.Warn("Hedged reads are disabled because the client has only one gRPC channel")
+		} else {
+			client.hedger = hedging.New(*hedgingProps)
+		}
+	}
+	if batchingProps := props.Configuration.GetAutoBatchingProps(); batchingProps != nil {
+		if conflict := middlewareBatchingConflict(*batchingProps, props.Configuration.GetMiddleware()); conflict != "" {
+			logger.Warn("Automatic batching of Gets is disabled because %s", conflict)
+		} else {
+			client.getBatcher = newGetBatcher(*batchingProps, func(ctx context.Context, r *GetBatchRequest) (interface{}, error) {
+				// The batched Gets are tracked, so the batch is sent even while they are drained on shutdown.
+				return client.makeReadRequest(withTrackedOperation(ctx), r, getBatchMethod)
+			})
+		}
+	}
+	if healthProps := props.Configuration.GetHealthMonitorProps(); healthProps != nil {
+		monitorProps := *healthProps
+		if monitorProps.Logger == nil {
+			monitorProps.Logger = props.Configuration.GetLoggerFactory().GetLogger("health-monitor")
+		}
+		client.healthMonitor = health.NewMonitor(monitorProps, client.dataClients.channels)
+		client.healthMonitor.Start()
+	}
 	client.controlClient = controlClient
 	client.pingClient = pingClient
 
@@ -298,7 +382,7 @@ func NewCacheClient(configuration config.Configuration, credentialProvider auth.
 		DefaultTtl:          defaultTtl,
 		EagerConnectTimeout: 30 * time.Second,
 	}
-	return commonCacheClient(props)
+	return commonCacheClient(props, nil)
 }
 
 // NewCacheClientWithEagerConnectTimeout returns a new CacheClient with
@@ -312,7 +396,7 @@ func NewCacheClientWithEagerConnectTimeout(configuration config.Configuration, c
 		DefaultTtl:          defaultTtl,
 		EagerConnectTimeout: eagerConnectTimeout,
 	}
-	return commonCacheClient(props)
+	return commonCacheClient(props, nil)
 }
 
 func NewCacheClientWithDefaultCache(configuration config.Configuration, credentialProvider auth.CredentialProvider, defaultTtl time.Duration, cacheName string) (CacheClient, error) {
@@ -323,7 +407,7 @@ func NewCacheClientWithDefaultCache(configuration config.Configuration, credenti
 		DefaultTtl:          defaultTtl,
 		EagerConnectTimeout: 30 * time.Second,
 	}
-	return commonCacheClient(props)
+	return commonCacheClient(props, nil)
 }
 
 func (c defaultScsClient) Logger() logger.MomentoLogger {
@@ -338,9 +422,7 @@ func (c defaultScsClient) getCacheNameForRequest(request hasCacheName) string {
 }
 
 func (c defaultScsClient) getNextDataClient() *scsDataClient {
-	nextClientIndex := dataClientCount.Add(1)
-	dataClient := c.dataClients[nextClientIndex%uint64(len(c.dataClients))]
-	return dataClient
+	return c.dataClients.next()
 }
 
 func (c defaultScsClient) CreateCache(ctx context.Context, request *CreateCacheRequest) (responses.CreateCacheResponse, error) {
@@ -348,6 +430,11 @@ func (c defaultScsClient) CreateCache(ctx context.Context, request *CreateCacheR
 	if err := isCacheNameValid(request.CacheName); err != nil {
 		return nil, err
 	}
+	end, trackErr := c.operations.begin("CreateCache")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	c.logger.Info("Creating cache with name: %s", request.CacheName)
 	err := c.controlClient.CreateCache(ctx, &models.CreateCacheRequest{
 		CacheName: request.CacheName,
@@ -369,6 +456,11 @@ func (c defaultScsClient) DeleteCache(ctx context.Context, request *DeleteCacheR
 	if err := isCacheNameValid(request.CacheName); err != nil {
 		return nil, err
 	}
+	end, trackErr := c.operations.begin("DeleteCache")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	c.logger.Info("Deleting cache with name: %s", request.CacheName)
 	err := c.controlClient.DeleteCache(ctx, &models.DeleteCacheRequest{
 		CacheName: request.CacheName,
@@ -386,6 +478,11 @@ func (c defaultScsClient) DeleteCache(ctx context.Context, request *DeleteCacheR
 }
 
 func (c defaultScsClient) ListCaches(ctx context.Context, request *ListCachesRequest) (responses.ListCachesResponse, error) {
+	end, trackErr := c.operations.begin("ListCaches")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	rsp, err := c.controlClient.ListCaches(ctx, &models.ListCachesRequest{})
 	if err != nil {
 		return nil, convertMomentoSvcErrorToCustomerError(err)
@@ -530,7 +627,25 @@ func (c defaultScsClient) SetBatch(ctx context.Context, r *SetBatchRequest) (res
 
 func (c defaultScsClient) Get(ctx context.Context, r *GetRequest) (responses.GetResponse, error) {
 	r.CacheName = c.getCacheNameForRequest(r)
-	resp, err := c.getNextDataClient().makeRequest(ctx, r)
+	// A batch is sent with the context of its first request, so requests with options or a retry observer
+	// of their own are sent on their own.
+	_, hasOverrides := models.RequestOverridesFromContext(ctx)
+	if c.getBatcher != nil && !hasOverrides && retry.OnRetryFromContext(ctx) == nil {
+		// Invalid requests are sent on their own so that they fail with the usual error, and do not fail
+		// the batch they would have been part of.
+		_, cacheNameErr := prepareCacheName(r)
+		key, keyErr := prepareKey(r)
+		if cacheNameErr == nil && keyErr == nil {
+			// The Get is tracked while it waits for its batch to be sent.
+			end, trackErr := c.operations.begin(r.requestName())
+			if trackErr != nil {
+				return nil, trackErr
+			}
+			defer end()
+			return c.getBatcher.get(ctx, r, key)
+		}
+	}
+	resp, err := c.makeReadRequest(ctx, r, getMethod)
 	if err != nil {
 		return nil, err
 	}
@@ -548,7 +663,7 @@ func (c defaultScsClient) GetWithHash(ctx context.Context, r *GetWithHashRequest
 
 func (c defaultScsClient) GetBatch(ctx context.Context, r *GetBatchRequest) (responses.GetBatchResponse, error) {
 	r.CacheName = c.getCacheNameForRequest(r)
-	resp, err := c.getNextDataClient().makeRequest(ctx, r)
+	resp, err := c.makeReadRequest(ctx, r, getBatchMethod)
 	if err != nil {
 		return nil, err
 	}
@@ -963,7 +1078,7 @@ func (c defaultScsClient) DictionaryGetField(ctx context.Context, r *DictionaryG
 		DictionaryName: r.DictionaryName,
 		Fields:         []Value{r.Field},
 	}
-	response, err := c.getNextDataClient().makeRequest(ctx, newRequest)
+	response, err := c.makeReadRequest(ctx, newRequest, dictionaryGetMethod)
 	if err != nil {
 		return nil, err
 	}
@@ -1060,18 +1175,42 @@ func (c defaultScsClient) DecreaseTtl(ctx context.Context, r *DecreaseTtlRequest
 }
 
 func (c defaultScsClient) Ping(ctx context.Context) (responses.PingResponse, error) {
+	end, trackErr := c.operations.begin("Ping")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	if err := c.pingClient.Ping(ctx); err != nil {
 		return nil, err
 	}
 	return &responses.PingSuccess{}, nil
 }
 
+func (c defaultScsClient) Health() health.Health {
+	if c.healthMonitor == nil {
+		return health.Health{State: health.StateUnknown}
+	}
+	return c.healthMonitor.Health()
+}
+
+func (c defaultScsClient) Shutdown(ctx context.Context) (ShutdownSummary, error) {
+	c.logger.Info("Shutting down cache client")
+	summary, err := c.operations.shutdown(ctx)
+	if err != nil {
+		c.logger.Warn("Cache client shut down before its requests finished: %s", summary)
+	}
+	c.Close()
+	return summary, err
+}
+
 func (c defaultScsClient) Close() {
+	c.operations.reject()
+	if c.healthMonitor != nil {
+		c.healthMonitor.Close()
+	}
 	defer c.pingClient.Close()
 	defer c.controlClient.Close()
-	for _, client := range c.dataClients {
-		defer client.Close()
-	}
+	defer c.dataClients.close()
 }
 
 func convertMomentoSvcErrorToCustomerError(e momentoerrors.MomentoSvcErr) MomentoError {
@@ -0,0 +1,207 @@
+package momento
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ShutdownSummary describes what a client left unfinished when it shut down.
+type ShutdownSummary struct {
+	// AbandonedOperations counts the operations still in flight when the shutdown context was done, by
+	// request name. It is empty if every operation finished.
+	AbandonedOperations map[string]int
+	// ClosedSubscriptions is the number of open topic subscriptions the shutdown closed.
+	ClosedSubscriptions int
+}
+
+// Abandoned returns the total number of operations abandoned by the shutdown.
+func (s ShutdownSummary) Abandoned() int {
+	total := 0
+	for _, count := range s.AbandonedOperations {
+		total += count
+	}
+	return total
+}
+
+func (s ShutdownSummary) String() string {
+	names := make([]string, 0, len(s.AbandonedOperations))
+	for name := range s.AbandonedOperations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	abandoned := make([]string, 0, len(names))
+	for _, name := range names {
+		abandoned = append(abandoned, fmt.Sprintf("%s: %d", name, s.AbandonedOperations[name]))
+	}
+	return fmt.Sprintf(
+		"ShutdownSummary{AbandonedOperations: {%s}, ClosedSubscriptions: %d}",
+		strings.Join(abandoned, ", "), s.ClosedSubscriptions,
+	)
+}
+
+// GracefulShutdowner is implemented by clients that can shut down gracefully, which every client of this
+// package does. It is kept out of the client interfaces so that their other implementations, such as
+// mocks, need not implement it; use Shutdown to shut down any client.
+type GracefulShutdowner interface {
+	// Shutdown closes the client gracefully. New requests fail with a ClientClosedError, and requests in
+	// flight may finish until ctx is done. The client is then closed, abandoning the requests still in
+	// flight; the summary counts them and the error is that of ctx.
+	Shutdown(ctx context.Context) (ShutdownSummary, error)
+}
+
+// Shutdown shuts the client down gracefully if it is a GracefulShutdowner, or else closes it.
+func Shutdown(ctx context.Context, client interface{ Close() }) (ShutdownSummary, error) {
+	if shutdowner, ok := client.(GracefulShutdowner); ok {
+		return shutdowner.Shutdown(ctx)
+	}
+	client.Close()
+	return ShutdownSummary{AbandonedOperations: map[string]int{}}, nil
+}
+
+// operationTracker tracks a client's operations in flight and the resources it must release, so that
+// the client can reject new operations and wait for the ones in flight when it shuts down.
+type operationTracker struct {
+	clientName string
+
+	mutex    sync.Mutex
+	closing  bool
+	inFlight map[string]int
+	total    int
+	drained  chan struct{}
+	nextId   uint64
+	closers  map[uint64]func()
+}
+
+func newOperationTracker(clientName string) *operationTracker {
+	return &operationTracker{
+		clientName: clientName,
+		inFlight:   make(map[string]int),
+		closers:    make(map[uint64]func()),
+	}
+}
+
+// begin starts an operation. It fails with a ClientClosedError if the client is shutting down. Otherwise
+// the caller must call the returned function when the operation finishes.
+func (t *operationTracker) begin(requestName string) (func(), MomentoError) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.closing {
+		return nil, NewMomentoError(
+			ClientClosedError,
+			fmt.Sprintf("%s is shutting down or closed; the %s request was not sent", t.clientName, requestName),
+			nil,
+		)
+	}
+	t.inFlight[requestName]++
+	t.total++
+	var once sync.Once
+	return func() { once.Do(func() { t.end(requestName) }) }, nil
+}
+
+// extend starts an operation on behalf of one in flight, such as a background write it leaves behind. It
+// succeeds even while shutting down, so that shutdown also waits for it.
+func (t *operationTracker) extend(requestName string) func() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.inFlight[requestName]++
+	t.total++
+	var once sync.Once
+	return func() { once.Do(func() { t.end(requestName) }) }
+}
+
+func (t *operationTracker) end(requestName string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.inFlight[requestName]--
+	if t.inFlight[requestName] == 0 {
+		delete(t.inFlight, requestName)
+	}
+	t.total--
+	if t.total == 0 && t.drained != nil {
+		close(t.drained)
+		t.drained = nil
+	}
+}
+
+// trackedOperationKey marks the context of a request sent on behalf of operations that are already tracked,
+// such as the GetBatch request of batched Gets, so that it is neither counted again nor rejected while they
+// are drained.
+type trackedOperationKey struct{}
+
+func withTrackedOperation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, trackedOperationKey{}, true)
+}
+
+func isTrackedOperation(ctx context.Context) bool {
+	tracked, _ := ctx.Value(trackedOperationKey{}).(bool)
+	return tracked
+}
+
+// track registers a resource that shutdown must close, such as a topic subscription. The returned
+// function unregisters it, and should be called when the resource is closed by other means.
+func (t *operationTracker) track(closeResource func()) func() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	id := t.nextId
+	t.nextId++
+	t.closers[id] = closeResource
+	return func() {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		delete(t.closers, id)
+	}
+}
+
+// reject makes begin fail from now on.
+func (t *operationTracker) reject() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.closing = true
+}
+
+// shutdown rejects new operations, closes the tracked resources, and waits until the operations in
+// flight finish or ctx is done. It returns the error of ctx if operations were abandoned.
+func (t *operationTracker) shutdown(ctx context.Context) (ShutdownSummary, error) {
+	t.mutex.Lock()
+	t.closing = true
+	closers := make([]func(), 0, len(t.closers))
+	for _, closeResource := range t.closers {
+		closers = append(closers, closeResource)
+	}
+	t.closers = make(map[uint64]func())
+	var drained chan struct{}
+	if t.total > 0 {
+		if t.drained == nil {
+			t.drained = make(chan struct{})
+		}
+		drained = t.drained
+	}
+	t.mutex.Unlock()
+
+	summary := ShutdownSummary{AbandonedOperations: map[string]int{}, ClosedSubscriptions: len(closers)}
+	for _, closeResource := range closers {
+		closeResource()
+	}
+	if drained == nil {
+		return summary, nil
+	}
+	select {
+	case <-drained:
+		return summary, nil
+	case <-ctx.Done():
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for name, count := range t.inFlight {
+		summary.AbandonedOperations[name] = count
+	}
+	if len(summary.AbandonedOperations) == 0 {
+		// The operations finished as ctx was done.
+		return summary, nil
+	}
+	return summary, ctx.Err()
+}
@@ -0,0 +1,181 @@
+package momento_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/config/batching"
+	pb "github.com/momentohq/client-sdk-go/internal/protos"
+	. "github.com/momentohq/client-sdk-go/momento"
+	helpers "github.com/momentohq/client-sdk-go/momento/test_helpers"
+	"github.com/momentohq/client-sdk-go/responses"
+)
+
+// closeOnlyClient is a client that cannot shut down gracefully, such as a mock.
+type closeOnlyClient struct {
+	closed bool
+}
+
+func (c *closeOnlyClient) Close() {
+	c.closed = true
+}
+
+var _ = Describe("client-shutdown", func() {
+	It("closes clients that cannot shut down gracefully", func() {
+		client := &closeOnlyClient{}
+		summary, err := Shutdown(context.Background(), client)
+		Expect(err).To(BeNil())
+		Expect(summary.Abandoned()).To(Equal(0))
+		Expect(client.closed).To(BeTrue())
+	})
+
+	It("drains a cache client and rejects new requests", Label(CACHE_SERVICE_LABEL), func() {
+		client, err := NewCacheClient(sharedContext.Configuration, sharedContext.CredentialProvider, sharedContext.DefaultTtl)
+		Expect(err).To(BeNil())
+
+		key := String(uuid.NewString())
+		Expect(client.Set(sharedContext.Ctx, &SetRequest{
+			CacheName: sharedContext.CacheName,
+			Key:       key,
+			Value:     String("value"),
+		})).To(BeAssignableToTypeOf(&responses.SetSuccess{}))
+
+		ctx, cancel := context.WithTimeout(sharedContext.Ctx, 5*time.Second)
+		defer cancel()
+		summary, err := Shutdown(ctx, client)
+		Expect(err).To(BeNil())
+		Expect(summary.Abandoned()).To(Equal(0))
+
+		Expect(
+			client.Get(sharedContext.Ctx, &GetRequest{CacheName: sharedContext.CacheName, Key: key}),
+		).Error().To(HaveMomentoErrorCode(ClientClosedError))
+		Expect(client.Ping(sharedContext.Ctx)).Error().To(HaveMomentoErrorCode(ClientClosedError))
+
+		summary, err = Shutdown(ctx, client)
+		Expect(err).To(BeNil())
+		Expect(summary.Abandoned()).To(Equal(0))
+	})
+
+	It("closes the subscriptions of a topic client", Label(TOPICS_SERVICE_LABEL), func() {
+		client, err := NewTopicClient(sharedContext.TopicConfiguration, sharedContext.CredentialProvider)
+		Expect(err).To(BeNil())
+
+		topicName := fmt.Sprintf("golang-shutdown-%s", uuid.NewString())
+		sub, err := client.Subscribe(sharedContext.Ctx, &TopicSubscribeRequest{
+			CacheName: sharedContext.CacheName,
+			TopicName: topicName,
+		})
+		Expect(err).To(BeNil())
+		closed, err := client.Subscribe(sharedContext.Ctx, &TopicSubscribeRequest{
+			CacheName: sharedContext.CacheName,
+			TopicName: topicName,
+		})
+		Expect(err).To(BeNil())
+		closed.Close()
+
+		ctx, cancel := context.WithTimeout(sharedContext.Ctx, 5*time.Second)
+		defer cancel()
+		summary, err := Shutdown(ctx, client)
+		Expect(err).To(BeNil())
+		Expect(summary.ClosedSubscriptions).To(Equal(1))
+
+		Expect(sub.Event(sharedContext.Ctx)).Error().To(MatchError(context.Canceled))
+		Expect(client.Publish(sharedContext.Ctx, &TopicPublishRequest{
+			CacheName: sharedContext.CacheName,
+			TopicName: topicName,
+			Value:     String("value"),
+		})).Error().To(HaveMomentoErrorCode(ClientClosedError))
+	})
+
+	Describe("with batched Gets", func() {
+		const window = 200 * time.Millisecond
+
+		var (
+			client CacheClient
+			// onGetBatch answers the server's GetBatch requests.
+			onGetBatch func(ctx context.Context, req *pb.XGetBatchRequest) ([]*pb.XGetResponse, error)
+		)
+
+		// getConcurrently starts a Get request for each key and returns the channel their results are sent on.
+		getConcurrently := func(keys ...string) chan error {
+			results := make(chan error, len(keys))
+			for _, key := range keys {
+				go func(key string) {
+					defer GinkgoRecover()
+					resp, err := client.Get(context.Background(), &GetRequest{CacheName: "cache", Key: String(key)})
+					if err == nil {
+						Expect(resp.(*responses.GetHit).ValueString()).To(Equal(key))
+					}
+					results <- err
+				}(key)
+			}
+			return results
+		}
+
+		BeforeEach(func() {
+			onGetBatch = func(_ context.Context, req *pb.XGetBatchRequest) ([]*pb.XGetResponse, error) {
+				results := make([]*pb.XGetResponse, 0, len(req.Items))
+				for _, item := range req.Items {
+					results = append(results, &pb.XGetResponse{Result: pb.ECacheResult_Hit, CacheBody: item.CacheKey})
+				}
+				return results, nil
+			}
+			server, err := helpers.NewFakeCacheServer(helpers.FakeCacheServerProps{
+				OnGetBatch: func(ctx context.Context, req *pb.XGetBatchRequest) ([]*pb.XGetResponse, error) {
+					return onGetBatch(ctx, req)
+				},
+			})
+			Expect(err).To(BeNil())
+			DeferCleanup(server.Close)
+
+			credentialProvider, err := server.CredentialProvider()
+			Expect(err).To(BeNil())
+			client, err = NewCacheClient(
+				config.LaptopLatest().WithAutoBatching(batching.Props{Window: window}),
+				credentialProvider,
+				time.Minute,
+			)
+			Expect(err).To(BeNil())
+			DeferCleanup(client.Close)
+		})
+
+		It("sends the batches of the Gets it drains", func() {
+			results := getConcurrently("a", "b")
+			// Shut down while the Gets wait for their batch to be sent.
+			time.Sleep(window / 4)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			summary, err := Shutdown(ctx, client)
+			Expect(err).To(BeNil())
+			Expect(summary.Abandoned()).To(Equal(0))
+			Expect(<-results).To(BeNil())
+			Expect(<-results).To(BeNil())
+		})
+
+		It("counts batched Gets once", func() {
+			received := make(chan struct{})
+			release := make(chan struct{})
+			defer close(release)
+			onGetBatch = func(context.Context, *pb.XGetBatchRequest) ([]*pb.XGetResponse, error) {
+				close(received)
+				<-release
+				return nil, nil
+			}
+			getConcurrently("a", "b")
+			Eventually(received, 5*time.Second).Should(BeClosed())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			summary, err := Shutdown(ctx, client)
+			Expect(err).NotTo(BeNil())
+			Expect(summary.AbandonedOperations).To(Equal(map[string]int{"Get": 2}))
+		})
+	})
+})
@@ -0,0 +1,292 @@
+package momento
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config/channelselection"
+	"github.com/momentohq/client-sdk-go/config/health"
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/internal/momentoerrors"
+)
+
+// retiredClientCheckInterval is how often a retired client is checked for requests still in flight.
+const retiredClientCheckInterval = 100 * time.Millisecond
+
+// channelLoad tracks the requests sent on a data client's channel.
+type channelLoad struct {
+	inFlight atomic.Int64
+	// waitingSince is when the oldest request started since a request on the channel last completed, in
+	// Unix nanoseconds, or 0 if no request has started since.
+	waitingSince atomic.Int64
+	// timedOut is set when a request times out, and cleared when a request completes.
+	timedOut atomic.Bool
+	// latestDeadline is the latest deadline of the requests sent on the channel, in Unix nanoseconds.
+	latestDeadline atomic.Int64
+}
+
+// begin records the start of a request that must complete by deadline.
+func (l *channelLoad) begin(deadline time.Time) {
+	l.inFlight.Add(1)
+	l.waitingSince.CompareAndSwap(0, time.Now().UnixNano())
+	for {
+		latest := l.latestDeadline.Load()
+		if deadline.UnixNano() <= latest || l.latestDeadline.CompareAndSwap(latest, deadline.UnixNano()) {
+			return
+		}
+	}
+}
+
+// end records the completion of a request. Requests that timed out after the whole client timeout are not
+// progress, as they are how requests on a wedged channel complete.
+func (l *channelLoad) end(timedOut bool) {
+	l.inFlight.Add(-1)
+	if timedOut {
+		l.timedOut.Store(true)
+		return
+	}
+	l.waitingSince.Store(0)
+	l.timedOut.Store(false)
+}
+
+// stalledFor returns how long requests on the channel have been waiting without any completing. Requests
+// are only considered stalled once one of them has timed out, so that a long request that is still within
+// its deadline does not count as a stall.
+func (l *channelLoad) stalledFor(now time.Time) time.Duration {
+	since := l.waitingSince.Load()
+	if since == 0 || !l.timedOut.Load() {
+		return 0
+	}
+	return now.Sub(time.Unix(0, since))
+}
+
+// drainedBy returns the time by which every request sent on the channel has reached its deadline.
+func (l *channelLoad) drainedBy() time.Time {
+	return time.Unix(0, l.latestDeadline.Load())
+}
+
+// dataClientSnapshot is an immutable view of the pool's data clients.
+type dataClientSnapshot struct {
+	clients  []*scsDataClient
+	channels []channelselection.Channel
+}
+
+func newDataClientSnapshot(clients []*scsDataClient) *dataClientSnapshot {
+	channels := make([]channelselection.Channel, len(clients))
+	for i, client := range clients {
+		channels[i] = client
+	}
+	return &dataClientSnapshot{clients: clients, channels: channels}
+}
+
+// dataClientPool holds the data clients of a cache client, each with its own gRPC channel, and chooses the
+// client each request is sent on. When channel selection is configured, it also replaces wedged channels and
+// adjusts the number of channels to the load.
+type dataClientPool struct {
+	props     channelselection.Props
+	newClient func() (*scsDataClient, momentoerrors.MomentoSvcErr)
+	logger    logger.MomentoLogger
+
+	snapshot atomic.Pointer[dataClientSnapshot]
+	// mutex serializes changes to the set of clients.
+	mutex     sync.Mutex
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newDataClientPool(
+	clients []*scsDataClient,
+	props *channelselection.Props,
+	newClient func() (*scsDataClient, momentoerrors.MomentoSvcErr),
+	logger logger.MomentoLogger,
+) *dataClientPool {
+	pool := &dataClientPool{
+		newClient: newClient,
+		logger:    logger,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	pool.snapshot.Store(newDataClientSnapshot(clients))
+	if props == nil {
+		pool.props = channelselection.Props{}.WithDefaults(uint32(len(clients)))
+		close(pool.done)
+		return pool
+	}
+	pool.props = props.WithDefaults(uint32(len(clients)))
+	go pool.run()
+	return pool
+}
+
+// next returns the client to send the next request on. Channels in TRANSIENT_FAILURE are skipped unless all
+// of them are.
+func (p *dataClientPool) next() *scsDataClient {
+	snapshot := p.snapshot.Load()
+	if len(snapshot.clients) == 1 {
+		return snapshot.clients[0]
+	}
+	clients, channels := snapshot.clients, snapshot.channels
+	if healthy := healthyDataClients(snapshot.clients); len(healthy) > 0 && len(healthy) < len(snapshot.clients) {
+		clients = healthy
+		channels = newDataClientSnapshot(healthy).channels
+	}
+	return clients[p.props.Selector.Select(channels)]
+}
+
+// other returns a client other than client, if there is one, chosen by the selector from the rest.
+func (p *dataClientPool) other(client *scsDataClient) *scsDataClient {
+	snapshot := p.snapshot.Load()
+	others := make([]*scsDataClient, 0, len(snapshot.clients))
+	for _, c := range snapshot.clients {
+		if c != client {
+			others = append(others, c)
+		}
+	}
+	if len(others) == 0 {
+		return client
+	}
+	if healthy := healthyDataClients(others); len(healthy) > 0 {
+		others = healthy
+	}
+	return others[p.props.Selector.Select(newDataClientSnapshot(others).channels)]
+}
+
+// channels returns the pool's current clients as health monitor channels.
+func (p *dataClientPool) channels() []health.Channel {
+	clients := p.snapshot.Load().clients
+	channels := make([]health.Channel, len(clients))
+	for i, client := range clients {
+		channels[i] = client
+	}
+	return channels
+}
+
+func (p *dataClientPool) size() int {
+	return len(p.snapshot.Load().clients)
+}
+
+// healthyDataClients returns the clients whose channels are not in TRANSIENT_FAILURE. It only allocates when
+// a channel is unhealthy, which is rare.
+func healthyDataClients(clients []*scsDataClient) []*scsDataClient {
+	for i, client := range clients {
+		if client.isHealthy() {
+			continue
+		}
+		healthy := append(make([]*scsDataClient, 0, len(clients)-1), clients[:i]...)
+		for _, client := range clients[i+1:] {
+			if client.isHealthy() {
+				healthy = append(healthy, client)
+			}
+		}
+		return healthy
+	}
+	return clients
+}
+
+func (p *dataClientPool) run() {
+	defer close(p.done)
+	ticker := time.NewTicker(p.props.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.maintain(time.Now())
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// maintain replaces wedged channels and adds or removes a channel if the load calls for it.
+func (p *dataClientPool) maintain(now time.Time) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	clients := append([]*scsDataClient{}, p.snapshot.Load().clients...)
+	changed := false
+	var totalInFlight int64
+	for i, client := range clients {
+		totalInFlight += client.InFlight()
+		if stalled := client.load.stalledFor(now); stalled > p.props.StallTimeout {
+			// A connection shared with other clients must not be leased again, so that the replacement
+			// dials a fresh one.
+			client.grpcManager.Discard()
+			replacement, err := p.connectNewClient()
+			if err != nil {
+				p.logger.Warn("Failed to replace gRPC channel stalled for %s: %s", stalled, err.Error())
+				continue
+			}
+			p.logger.Warn("Replacing gRPC channel with no completed requests for %s", stalled)
+			clients[i] = replacement
+			p.retire(client)
+			changed = true
+		}
+	}
+
+	count := int64(len(clients))
+	switch {
+	case totalInFlight > count*p.props.TargetInFlightPerChannel && uint32(count) < p.props.MaxChannels:
+		client, err := p.connectNewClient()
+		if err != nil {
+			p.logger.Warn("Failed to add a gRPC channel: %s", err.Error())
+			break
+		}
+		p.logger.Debug("Adding a gRPC channel; %d requests in flight on %d channels", totalInFlight, count)
+		clients = append(clients, client)
+		changed = true
+	case totalInFlight*4 < count*p.props.TargetInFlightPerChannel && uint32(count) > p.props.MinChannels:
+		p.logger.Debug("Removing a gRPC channel; %d requests in flight on %d channels", totalInFlight, count)
+		p.retire(clients[len(clients)-1])
+		clients = clients[:len(clients)-1]
+		changed = true
+	}
+
+	if changed {
+		p.snapshot.Store(newDataClientSnapshot(clients))
+	}
+}
+
+// connectNewClient creates a client and starts connecting its channel without waiting for it.
+func (p *dataClientPool) connectNewClient() (*scsDataClient, momentoerrors.MomentoSvcErr) {
+	client, err := p.newClient()
+	if err != nil {
+		return nil, err
+	}
+	client.grpcManager.Conn.Connect()
+	return client, nil
+}
+
+// retire closes a client that has been removed from the pool once it has no requests in flight, or once
+// its requests have all reached their deadlines. Requests that chose the client just before it was removed
+// have until the first check to start.
+func (p *dataClientPool) retire(client *scsDataClient) {
+	go func() {
+		defer client.Close()
+		ticker := time.NewTicker(retiredClientCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-p.stop:
+				return
+			}
+			if client.InFlight() == 0 || time.Now().After(client.load.drainedBy()) {
+				return
+			}
+		}
+	}()
+}
+
+// close stops maintaining the pool and closes its clients.
+func (p *dataClientPool) close() {
+	p.closeOnce.Do(func() {
+		close(p.stop)
+		<-p.done
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		for _, client := range p.snapshot.Load().clients {
+			client.Close()
+		}
+	})
+}
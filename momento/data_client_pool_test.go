@@ -0,0 +1,41 @@
+package momento
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("data-client-pool", func() {
+	Describe("channelLoad", func() {
+		var load *channelLoad
+
+		BeforeEach(func() {
+			load = &channelLoad{}
+		})
+
+		It("does not count requests within their deadlines as a stall", func() {
+			load.begin(time.Now().Add(time.Hour))
+			Expect(load.stalledFor(time.Now().Add(time.Minute))).To(BeZero())
+		})
+
+		It("counts the time since the oldest request started once a request times out", func() {
+			start := time.Now()
+			load.begin(start.Add(time.Hour))
+			load.begin(start.Add(time.Second))
+			load.end(true)
+			Expect(load.stalledFor(start.Add(time.Minute))).To(BeNumerically("~", time.Minute, time.Second))
+
+			load.end(false)
+			Expect(load.stalledFor(start.Add(time.Minute))).To(BeZero())
+		})
+
+		It("is drained by the latest deadline of its requests", func() {
+			deadline := time.Now().Add(time.Hour)
+			load.begin(deadline)
+			load.begin(deadline.Add(-time.Minute))
+			Expect(load.drainedBy()).To(BeTemporally("==", deadline))
+		})
+	})
+})
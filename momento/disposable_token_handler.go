@@ -0,0 +1,318 @@
+package momento
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config/logger"
+	responses "github.com/momentohq/client-sdk-go/responses/auth"
+	"github.com/momentohq/client-sdk-go/utils"
+)
+
+const (
+	defaultVendedTokenExpiresIn     = time.Hour
+	defaultVendedTokenRefreshBefore = 5 * time.Minute
+	defaultVendingRatePerMinute     = 6
+	defaultVendingBurst             = 3
+	vendingPruneInterval            = time.Minute
+	vendingRequestTimeout           = 10 * time.Second
+)
+
+// ScopeResolver authenticates a token request and returns the principal it is made for, such as a user id,
+// and the scope of the disposable token to issue to it. Tokens are cached and rate limited per principal.
+//
+// To reject a request, return a MomentoError: AuthenticationError responds with 401, PermissionError with
+// 403, and InvalidArgumentError or BadRequestError with 400. Any other error responds with 401 and its
+// message is not sent to the client.
+type ScopeResolver func(r *http.Request) (principal string, scope DisposableTokenScope, err error)
+
+type DisposableTokenHandlerProps struct {
+	// AuthClient generates the tokens. Its credentials must be allowed to generate disposable tokens.
+	AuthClient AuthClient
+	// ScopeResolver authenticates each request and decides the scope of its token.
+	ScopeResolver ScopeResolver
+	// ExpiresIn is how long issued tokens are valid for. Defaults to 1 hour, the longest allowed.
+	ExpiresIn time.Duration
+	// RefreshBefore is how long before a cached token expires a new one is issued in its place. Defaults to
+	// 5 minutes, or half of ExpiresIn if that is shorter.
+	RefreshBefore time.Duration
+	// RatePerMinute and Burst limit how many tokens are issued to each principal. Requests answered from the
+	// cache do not count. Default to 6 per minute with bursts of 3.
+	RatePerMinute float64
+	Burst         int
+	// LoggerFactory defaults to a factory of no-op loggers.
+	LoggerFactory logger.MomentoLoggerFactory
+}
+
+// DisposableTokenResponse is the JSON body of a successful token request.
+type DisposableTokenResponse struct {
+	// Token is a disposable token, usable as an API key, e.g. with auth.FromString.
+	Token string `json:"token"`
+	// Endpoint is the base endpoint the token is valid for.
+	Endpoint string `json:"endpoint"`
+	// ExpiresAt is when the token expires, in seconds since the Unix epoch.
+	ExpiresAt int64 `json:"expires_at"`
+}
+
+// DisposableTokenErrorResponse is the JSON body of a failed token request.
+type DisposableTokenErrorResponse struct {
+	Error DisposableTokenError `json:"error"`
+}
+
+type DisposableTokenError struct {
+	// Code is one of "unauthenticated", "forbidden", "bad_request", "method_not_allowed", "rate_limited" or
+	// "unavailable".
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// vendedToken is a token issued to a principal for one scope.
+type vendedToken struct {
+	response  DisposableTokenResponse
+	expiresAt time.Time
+}
+
+// vendingPrincipal holds the tokens and issuance budget of one principal.
+type vendingPrincipal struct {
+	mutex sync.Mutex
+	// tokens is keyed by a description of the scope, as a principal may be given different scopes over time.
+	tokens     map[string]vendedToken
+	allowance  float64
+	lastRefill time.Time
+}
+
+type disposableTokenHandler struct {
+	props DisposableTokenHandlerProps
+	log   logger.MomentoLogger
+
+	mutex      sync.Mutex
+	principals map[string]*vendingPrincipal
+	lastPrune  time.Time
+}
+
+// NewDisposableTokenHandler returns an http.Handler that vends disposable tokens to browser and mobile
+// clients. It accepts GET and POST requests, resolves the principal and scope of each with the ScopeResolver,
+// and responds with a DisposableTokenResponse. Tokens are reused for the same principal and scope until they
+// are about to expire, and issuance is rate limited per principal.
+// Example usage:
+//
+//	handler, err := momento.NewDisposableTokenHandler(momento.DisposableTokenHandlerProps{
+//		AuthClient: authClient,
+//		ScopeResolver: func(r *http.Request) (string, momento.DisposableTokenScope, error) {
+//			userId, err := authenticate(r)
+//			if err != nil {
+//				return "", nil, momento.NewMomentoError(momento.AuthenticationError, "invalid session", err)
+//			}
+//			return userId, momento.TopicNamePrefixPublishSubscribe(momento.CacheName{Name: "chat"}, userId+"/"), nil
+//		},
+//	})
+//	http.Handle("/momento/token", handler)
+func NewDisposableTokenHandler(props DisposableTokenHandlerProps) (http.Handler, error) {
+	if props.AuthClient == nil {
+		return nil, NewMomentoError(InvalidArgumentError, "auth client is required", nil)
+	}
+	if props.ScopeResolver == nil {
+		return nil, NewMomentoError(InvalidArgumentError, "scope resolver is required", nil)
+	}
+	if props.ExpiresIn <= 0 {
+		props.ExpiresIn = defaultVendedTokenExpiresIn
+	}
+	if props.ExpiresIn > defaultVendedTokenExpiresIn {
+		return nil, NewMomentoError(InvalidArgumentError, "disposable tokens cannot be valid for more than an hour", nil)
+	}
+	if props.RefreshBefore <= 0 {
+		props.RefreshBefore = defaultVendedTokenRefreshBefore
+		if props.RefreshBefore > props.ExpiresIn/2 {
+			props.RefreshBefore = props.ExpiresIn / 2
+		}
+	}
+	if props.RefreshBefore >= props.ExpiresIn {
+		return nil, NewMomentoError(InvalidArgumentError, "refresh before must be shorter than expires in", nil)
+	}
+	if props.RatePerMinute <= 0 {
+		props.RatePerMinute = defaultVendingRatePerMinute
+	}
+	if props.Burst <= 0 {
+		props.Burst = defaultVendingBurst
+	}
+	if props.LoggerFactory == nil {
+		props.LoggerFactory = logger.NewNoopMomentoLoggerFactory()
+	}
+	return &disposableTokenHandler{
+		props:      props,
+		log:        props.LoggerFactory.GetLogger("disposable-token-handler"),
+		principals: make(map[string]*vendingPrincipal),
+		lastPrune:  time.Now(),
+	}, nil
+}
+
+func (h *disposableTokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		writeVendingError(w, http.StatusMethodNotAllowed, "method_not_allowed", "use GET or POST")
+		return
+	}
+
+	principalName, scope, err := h.props.ScopeResolver(r)
+	if err != nil {
+		h.writeResolverError(w, err)
+		return
+	}
+	if principalName == "" || scope == nil {
+		h.log.Warn("Scope resolver returned an empty principal or scope without an error")
+		writeVendingError(w, http.StatusForbidden, "forbidden", "no token can be issued for this request")
+		return
+	}
+
+	principal := h.principal(principalName)
+	principal.mutex.Lock()
+	defer principal.mutex.Unlock()
+
+	now := time.Now()
+	scopeKey := fmt.Sprintf("%#v", scope)
+	if token, ok := principal.tokens[scopeKey]; ok && now.Add(h.props.RefreshBefore).Before(token.expiresAt) {
+		writeVendingResponse(w, http.StatusOK, token.response)
+		return
+	}
+
+	if wait := principal.take(now, h.props.RatePerMinute/60, h.props.Burst); wait > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		writeVendingError(w, http.StatusTooManyRequests, "rate_limited", "too many tokens requested")
+		return
+	}
+
+	token, err := h.issue(r, scope)
+	if err != nil {
+		h.log.Warn("Failed to generate a disposable token for %s: %s", principalName, err)
+		if momentoErr, ok := err.(MomentoError); ok && momentoErr.Code() == LimitExceededError {
+			writeVendingError(w, http.StatusTooManyRequests, "rate_limited", "too many tokens requested")
+			return
+		}
+		writeVendingError(w, http.StatusServiceUnavailable, "unavailable", "a token could not be issued")
+		return
+	}
+	principal.tokens[scopeKey] = token
+	writeVendingResponse(w, http.StatusOK, token.response)
+}
+
+func (h *disposableTokenHandler) issue(r *http.Request, scope DisposableTokenScope) (vendedToken, error) {
+	ctx, cancel := context.WithTimeout(r.Context(), vendingRequestTimeout)
+	defer cancel()
+	resp, err := h.props.AuthClient.GenerateDisposableToken(ctx, &GenerateDisposableTokenRequest{
+		ExpiresIn: utils.ExpiresInSeconds(int64(h.props.ExpiresIn.Seconds())),
+		Scope:     scope,
+	})
+	if err != nil {
+		return vendedToken{}, err
+	}
+	success, ok := resp.(*responses.GenerateDisposableTokenSuccess)
+	if !ok {
+		return vendedToken{}, NewMomentoError(UnknownServiceError, fmt.Sprintf("unexpected token response %T", resp), nil)
+	}
+	expiresAt := time.Unix(int64(success.ValidUntil), 0)
+	return vendedToken{
+		response: DisposableTokenResponse{
+			Token:     success.ApiKey,
+			Endpoint:  success.Endpoint,
+			ExpiresAt: expiresAt.Unix(),
+		},
+		expiresAt: expiresAt,
+	}, nil
+}
+
+// principal returns the state of the named principal, creating it if needed, and periodically forgets
+// principals that have no usable tokens and a full issuance budget.
+func (h *disposableTokenHandler) principal(name string) *vendingPrincipal {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(h.lastPrune) >= vendingPruneInterval {
+		h.lastPrune = now
+		for key, p := range h.principals {
+			if p.mutex.TryLock() {
+				if p.idle(now, h.props.RatePerMinute/60, h.props.Burst) {
+					delete(h.principals, key)
+				}
+				p.mutex.Unlock()
+			}
+		}
+	}
+
+	p, ok := h.principals[name]
+	if !ok {
+		p = &vendingPrincipal{
+			tokens:     make(map[string]vendedToken),
+			allowance:  float64(h.props.Burst),
+			lastRefill: now,
+		}
+		h.principals[name] = p
+	}
+	return p
+}
+
+// take consumes one issuance from the principal's budget, or returns how long until one is available.
+func (p *vendingPrincipal) take(now time.Time, ratePerSecond float64, burst int) time.Duration {
+	p.refill(now, ratePerSecond, burst)
+	if p.allowance >= 1 {
+		p.allowance--
+		return 0
+	}
+	return time.Duration((1 - p.allowance) / ratePerSecond * float64(time.Second))
+}
+
+func (p *vendingPrincipal) refill(now time.Time, ratePerSecond float64, burst int) {
+	p.allowance += now.Sub(p.lastRefill).Seconds() * ratePerSecond
+	if p.allowance > float64(burst) {
+		p.allowance = float64(burst)
+	}
+	p.lastRefill = now
+}
+
+// idle drops expired tokens and reports whether the principal can be forgotten without loosening its limit.
+func (p *vendingPrincipal) idle(now time.Time, ratePerSecond float64, burst int) bool {
+	for key, token := range p.tokens {
+		if !now.Before(token.expiresAt) {
+			delete(p.tokens, key)
+		}
+	}
+	p.refill(now, ratePerSecond, burst)
+	return len(p.tokens) == 0 && p.allowance >= float64(burst)
+}
+
+func (h *disposableTokenHandler) writeResolverError(w http.ResponseWriter, err error) {
+	if momentoErr, ok := err.(MomentoError); ok {
+		switch momentoErr.Code() {
+		case AuthenticationError:
+			writeVendingError(w, http.StatusUnauthorized, "unauthenticated", momentoErr.Message())
+			return
+		case PermissionError:
+			writeVendingError(w, http.StatusForbidden, "forbidden", momentoErr.Message())
+			return
+		case InvalidArgumentError, BadRequestError:
+			writeVendingError(w, http.StatusBadRequest, "bad_request", momentoErr.Message())
+			return
+		}
+	}
+	h.log.Debug("Scope resolver rejected a token request: %s", err)
+	writeVendingError(w, http.StatusUnauthorized, "unauthenticated", "the request could not be authenticated")
+}
+
+func writeVendingResponse(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeVendingError(w http.ResponseWriter, status int, code string, message string) {
+	writeVendingResponse(w, status, DisposableTokenErrorResponse{
+		Error: DisposableTokenError{Code: code, Message: message},
+	})
+}
@@ -0,0 +1,136 @@
+package momento_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/momentohq/client-sdk-go/momento"
+	responses "github.com/momentohq/client-sdk-go/responses/auth"
+)
+
+type fakeTokenAuthClient struct {
+	AuthClient
+	requests []*GenerateDisposableTokenRequest
+	err      error
+}
+
+func (c *fakeTokenAuthClient) GenerateDisposableToken(_ context.Context, request *GenerateDisposableTokenRequest) (responses.GenerateDisposableTokenResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.requests = append(c.requests, request)
+	return &responses.GenerateDisposableTokenSuccess{
+		ApiKey:     "token",
+		Endpoint:   "test.momentohq.com",
+		ValidUntil: uint64(time.Now().Add(time.Duration(request.ExpiresIn.Seconds()) * time.Second).Unix()),
+	}, nil
+}
+
+var _ = Describe("auth disposable-token-handler", Label(AUTH_SERVICE_LABEL), func() {
+	var authClient *fakeTokenAuthClient
+	var handler http.Handler
+
+	resolver := func(r *http.Request) (string, DisposableTokenScope, error) {
+		user := r.Header.Get("X-User")
+		if user == "" {
+			return "", nil, NewMomentoError(AuthenticationError, "missing user", nil)
+		}
+		return user, TopicNamePrefixPublishSubscribe(CacheName{Name: "chat"}, user+"/"), nil
+	}
+
+	request := func(user string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/token", nil)
+		if user != "" {
+			r.Header.Set("X-User", user)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	BeforeEach(func() {
+		authClient = &fakeTokenAuthClient{}
+		var err error
+		handler, err = NewDisposableTokenHandler(DisposableTokenHandlerProps{
+			AuthClient:    authClient,
+			ScopeResolver: resolver,
+			ExpiresIn:     10 * time.Minute,
+			Burst:         2,
+		})
+		Expect(err).To(BeNil())
+	})
+
+	It("issues a token with the resolved scope", func() {
+		w := request("alice")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("Cache-Control")).To(Equal("no-store"))
+
+		var body DisposableTokenResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &body)).To(Succeed())
+		Expect(body.Token).To(Equal("token"))
+		Expect(body.Endpoint).To(Equal("test.momentohq.com"))
+		Expect(body.ExpiresAt).To(BeNumerically("~", time.Now().Add(10*time.Minute).Unix(), 2))
+
+		Expect(authClient.requests).To(HaveLen(1))
+		Expect(authClient.requests[0].Scope).To(Equal(TopicNamePrefixPublishSubscribe(CacheName{Name: "chat"}, "alice/")))
+	})
+
+	It("reuses the token for the same principal", func() {
+		Expect(request("alice").Code).To(Equal(http.StatusOK))
+		Expect(request("alice").Code).To(Equal(http.StatusOK))
+		Expect(request("bob").Code).To(Equal(http.StatusOK))
+		Expect(authClient.requests).To(HaveLen(2))
+	})
+
+	It("rate limits issuance per principal", func() {
+		handler, _ = NewDisposableTokenHandler(DisposableTokenHandlerProps{
+			AuthClient:    authClient,
+			ScopeResolver: resolver,
+			ExpiresIn:     time.Minute,
+			RefreshBefore: 59 * time.Second,
+			Burst:         2,
+		})
+		Expect(request("alice").Code).To(Equal(http.StatusOK))
+		time.Sleep(1100 * time.Millisecond)
+		Expect(request("alice").Code).To(Equal(http.StatusOK))
+		time.Sleep(1100 * time.Millisecond)
+
+		w := request("alice")
+		Expect(w.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(w.Header().Get("Retry-After")).ToNot(BeEmpty())
+		var body DisposableTokenErrorResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &body)).To(Succeed())
+		Expect(body.Error.Code).To(Equal("rate_limited"))
+
+		Expect(request("bob").Code).To(Equal(http.StatusOK))
+	})
+
+	It("maps resolver and service errors to status codes", func() {
+		w := request("")
+		Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		var body DisposableTokenErrorResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &body)).To(Succeed())
+		Expect(body.Error).To(Equal(DisposableTokenError{Code: "unauthenticated", Message: "missing user"}))
+
+		authClient.err = NewMomentoError(ServerUnavailableError, "down", nil)
+		Expect(request("alice").Code).To(Equal(http.StatusServiceUnavailable))
+
+		r := httptest.NewRequest(http.MethodDelete, "/token", nil)
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		Expect(w.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+
+	It("rejects invalid props", func() {
+		_, err := NewDisposableTokenHandler(DisposableTokenHandlerProps{ScopeResolver: resolver})
+		Expect(err).To(HaveMomentoErrorCode(InvalidArgumentError))
+		_, err = NewDisposableTokenHandler(DisposableTokenHandlerProps{AuthClient: authClient, ScopeResolver: resolver, ExpiresIn: 2 * time.Hour})
+		Expect(err).To(HaveMomentoErrorCode(InvalidArgumentError))
+	})
+})
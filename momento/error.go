@@ -46,6 +46,14 @@ const (
 	ConnectionError = "ConnectionError"
 	// ClientResourceExhausted occurs when a client resource (such as memory or number of concurrent grpc streams) is exhausted.
 	ClientResourceExhaustedError = "ClientResourceExhaustedError"
+	// EncryptionIntegrityError occurs when an encrypted value could not be authenticated, because it was modified
+	// after it was written or was encrypted with a key that is not available to the client.
+	EncryptionIntegrityError = "EncryptionIntegrityError"
+	// CircuitOpenError occurs when a request is rejected without being sent because the client's circuit
+	// breaker has detected that the service is unhealthy.
+	CircuitOpenError = "CircuitOpenError"
+	// ClientClosedError occurs when a request is made on a client that is shutting down or closed.
+	ClientClosedError = "ClientClosedError"
 )
 
 type MomentoError interface {
@@ -0,0 +1,899 @@
+package momento
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config/health"
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/responses"
+)
+
+// ReplicationMode is how a FailoverCacheClient copies writes to the regions that did not serve them. Only
+// writes that can safely be applied again are replicated: Set, SetWithHash, SetBatch, Delete, the TTL
+// updates, and the collection writes that put or remove given elements, fields or values. Writes whose
+// effect depends on the state of the region, such as increments, pushes, pops and conditional sets, as well
+// as CreateCache and DeleteCache, are applied only in the region that serves them.
+type ReplicationMode string
+
+const (
+	// ReplicationNone writes only to the region that serves the request.
+	ReplicationNone ReplicationMode = "none"
+	// ReplicationSync writes to the other regions before the request returns.
+	ReplicationSync ReplicationMode = "sync"
+	// ReplicationAsync writes to the other regions in the background after the request returns. The writes
+	// to an item are replicated one at a time, in the order they were served.
+	ReplicationAsync ReplicationMode = "async"
+)
+
+const (
+	defaultFailbackAfter        = 30 * time.Second
+	defaultLatencyProbeInterval = 30 * time.Second
+	// latencyWeight is the weight of each probe in a region's average ping latency.
+	latencyWeight = 0.3
+)
+
+// FailoverRegion is a regional cache client wrapped by a FailoverCacheClient.
+type FailoverRegion struct {
+	// Name identifies the region in events and logs.
+	Name   string
+	Client CacheClient
+}
+
+// FailoverEvent describes the client switching the region it sends requests to.
+type FailoverEvent struct {
+	From string
+	To   string
+	// Err is the error that caused the failover, or nil when the client fails back to the primary region.
+	Err error
+}
+
+// ReplicationError describes a write that could not be replicated to a region.
+type ReplicationError struct {
+	Region    string
+	Operation string
+	Err       error
+}
+
+func (e ReplicationError) Error() string {
+	return fmt.Sprintf("failed to replicate %s to region %s: %v", e.Operation, e.Region, e.Err)
+}
+
+func (e ReplicationError) Unwrap() error {
+	return e.Err
+}
+
+// FailoverCacheClientProps configures a FailoverCacheClient. The zero value of each optional field selects
+// its default.
+type FailoverCacheClientProps struct {
+	// Regions are the regional clients in order of preference. The first is the primary region. The
+	// regions are expected to have caches with the same names.
+	Regions []FailoverRegion
+	// ShouldFailover reports whether an error from a region should send the request, and the requests
+	// after it, to the next region. Defaults to IsFailoverError. Writes that time out are never sent to the
+	// next region, as they may have been applied.
+	ShouldFailover func(err error) bool
+	// FailbackAfter is how long the client sends requests to a secondary region before trying the
+	// primary region again. Defaults to 30 seconds.
+	FailbackAfter time.Duration
+	// ReadFromNearest sends reads to the healthy region with the lowest ping latency instead of the
+	// region writes are sent to.
+	ReadFromNearest bool
+	// LatencyProbeInterval is the time between pings that measure the latency of each region when
+	// ReadFromNearest is set. Defaults to 30 seconds.
+	LatencyProbeInterval time.Duration
+	// Replication is how writes are copied to the regions that did not serve them. Defaults to
+	// ReplicationNone.
+	Replication ReplicationMode
+	// OnFailover, if set, is called when the client switches the region it sends requests to.
+	OnFailover func(event FailoverEvent)
+	// OnReplicationError, if set, is called when a write cannot be replicated to a region. Replication
+	// errors do not fail the write, which has taken effect in the region that served it.
+	OnReplicationError func(err ReplicationError)
+	// LoggerFactory is used to log failovers and replication errors. Defaults to the logger of the primary
+	// region's client.
+	LoggerFactory logger.MomentoLoggerFactory
+}
+
+// FailoverCacheClient is a CacheClient that sends requests to one of several regional clients, failing
+// over to the next region when the active one is unhealthy or unreachable.
+type FailoverCacheClient interface {
+	CacheClient
+
+	// ActiveRegion returns the name of the region requests are currently sent to.
+	ActiveRegion() string
+}
+
+// IsFailoverError reports whether err indicates that a region is unreachable or unhealthy rather than a
+// problem with the request.
+func IsFailoverError(err error) bool {
+	momentoErr, ok := err.(MomentoError)
+	if !ok {
+		return false
+	}
+	switch momentoErr.Code() {
+	case ConnectionError, ServerUnavailableError, TimeoutError, InternalServerError, CircuitOpenError:
+		return true
+	default:
+		return false
+	}
+}
+
+type failoverCacheClient struct {
+	props      FailoverCacheClientProps
+	logger     logger.MomentoLogger
+	operations *operationTracker
+	// replicationQueues hold the background replications to each region.
+	replicationQueues []*replicationQueue
+
+	mutex        sync.Mutex
+	active       int
+	failedOverAt time.Time
+	// latencies are the average ping latencies of the regions, or 0 if not known.
+	latencies []time.Duration
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewFailoverCacheClient returns a CacheClient that routes requests to the active one of several regional
+// clients. Requests go to the primary region until it fails with an error accepted by ShouldFailover or its
+// health monitor reports it unhealthy, and then to the next region until FailbackAfter has passed. Closing
+// the returned client closes the regional clients.
+func NewFailoverCacheClient(props FailoverCacheClientProps) (FailoverCacheClient, error) {
+	if len(props.Regions) == 0 {
+		return nil, NewMomentoError(InvalidArgumentError, "at least one region must be provided", nil)
+	}
+	names := make(map[string]bool, len(props.Regions))
+	for _, region := range props.Regions {
+		if region.Client == nil {
+			return nil, NewMomentoError(InvalidArgumentError, fmt.Sprintf("region %s has no client", region.Name), nil)
+		}
+		if names[region.Name] {
+			return nil, NewMomentoError(InvalidArgumentError, fmt.Sprintf("region %s is provided more than once", region.Name), nil)
+		}
+		names[region.Name] = true
+	}
+	switch props.Replication {
+	case "":
+		props.Replication = ReplicationNone
+	case ReplicationNone, ReplicationSync, ReplicationAsync:
+	default:
+		return nil, NewMomentoError(InvalidArgumentError, fmt.Sprintf("unknown replication mode %s", props.Replication), nil)
+	}
+	if props.ShouldFailover == nil {
+		props.ShouldFailover = IsFailoverError
+	}
+	if props.FailbackAfter <= 0 {
+		props.FailbackAfter = defaultFailbackAfter
+	}
+	if props.LatencyProbeInterval <= 0 {
+		props.LatencyProbeInterval = defaultLatencyProbeInterval
+	}
+
+	var log logger.MomentoLogger
+	if props.LoggerFactory != nil {
+		log = props.LoggerFactory.GetLogger("failover-cache-client")
+	} else {
+		log = props.Regions[0].Client.Logger()
+	}
+	client := &failoverCacheClient{
+		props:             props,
+		logger:            log,
+		operations:        newOperationTracker("failover cache client"),
+		replicationQueues: make([]*replicationQueue, len(props.Regions)),
+		latencies:         make([]time.Duration, len(props.Regions)),
+		stop:              make(chan struct{}),
+		done:              make(chan struct{}),
+	}
+	for i := range client.replicationQueues {
+		client.replicationQueues[i] = &replicationQueue{tails: map[string]chan struct{}{}}
+	}
+	if props.ReadFromNearest {
+		go client.probeLatencies()
+	} else {
+		close(client.done)
+	}
+	return client, nil
+}
+
+func (c *failoverCacheClient) ActiveRegion() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.props.Regions[c.active].Name
+}
+
+func (c *failoverCacheClient) Logger() logger.MomentoLogger {
+	return c.logger
+}
+
+// Health returns the health of the active region's client.
+func (c *failoverCacheClient) Health() health.Health {
+	c.mutex.Lock()
+	client := c.props.Regions[c.active].Client
+	c.mutex.Unlock()
+	return Health(client)
+}
+
+func (c *failoverCacheClient) Ping(ctx context.Context) (responses.PingResponse, error) {
+	return route(c, ctx, "Ping", readRequest, &pingRequest{}, func(client CacheClient, ctx context.Context, _ *pingRequest) (responses.PingResponse, error) {
+		return client.Ping(ctx)
+	})
+}
+
+// pingRequest stands in for the request of Ping, which has none.
+type pingRequest struct{}
+
+// Shutdown waits for the requests and background replications in flight, then shuts down the regional
+// clients. The summary adds up what each of them abandoned.
+func (c *failoverCacheClient) Shutdown(ctx context.Context) (ShutdownSummary, error) {
+	summary, err := c.operations.shutdown(ctx)
+	c.stopProbing()
+	for _, region := range c.props.Regions {
+		regionSummary, regionErr := Shutdown(ctx, region.Client)
+		for name, count := range regionSummary.AbandonedOperations {
+			summary.AbandonedOperations[name] += count
+		}
+		summary.ClosedSubscriptions += regionSummary.ClosedSubscriptions
+		if err == nil {
+			err = regionErr
+		}
+	}
+	return summary, err
+}
+
+func (c *failoverCacheClient) Close() {
+	c.operations.reject()
+	c.stopProbing()
+	for _, region := range c.props.Regions {
+		region.Client.Close()
+	}
+}
+
+func (c *failoverCacheClient) stopProbing() {
+	c.closeOnce.Do(func() {
+		close(c.stop)
+		<-c.done
+	})
+}
+
+// requestKind is how route sends a request.
+type requestKind int
+
+const (
+	// readRequest is sent to the nearest region if ReadFromNearest is set, and is never replicated.
+	readRequest requestKind = iota
+	// writeRequest is sent to the active region, and is not replicated as applying it again could change
+	// its effect.
+	writeRequest
+	// replicatedWrite is sent to the active region, and replicated as configured by the replication mode.
+	replicatedWrite
+)
+
+// route sends a request to the regions in order of preference until one serves it or fails with an
+// error that does not call for failover, and replicates writes it served.
+func route[R any, T any](
+	c *failoverCacheClient,
+	ctx context.Context,
+	operation string,
+	kind requestKind,
+	r *R,
+	call func(client CacheClient, ctx context.Context, r *R) (T, error),
+) (T, error) {
+	var zero T
+	end, trackErr := c.operations.begin(operation)
+	if trackErr != nil {
+		return zero, trackErr
+	}
+	defer end()
+
+	order := c.regionOrder(kind == readRequest)
+	var failed []int
+	var lastErr error
+	for _, index := range order {
+		// Each region gets its own copy of the request, since clients fill in their default cache name.
+		request := *r
+		resp, err := call(c.props.Regions[index].Client, ctx, &request)
+		if err == nil {
+			if len(failed) > 0 {
+				c.failOver(failed, index, lastErr)
+			}
+			if kind == replicatedWrite {
+				replicate(c, ctx, operation, index, r, call)
+			}
+			return resp, nil
+		}
+		if ctx.Err() != nil || !c.props.ShouldFailover(err) {
+			return zero, err
+		}
+		// A write that timed out may still be applied, and sending it to the next region could apply it
+		// twice or out of order.
+		if momentoErr, ok := err.(MomentoError); ok && kind != readRequest && momentoErr.Code() == TimeoutError {
+			return zero, err
+		}
+		c.logger.Warn("%s request failed in region %s: %s", operation, c.props.Regions[index].Name, err.Error())
+		failed = append(failed, index)
+		lastErr = err
+	}
+	return zero, lastErr
+}
+
+// replicate copies a write served by one region to the others, as configured by the replication mode.
+func replicate[R any, T any](
+	c *failoverCacheClient,
+	ctx context.Context,
+	operation string,
+	served int,
+	r *R,
+	call func(client CacheClient, ctx context.Context, r *R) (T, error),
+) {
+	if c.props.Replication == ReplicationNone {
+		return
+	}
+	items := replicationKeys(r)
+	var wg sync.WaitGroup
+	for index := range c.props.Regions {
+		if index == served {
+			continue
+		}
+		if c.props.Replication == ReplicationAsync {
+			// Background replications are tracked so that Shutdown waits for them, and outlive the request's context.
+			end := c.operations.extend(operation)
+			index, request := index, *r
+			c.replicationQueues[index].enqueue(items, func() {
+				defer end()
+				replicateTo(c, detachedContext{parent: ctx}, operation, index, request, call)
+			})
+			continue
+		}
+		wg.Add(1)
+		go func(index int, request R) {
+			defer wg.Done()
+			replicateTo(c, ctx, operation, index, request, call)
+		}(index, *r)
+	}
+	wg.Wait()
+}
+
+func replicateTo[R any, T any](
+	c *failoverCacheClient,
+	ctx context.Context,
+	operation string,
+	index int,
+	request R,
+	call func(client CacheClient, ctx context.Context, r *R) (T, error),
+) {
+	if _, err := call(c.props.Regions[index].Client, ctx, &request); err != nil {
+		replicationErr := ReplicationError{Region: c.props.Regions[index].Name, Operation: operation, Err: err}
+		c.logger.Warn(replicationErr.Error())
+		if c.props.OnReplicationError != nil {
+			c.props.OnReplicationError(replicationErr)
+		}
+	}
+}
+
+// replicationQueue runs the background replications to a region, one at a time for each item, in the
+// order they were queued.
+type replicationQueue struct {
+	mutex sync.Mutex
+	// tails are closed when the last replication queued for each item finishes.
+	tails map[string]chan struct{}
+}
+
+// enqueue runs replicate in the background once the replications queued before it for any of the items
+// have finished.
+func (q *replicationQueue) enqueue(items []string, replicate func()) {
+	done := make(chan struct{})
+	var previous []chan struct{}
+	q.mutex.Lock()
+	for _, item := range items {
+		// A batch may hold an item more than once.
+		if tail, ok := q.tails[item]; ok && tail != done {
+			previous = append(previous, tail)
+		}
+		q.tails[item] = done
+	}
+	q.mutex.Unlock()
+
+	go func() {
+		for _, tail := range previous {
+			<-tail
+		}
+		replicate()
+		q.mutex.Lock()
+		for _, item := range items {
+			if q.tails[item] == done {
+				delete(q.tails, item)
+			}
+		}
+		q.mutex.Unlock()
+		close(done)
+	}()
+}
+
+// replicationKeys returns the items a replicated write changes, as keys of a replicationQueue.
+func replicationKeys(r any) []string {
+	item := func(cacheName string, name []byte) string {
+		return cacheName + "\x00" + string(name)
+	}
+	switch r := r.(type) {
+	case *SetRequest:
+		return []string{item(r.CacheName, shardKey(r.Key))}
+	case *SetWithHashRequest:
+		return []string{item(r.CacheName, shardKey(r.Key))}
+	case *SetBatchRequest:
+		keys := make([]string, 0, len(r.Items))
+		for _, batchItem := range r.Items {
+			keys = append(keys, item(r.CacheName, shardKey(batchItem.Key)))
+		}
+		return keys
+	case *DeleteRequest:
+		return []string{item(r.CacheName, shardKey(r.Key))}
+	case *UpdateTtlRequest:
+		return []string{item(r.CacheName, shardKey(r.Key))}
+	case *IncreaseTtlRequest:
+		return []string{item(r.CacheName, shardKey(r.Key))}
+	case *DecreaseTtlRequest:
+		return []string{item(r.CacheName, shardKey(r.Key))}
+	case *SortedSetPutElementRequest:
+		return []string{item(r.CacheName, []byte(r.SetName))}
+	case *SortedSetPutElementsRequest:
+		return []string{item(r.CacheName, []byte(r.SetName))}
+	case *SortedSetRemoveElementRequest:
+		return []string{item(r.CacheName, []byte(r.SetName))}
+	case *SortedSetRemoveElementsRequest:
+		return []string{item(r.CacheName, []byte(r.SetName))}
+	case *SetAddElementRequest:
+		return []string{item(r.CacheName, []byte(r.SetName))}
+	case *SetAddElementsRequest:
+		return []string{item(r.CacheName, []byte(r.SetName))}
+	case *SetRemoveElementRequest:
+		return []string{item(r.CacheName, []byte(r.SetName))}
+	case *SetRemoveElementsRequest:
+		return []string{item(r.CacheName, []byte(r.SetName))}
+	case *ListRemoveValueRequest:
+		return []string{item(r.CacheName, []byte(r.ListName))}
+	case *DictionarySetFieldRequest:
+		return []string{item(r.CacheName, []byte(r.DictionaryName))}
+	case *DictionarySetFieldsRequest:
+		return []string{item(r.CacheName, []byte(r.DictionaryName))}
+	case *DictionaryRemoveFieldRequest:
+		return []string{item(r.CacheName, []byte(r.DictionaryName))}
+	case *DictionaryRemoveFieldsRequest:
+		return []string{item(r.CacheName, []byte(r.DictionaryName))}
+	default:
+		// Writes to items that are not known are replicated one at a time.
+		return []string{""}
+	}
+}
+
+// regionOrder returns the regions to try, in order. Writes start with the active region and reads with
+// the nearest one if ReadFromNearest is set. Regions whose health monitor reports them unhealthy are tried
+// last. It fails over from an unhealthy active region, and back to the primary region once FailbackAfter
+// has passed.
+func (c *failoverCacheClient) regionOrder(isRead bool) []int {
+	healthy := make([]bool, len(c.props.Regions))
+	for i, region := range c.props.Regions {
+		healthy[i] = Health(region.Client).State != health.StateUnhealthy
+	}
+
+	c.mutex.Lock()
+	var event *FailoverEvent
+	if c.active != 0 && healthy[0] && time.Since(c.failedOverAt) >= c.props.FailbackAfter {
+		event = &FailoverEvent{From: c.props.Regions[c.active].Name, To: c.props.Regions[0].Name}
+		c.active = 0
+	}
+	if !healthy[c.active] {
+		for i := range c.props.Regions {
+			if !healthy[i] {
+				continue
+			}
+			event = &FailoverEvent{
+				From: c.props.Regions[c.active].Name,
+				To:   c.props.Regions[i].Name,
+				Err: NewMomentoError(
+					ServerUnavailableError,
+					fmt.Sprintf("health monitor reports region %s unhealthy", c.props.Regions[c.active].Name),
+					nil,
+				),
+			}
+			c.active = i
+			c.failedOverAt = time.Now()
+			break
+		}
+	}
+	active := c.active
+	first := active
+	if isRead && c.props.ReadFromNearest {
+		if nearest := c.nearest(healthy); nearest >= 0 {
+			first = nearest
+		}
+	}
+	c.mutex.Unlock()
+	if event != nil {
+		c.notifyFailover(*event)
+	}
+
+	preferred := make([]int, 0, len(c.props.Regions))
+	preferred = append(preferred, first)
+	if first != active {
+		preferred = append(preferred, active)
+	}
+	for i := range c.props.Regions {
+		if i != first && i != active {
+			preferred = append(preferred, i)
+		}
+	}
+	order := make([]int, 0, len(preferred))
+	for _, wantHealthy := range []bool{true, false} {
+		for _, i := range preferred {
+			if healthy[i] == wantHealthy {
+				order = append(order, i)
+			}
+		}
+	}
+	return order
+}
+
+// nearest returns the healthy region with the lowest known latency, or -1 if none is known. It must be
+// called with the mutex held.
+func (c *failoverCacheClient) nearest(healthy []bool) int {
+	nearest := -1
+	for i, latency := range c.latencies {
+		if !healthy[i] || latency <= 0 {
+			continue
+		}
+		if nearest < 0 || latency < c.latencies[nearest] {
+			nearest = i
+		}
+	}
+	return nearest
+}
+
+// failOver makes the region that served a request active if the active region was among those that failed.
+func (c *failoverCacheClient) failOver(failed []int, served int, err error) {
+	c.mutex.Lock()
+	activeFailed := false
+	for _, index := range failed {
+		if index == c.active {
+			activeFailed = true
+		}
+	}
+	if !activeFailed || served == c.active {
+		c.mutex.Unlock()
+		return
+	}
+	event := FailoverEvent{From: c.props.Regions[c.active].Name, To: c.props.Regions[served].Name, Err: err}
+	c.active = served
+	c.failedOverAt = time.Now()
+	c.mutex.Unlock()
+	c.notifyFailover(event)
+}
+
+func (c *failoverCacheClient) notifyFailover(event FailoverEvent) {
+	if event.Err != nil {
+		c.logger.Warn("Failing over from region %s to region %s: %s", event.From, event.To, event.Err.Error())
+	} else {
+		c.logger.Info("Failing back from region %s to region %s", event.From, event.To)
+	}
+	if c.props.OnFailover != nil {
+		c.props.OnFailover(event)
+	}
+}
+
+// probeLatencies pings each region every LatencyProbeInterval to find the nearest one.
+func (c *failoverCacheClient) probeLatencies() {
+	defer close(c.done)
+	ticker := time.NewTicker(c.props.LatencyProbeInterval)
+	defer ticker.Stop()
+	for {
+		c.probeLatenciesOnce()
+		select {
+		case <-ticker.C:
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+func (c *failoverCacheClient) probeLatenciesOnce() {
+	latencies := make([]time.Duration, len(c.props.Regions))
+	var wg sync.WaitGroup
+	for i, region := range c.props.Regions {
+		wg.Add(1)
+		go func(i int, client CacheClient) {
+			defer wg.Done()
+			start := time.Now()
+			if _, err := client.Ping(context.Background()); err != nil {
+				latencies[i] = -1
+				return
+			}
+			latencies[i] = time.Since(start)
+		}(i, region.Client)
+	}
+	wg.Wait()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for i, latency := range latencies {
+		switch {
+		case latency < 0:
+			// A region that cannot be pinged is not a candidate until a ping succeeds.
+			c.latencies[i] = 0
+		case c.latencies[i] == 0:
+			c.latencies[i] = latency
+		default:
+			c.latencies[i] = time.Duration(math.Round(
+				float64(c.latencies[i])*(1-latencyWeight) + float64(latency)*latencyWeight,
+			))
+		}
+	}
+}
+
+func (c *failoverCacheClient) CreateCache(ctx context.Context, r *CreateCacheRequest) (responses.CreateCacheResponse, error) {
+	return route(c, ctx, "CreateCache", writeRequest, r, CacheClient.CreateCache)
+}
+
+func (c *failoverCacheClient) DeleteCache(ctx context.Context, r *DeleteCacheRequest) (responses.DeleteCacheResponse, error) {
+	return route(c, ctx, "DeleteCache", writeRequest, r, CacheClient.DeleteCache)
+}
+
+func (c *failoverCacheClient) ListCaches(ctx context.Context, r *ListCachesRequest) (responses.ListCachesResponse, error) {
+	return route(c, ctx, "ListCaches", readRequest, r, CacheClient.ListCaches)
+}
+
+func (c *failoverCacheClient) Increment(ctx context.Context, r *IncrementRequest) (responses.IncrementResponse, error) {
+	return route(c, ctx, "Increment", writeRequest, r, CacheClient.Increment)
+}
+
+func (c *failoverCacheClient) Set(ctx context.Context, r *SetRequest) (responses.SetResponse, error) {
+	return route(c, ctx, "Set", replicatedWrite, r, CacheClient.Set)
+}
+
+func (c *failoverCacheClient) SetIfNotExists(ctx context.Context, r *SetIfNotExistsRequest) (responses.SetIfNotExistsResponse, error) {
+	return route(c, ctx, "SetIfNotExists", writeRequest, r, CacheClient.SetIfNotExists)
+}
+
+func (c *failoverCacheClient) SetIfAbsent(ctx context.Context, r *SetIfAbsentRequest) (responses.SetIfAbsentResponse, error) {
+	return route(c, ctx, "SetIfAbsent", writeRequest, r, CacheClient.SetIfAbsent)
+}
+
+func (c *failoverCacheClient) SetIfPresent(ctx context.Context, r *SetIfPresentRequest) (responses.SetIfPresentResponse, error) {
+	return route(c, ctx, "SetIfPresent", writeRequest, r, CacheClient.SetIfPresent)
+}
+
+func (c *failoverCacheClient) SetIfPresentAndNotEqual(ctx context.Context, r *SetIfPresentAndNotEqualRequest) (responses.SetIfPresentAndNotEqualResponse, error) {
+	return route(c, ctx, "SetIfPresentAndNotEqual", writeRequest, r, CacheClient.SetIfPresentAndNotEqual)
+}
+
+func (c *failoverCacheClient) SetIfEqual(ctx context.Context, r *SetIfEqualRequest) (responses.SetIfEqualResponse, error) {
+	return route(c, ctx, "SetIfEqual", writeRequest, r, CacheClient.SetIfEqual)
+}
+
+func (c *failoverCacheClient) SetIfAbsentOrEqual(ctx context.Context, r *SetIfAbsentOrEqualRequest) (responses.SetIfAbsentOrEqualResponse, error) {
+	return route(c, ctx, "SetIfAbsentOrEqual", writeRequest, r, CacheClient.SetIfAbsentOrEqual)
+}
+
+func (c *failoverCacheClient) SetIfNotEqual(ctx context.Context, r *SetIfNotEqualRequest) (responses.SetIfNotEqualResponse, error) {
+	return route(c, ctx, "SetIfNotEqual", writeRequest, r, CacheClient.SetIfNotEqual)
+}
+
+func (c *failoverCacheClient) SetIfPresentAndHashNotEqual(ctx context.Context, r *SetIfPresentAndHashNotEqualRequest) (responses.SetIfPresentAndHashNotEqualResponse, error) {
+	return route(c, ctx, "SetIfPresentAndHashNotEqual", writeRequest, r, CacheClient.SetIfPresentAndHashNotEqual)
+}
+
+func (c *failoverCacheClient) SetIfPresentAndHashEqual(ctx context.Context, r *SetIfPresentAndHashEqualRequest) (responses.SetIfPresentAndHashEqualResponse, error) {
+	return route(c, ctx, "SetIfPresentAndHashEqual", writeRequest, r, CacheClient.SetIfPresentAndHashEqual)
+}
+
+func (c *failoverCacheClient) SetIfAbsentOrHashEqual(ctx context.Context, r *SetIfAbsentOrHashEqualRequest) (responses.SetIfAbsentOrHashEqualResponse, error) {
+	return route(c, ctx, "SetIfAbsentOrHashEqual", writeRequest, r, CacheClient.SetIfAbsentOrHashEqual)
+}
+
+func (c *failoverCacheClient) SetIfAbsentOrHashNotEqual(ctx context.Context, r *SetIfAbsentOrHashNotEqualRequest) (responses.SetIfAbsentOrHashNotEqualResponse, error) {
+	return route(c, ctx, "SetIfAbsentOrHashNotEqual", writeRequest, r, CacheClient.SetIfAbsentOrHashNotEqual)
+}
+
+func (c *failoverCacheClient) SetWithHash(ctx context.Context, r *SetWithHashRequest) (responses.SetWithHashResponse, error) {
+	return route(c, ctx, "SetWithHash", replicatedWrite, r, CacheClient.SetWithHash)
+}
+
+func (c *failoverCacheClient) SetBatch(ctx context.Context, r *SetBatchRequest) (responses.SetBatchResponse, error) {
+	return route(c, ctx, "SetBatch", replicatedWrite, r, CacheClient.SetBatch)
+}
+
+func (c *failoverCacheClient) Get(ctx context.Context, r *GetRequest) (responses.GetResponse, error) {
+	return route(c, ctx, "Get", readRequest, r, CacheClient.Get)
+}
+
+func (c *failoverCacheClient) GetWithHash(ctx context.Context, r *GetWithHashRequest) (responses.GetWithHashResponse, error) {
+	return route(c, ctx, "GetWithHash", readRequest, r, CacheClient.GetWithHash)
+}
+
+func (c *failoverCacheClient) GetBatch(ctx context.Context, r *GetBatchRequest) (responses.GetBatchResponse, error) {
+	return route(c, ctx, "GetBatch", readRequest, r, CacheClient.GetBatch)
+}
+
+func (c *failoverCacheClient) Delete(ctx context.Context, r *DeleteRequest) (responses.DeleteResponse, error) {
+	return route(c, ctx, "Delete", replicatedWrite, r, CacheClient.Delete)
+}
+
+func (c *failoverCacheClient) KeysExist(ctx context.Context, r *KeysExistRequest) (responses.KeysExistResponse, error) {
+	return route(c, ctx, "KeysExist", readRequest, r, CacheClient.KeysExist)
+}
+
+func (c *failoverCacheClient) ItemGetType(ctx context.Context, r *ItemGetTypeRequest) (responses.ItemGetTypeResponse, error) {
+	return route(c, ctx, "ItemGetType", readRequest, r, CacheClient.ItemGetType)
+}
+
+func (c *failoverCacheClient) ItemGetTtl(ctx context.Context, r *ItemGetTtlRequest) (responses.ItemGetTtlResponse, error) {
+	return route(c, ctx, "ItemGetTtl", readRequest, r, CacheClient.ItemGetTtl)
+}
+
+func (c *failoverCacheClient) SortedSetFetchByRank(ctx context.Context, r *SortedSetFetchByRankRequest) (responses.SortedSetFetchResponse, error) {
+	return route(c, ctx, "SortedSetFetchByRank", readRequest, r, CacheClient.SortedSetFetchByRank)
+}
+
+func (c *failoverCacheClient) SortedSetFetchByScore(ctx context.Context, r *SortedSetFetchByScoreRequest) (responses.SortedSetFetchResponse, error) {
+	return route(c, ctx, "SortedSetFetchByScore", readRequest, r, CacheClient.SortedSetFetchByScore)
+}
+
+func (c *failoverCacheClient) SortedSetPutElement(ctx context.Context, r *SortedSetPutElementRequest) (responses.SortedSetPutElementResponse, error) {
+	return route(c, ctx, "SortedSetPutElement", replicatedWrite, r, CacheClient.SortedSetPutElement)
+}
+
+func (c *failoverCacheClient) SortedSetPutElements(ctx context.Context, r *SortedSetPutElementsRequest) (responses.SortedSetPutElementsResponse, error) {
+	return route(c, ctx, "SortedSetPutElements", replicatedWrite, r, CacheClient.SortedSetPutElements)
+}
+
+func (c *failoverCacheClient) SortedSetGetScore(ctx context.Context, r *SortedSetGetScoreRequest) (responses.SortedSetGetScoreResponse, error) {
+	return route(c, ctx, "SortedSetGetScore", readRequest, r, CacheClient.SortedSetGetScore)
+}
+
+func (c *failoverCacheClient) SortedSetGetScores(ctx context.Context, r *SortedSetGetScoresRequest) (responses.SortedSetGetScoresResponse, error) {
+	return route(c, ctx, "SortedSetGetScores", readRequest, r, CacheClient.SortedSetGetScores)
+}
+
+func (c *failoverCacheClient) SortedSetRemoveElement(ctx context.Context, r *SortedSetRemoveElementRequest) (responses.SortedSetRemoveElementResponse, error) {
+	return route(c, ctx, "SortedSetRemoveElement", replicatedWrite, r, CacheClient.SortedSetRemoveElement)
+}
+
+func (c *failoverCacheClient) SortedSetRemoveElements(ctx context.Context, r *SortedSetRemoveElementsRequest) (responses.SortedSetRemoveElementsResponse, error) {
+	return route(c, ctx, "SortedSetRemoveElements", replicatedWrite, r, CacheClient.SortedSetRemoveElements)
+}
+
+func (c *failoverCacheClient) SortedSetGetRank(ctx context.Context, r *SortedSetGetRankRequest) (responses.SortedSetGetRankResponse, error) {
+	return route(c, ctx, "SortedSetGetRank", readRequest, r, CacheClient.SortedSetGetRank)
+}
+
+func (c *failoverCacheClient) SortedSetLength(ctx context.Context, r *SortedSetLengthRequest) (responses.SortedSetLengthResponse, error) {
+	return route(c, ctx, "SortedSetLength", readRequest, r, CacheClient.SortedSetLength)
+}
+
+func (c *failoverCacheClient) SortedSetLengthByScore(ctx context.Context, r *SortedSetLengthByScoreRequest) (responses.SortedSetLengthByScoreResponse, error) {
+	return route(c, ctx, "SortedSetLengthByScore", readRequest, r, CacheClient.SortedSetLengthByScore)
+}
+
+func (c *failoverCacheClient) SortedSetIncrementScore(ctx context.Context, r *SortedSetIncrementScoreRequest) (responses.SortedSetIncrementScoreResponse, error) {
+	return route(c, ctx, "SortedSetIncrementScore", writeRequest, r, CacheClient.SortedSetIncrementScore)
+}
+
+func (c *failoverCacheClient) SetAddElement(ctx context.Context, r *SetAddElementRequest) (responses.SetAddElementResponse, error) {
+	return route(c, ctx, "SetAddElement", replicatedWrite, r, CacheClient.SetAddElement)
+}
+
+func (c *failoverCacheClient) SetAddElements(ctx context.Context, r *SetAddElementsRequest) (responses.SetAddElementsResponse, error) {
+	return route(c, ctx, "SetAddElements", replicatedWrite, r, CacheClient.SetAddElements)
+}
+
+func (c *failoverCacheClient) SetFetch(ctx context.Context, r *SetFetchRequest) (responses.SetFetchResponse, error) {
+	return route(c, ctx, "SetFetch", readRequest, r, CacheClient.SetFetch)
+}
+
+func (c *failoverCacheClient) SetLength(ctx context.Context, r *SetLengthRequest) (responses.SetLengthResponse, error) {
+	return route(c, ctx, "SetLength", readRequest, r, CacheClient.SetLength)
+}
+
+func (c *failoverCacheClient) SetRemoveElement(ctx context.Context, r *SetRemoveElementRequest) (responses.SetRemoveElementResponse, error) {
+	return route(c, ctx, "SetRemoveElement", replicatedWrite, r, CacheClient.SetRemoveElement)
+}
+
+func (c *failoverCacheClient) SetRemoveElements(ctx context.Context, r *SetRemoveElementsRequest) (responses.SetRemoveElementsResponse, error) {
+	return route(c, ctx, "SetRemoveElements", replicatedWrite, r, CacheClient.SetRemoveElements)
+}
+
+func (c *failoverCacheClient) SetContainsElements(ctx context.Context, r *SetContainsElementsRequest) (responses.SetContainsElementsResponse, error) {
+	return route(c, ctx, "SetContainsElements", readRequest, r, CacheClient.SetContainsElements)
+}
+
+func (c *failoverCacheClient) SetPop(ctx context.Context, r *SetPopRequest) (responses.SetPopResponse, error) {
+	return route(c, ctx, "SetPop", writeRequest, r, CacheClient.SetPop)
+}
+
+func (c *failoverCacheClient) ListPushFront(ctx context.Context, r *ListPushFrontRequest) (responses.ListPushFrontResponse, error) {
+	return route(c, ctx, "ListPushFront", writeRequest, r, CacheClient.ListPushFront)
+}
+
+func (c *failoverCacheClient) ListPushBack(ctx context.Context, r *ListPushBackRequest) (responses.ListPushBackResponse, error) {
+	return route(c, ctx, "ListPushBack", writeRequest, r, CacheClient.ListPushBack)
+}
+
+func (c *failoverCacheClient) ListPopFront(ctx context.Context, r *ListPopFrontRequest) (responses.ListPopFrontResponse, error) {
+	return route(c, ctx, "ListPopFront", writeRequest, r, CacheClient.ListPopFront)
+}
+
+func (c *failoverCacheClient) ListPopBack(ctx context.Context, r *ListPopBackRequest) (responses.ListPopBackResponse, error) {
+	return route(c, ctx, "ListPopBack", writeRequest, r, CacheClient.ListPopBack)
+}
+
+func (c *failoverCacheClient) ListConcatenateFront(ctx context.Context, r *ListConcatenateFrontRequest) (responses.ListConcatenateFrontResponse, error) {
+	return route(c, ctx, "ListConcatenateFront", writeRequest, r, CacheClient.ListConcatenateFront)
+}
+
+func (c *failoverCacheClient) ListConcatenateBack(ctx context.Context, r *ListConcatenateBackRequest) (responses.ListConcatenateBackResponse, error) {
+	return route(c, ctx, "ListConcatenateBack", writeRequest, r, CacheClient.ListConcatenateBack)
+}
+
+func (c *failoverCacheClient) ListFetch(ctx context.Context, r *ListFetchRequest) (responses.ListFetchResponse, error) {
+	return route(c, ctx, "ListFetch", readRequest, r, CacheClient.ListFetch)
+}
+
+func (c *failoverCacheClient) ListLength(ctx context.Context, r *ListLengthRequest) (responses.ListLengthResponse, error) {
+	return route(c, ctx, "ListLength", readRequest, r, CacheClient.ListLength)
+}
+
+func (c *failoverCacheClient) ListRemoveValue(ctx context.Context, r *ListRemoveValueRequest) (responses.ListRemoveValueResponse, error) {
+	return route(c, ctx, "ListRemoveValue", replicatedWrite, r, CacheClient.ListRemoveValue)
+}
+
+func (c *failoverCacheClient) DictionarySetField(ctx context.Context, r *DictionarySetFieldRequest) (responses.DictionarySetFieldResponse, error) {
+	return route(c, ctx, "DictionarySetField", replicatedWrite, r, CacheClient.DictionarySetField)
+}
+
+func (c *failoverCacheClient) DictionarySetFields(ctx context.Context, r *DictionarySetFieldsRequest) (responses.DictionarySetFieldsResponse, error) {
+	return route(c, ctx, "DictionarySetFields", replicatedWrite, r, CacheClient.DictionarySetFields)
+}
+
+func (c *failoverCacheClient) DictionaryFetch(ctx context.Context, r *DictionaryFetchRequest) (responses.DictionaryFetchResponse, error) {
+	return route(c, ctx, "DictionaryFetch", readRequest, r, CacheClient.DictionaryFetch)
+}
+
+func (c *failoverCacheClient) DictionaryLength(ctx context.Context, r *DictionaryLengthRequest) (responses.DictionaryLengthResponse, error) {
+	return route(c, ctx, "DictionaryLength", readRequest, r, CacheClient.DictionaryLength)
+}
+
+func (c *failoverCacheClient) DictionaryGetField(ctx context.Context, r *DictionaryGetFieldRequest) (responses.DictionaryGetFieldResponse, error) {
+	return route(c, ctx, "DictionaryGetField", readRequest, r, CacheClient.DictionaryGetField)
+}
+
+func (c *failoverCacheClient) DictionaryGetFields(ctx context.Context, r *DictionaryGetFieldsRequest) (responses.DictionaryGetFieldsResponse, error) {
+	return route(c, ctx, "DictionaryGetFields", readRequest, r, CacheClient.DictionaryGetFields)
+}
+
+func (c *failoverCacheClient) DictionaryIncrement(ctx context.Context, r *DictionaryIncrementRequest) (responses.DictionaryIncrementResponse, error) {
+	return route(c, ctx, "DictionaryIncrement", writeRequest, r, CacheClient.DictionaryIncrement)
+}
+
+func (c *failoverCacheClient) DictionaryRemoveField(ctx context.Context, r *DictionaryRemoveFieldRequest) (responses.DictionaryRemoveFieldResponse, error) {
+	return route(c, ctx, "DictionaryRemoveField", replicatedWrite, r, CacheClient.DictionaryRemoveField)
+}
+
+func (c *failoverCacheClient) DictionaryRemoveFields(ctx context.Context, r *DictionaryRemoveFieldsRequest) (responses.DictionaryRemoveFieldsResponse, error) {
+	return route(c, ctx, "DictionaryRemoveFields", replicatedWrite, r, CacheClient.DictionaryRemoveFields)
+}
+
+func (c *failoverCacheClient) UpdateTtl(ctx context.Context, r *UpdateTtlRequest) (responses.UpdateTtlResponse, error) {
+	return route(c, ctx, "UpdateTtl", replicatedWrite, r, CacheClient.UpdateTtl)
+}
+
+func (c *failoverCacheClient) IncreaseTtl(ctx context.Context, r *IncreaseTtlRequest) (responses.IncreaseTtlResponse, error) {
+	return route(c, ctx, "IncreaseTtl", replicatedWrite, r, CacheClient.IncreaseTtl)
+}
+
+func (c *failoverCacheClient) DecreaseTtl(ctx context.Context, r *DecreaseTtlRequest) (responses.DecreaseTtlResponse, error) {
+	return route(c, ctx, "DecreaseTtl", replicatedWrite, r, CacheClient.DecreaseTtl)
+}
@@ -0,0 +1,355 @@
+package momento_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/momentohq/client-sdk-go/config/health"
+	"github.com/momentohq/client-sdk-go/config/logger"
+	. "github.com/momentohq/client-sdk-go/momento"
+	"github.com/momentohq/client-sdk-go/responses"
+)
+
+// fakeRegionClient is a regional CacheClient whose failures are controlled by the test. Only the methods
+// used by the tests are implemented.
+type fakeRegionClient struct {
+	CacheClient
+	mutex   sync.Mutex
+	err     error
+	state   health.State
+	latency time.Duration
+	values  map[string]string
+	gets    int
+	// increments counts the Increment requests served.
+	increments int
+	// setDelay, if set, returns how long setting a value takes.
+	setDelay func(value string) time.Duration
+	closed   bool
+}
+
+func newFakeRegionClient() *fakeRegionClient {
+	return &fakeRegionClient{state: health.StateHealthy, values: map[string]string{}}
+}
+
+func (f *fakeRegionClient) fail(err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.err = err
+}
+
+func (f *fakeRegionClient) setState(state health.State) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.state = state
+}
+
+func (f *fakeRegionClient) value(key string) string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.values[key]
+}
+
+func (f *fakeRegionClient) getCount() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.gets
+}
+
+func (f *fakeRegionClient) Logger() logger.MomentoLogger {
+	return logger.NewNoopMomentoLoggerFactory().GetLogger("fake")
+}
+
+func (f *fakeRegionClient) Get(ctx context.Context, r *GetRequest) (responses.GetResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.gets++
+	if f.err != nil {
+		return nil, f.err
+	}
+	value, ok := f.values[string(r.Key.(String))]
+	if !ok {
+		return &responses.GetMiss{}, nil
+	}
+	return responses.NewGetHit([]byte(value)), nil
+}
+
+func (f *fakeRegionClient) Set(ctx context.Context, r *SetRequest) (responses.SetResponse, error) {
+	if f.setDelay != nil {
+		time.Sleep(f.setDelay(string(r.Value.(String))))
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.values[string(r.Key.(String))] = string(r.Value.(String))
+	return &responses.SetSuccess{}, nil
+}
+
+func (f *fakeRegionClient) Increment(ctx context.Context, r *IncrementRequest) (responses.IncrementResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.increments++
+	return responses.NewIncrementSuccess(int64(f.increments)), nil
+}
+
+func (f *fakeRegionClient) incrementCount() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.increments
+}
+
+func (f *fakeRegionClient) Ping(ctx context.Context) (responses.PingResponse, error) {
+	time.Sleep(f.latency)
+	return &responses.PingSuccess{}, nil
+}
+
+func (f *fakeRegionClient) Health() health.Health {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return health.Health{State: f.state}
+}
+
+func (f *fakeRegionClient) Shutdown(ctx context.Context) (ShutdownSummary, error) {
+	f.Close()
+	return ShutdownSummary{AbandonedOperations: map[string]int{}}, nil
+}
+
+func (f *fakeRegionClient) Close() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.closed = true
+}
+
+var _ = Describe("failover-cache-client", func() {
+	var (
+		primary   *fakeRegionClient
+		secondary *fakeRegionClient
+		events    chan FailoverEvent
+		props     FailoverCacheClientProps
+	)
+	unavailable := NewMomentoError(ServerUnavailableError, "unavailable", nil)
+
+	BeforeEach(func() {
+		primary = newFakeRegionClient()
+		secondary = newFakeRegionClient()
+		events = make(chan FailoverEvent, 10)
+		props = FailoverCacheClientProps{
+			Regions: []FailoverRegion{
+				{Name: "us-west-2", Client: primary},
+				{Name: "us-east-1", Client: secondary},
+			},
+			OnFailover: func(event FailoverEvent) { events <- event },
+		}
+	})
+
+	get := func(client CacheClient) (responses.GetResponse, error) {
+		return client.Get(context.Background(), &GetRequest{CacheName: "cache", Key: String("key")})
+	}
+	set := func(client CacheClient, value string) (responses.SetResponse, error) {
+		return client.Set(context.Background(), &SetRequest{CacheName: "cache", Key: String("key"), Value: String(value)})
+	}
+
+	It("validates the regions", func() {
+		Expect(NewFailoverCacheClient(FailoverCacheClientProps{})).Error().To(HaveMomentoErrorCode(InvalidArgumentError))
+		props.Regions[1].Name = "us-west-2"
+		Expect(NewFailoverCacheClient(props)).Error().To(HaveMomentoErrorCode(InvalidArgumentError))
+	})
+
+	It("sends requests to the primary region", func() {
+		client, err := NewFailoverCacheClient(props)
+		Expect(err).To(BeNil())
+		Expect(set(client, "value")).To(BeAssignableToTypeOf(&responses.SetSuccess{}))
+		Expect(primary.value("key")).To(Equal("value"))
+		Expect(secondary.value("key")).To(Equal(""))
+		Expect(client.ActiveRegion()).To(Equal("us-west-2"))
+	})
+
+	It("fails over on connectivity errors and stays failed over", func() {
+		client, err := NewFailoverCacheClient(props)
+		Expect(err).To(BeNil())
+		primary.fail(unavailable)
+
+		Expect(set(client, "value")).To(BeAssignableToTypeOf(&responses.SetSuccess{}))
+		Expect(secondary.value("key")).To(Equal("value"))
+		Expect(client.ActiveRegion()).To(Equal("us-east-1"))
+		Expect(events).To(Receive(Equal(FailoverEvent{From: "us-west-2", To: "us-east-1", Err: unavailable})))
+
+		Expect(get(client)).To(BeAssignableToTypeOf(&responses.GetHit{}))
+		Expect(primary.getCount()).To(Equal(0))
+	})
+
+	It("does not fail over on errors caused by the request", func() {
+		client, err := NewFailoverCacheClient(props)
+		Expect(err).To(BeNil())
+		primary.fail(NewMomentoError(InvalidArgumentError, "bad key", nil))
+		Expect(get(client)).Error().To(HaveMomentoErrorCode(InvalidArgumentError))
+		Expect(secondary.getCount()).To(Equal(0))
+		Expect(client.ActiveRegion()).To(Equal("us-west-2"))
+	})
+
+	It("returns the last error when every region fails", func() {
+		client, err := NewFailoverCacheClient(props)
+		Expect(err).To(BeNil())
+		primary.fail(unavailable)
+		secondary.fail(NewMomentoError(TimeoutError, "timeout", nil))
+		Expect(get(client)).Error().To(HaveMomentoErrorCode(TimeoutError))
+		Expect(client.ActiveRegion()).To(Equal("us-west-2"))
+	})
+
+	It("does not fail over writes that time out", func() {
+		client, err := NewFailoverCacheClient(props)
+		Expect(err).To(BeNil())
+		primary.fail(NewMomentoError(TimeoutError, "timeout", nil))
+
+		Expect(set(client, "value")).Error().To(HaveMomentoErrorCode(TimeoutError))
+		Expect(secondary.value("key")).To(Equal(""))
+		Expect(client.ActiveRegion()).To(Equal("us-west-2"))
+
+		// Reads that time out are failed over, as sending them again changes nothing.
+		Expect(get(client)).To(BeAssignableToTypeOf(&responses.GetMiss{}))
+		Expect(secondary.getCount()).To(Equal(1))
+	})
+
+	It("fails over when the health monitor reports the active region unhealthy", func() {
+		client, err := NewFailoverCacheClient(props)
+		Expect(err).To(BeNil())
+		primary.setState(health.StateUnhealthy)
+		Expect(get(client)).To(BeAssignableToTypeOf(&responses.GetMiss{}))
+		Expect(primary.getCount()).To(Equal(0))
+		Expect(client.ActiveRegion()).To(Equal("us-east-1"))
+		Expect(events).To(Receive(HaveField("To", "us-east-1")))
+	})
+
+	It("treats regions that do not report their health as healthy", func() {
+		// The wrapper hides the fake's Health, as a mock of CacheClient would not have one.
+		props.Regions[0].Client = struct{ CacheClient }{primary}
+		client, err := NewFailoverCacheClient(props)
+		Expect(err).To(BeNil())
+		Expect(Health(client).State).To(Equal(health.StateUnknown))
+		Expect(get(client)).To(BeAssignableToTypeOf(&responses.GetMiss{}))
+		Expect(primary.getCount()).To(Equal(1))
+
+		props.Regions[0].Client = primary
+		client, err = NewFailoverCacheClient(props)
+		Expect(err).To(BeNil())
+		Expect(Health(client).State).To(Equal(health.StateHealthy))
+	})
+
+	It("fails back to the primary region", func() {
+		props.FailbackAfter = 20 * time.Millisecond
+		client, err := NewFailoverCacheClient(props)
+		Expect(err).To(BeNil())
+		primary.fail(unavailable)
+		Expect(get(client)).Error().To(BeNil())
+		Expect(client.ActiveRegion()).To(Equal("us-east-1"))
+
+		primary.fail(nil)
+		time.Sleep(30 * time.Millisecond)
+		Expect(get(client)).Error().To(BeNil())
+		Expect(client.ActiveRegion()).To(Equal("us-west-2"))
+		Expect(events).To(Receive())
+		Expect(events).To(Receive(Equal(FailoverEvent{From: "us-east-1", To: "us-west-2"})))
+	})
+
+	It("sends reads to the nearest region", func() {
+		primary.latency = 30 * time.Millisecond
+		props.ReadFromNearest = true
+		client, err := NewFailoverCacheClient(props)
+		Expect(err).To(BeNil())
+		defer client.Close()
+
+		Eventually(func() int {
+			_, _ = get(client)
+			return secondary.getCount()
+		}).Should(BeNumerically(">", 0))
+		Expect(set(client, "value")).To(BeAssignableToTypeOf(&responses.SetSuccess{}))
+		Expect(primary.value("key")).To(Equal("value"))
+	})
+
+	Describe("replication", func() {
+		It("replicates writes synchronously", func() {
+			props.Replication = ReplicationSync
+			client, err := NewFailoverCacheClient(props)
+			Expect(err).To(BeNil())
+			Expect(set(client, "value")).To(BeAssignableToTypeOf(&responses.SetSuccess{}))
+			Expect(primary.value("key")).To(Equal("value"))
+			Expect(secondary.value("key")).To(Equal("value"))
+		})
+
+		It("replicates writes asynchronously and reports failures", func() {
+			replicationErrors := make(chan ReplicationError, 1)
+			props.Replication = ReplicationAsync
+			props.OnReplicationError = func(err ReplicationError) { replicationErrors <- err }
+			client, err := NewFailoverCacheClient(props)
+			Expect(err).To(BeNil())
+
+			Expect(set(client, "value")).To(BeAssignableToTypeOf(&responses.SetSuccess{}))
+			Eventually(func() string { return secondary.value("key") }).Should(Equal("value"))
+
+			secondary.fail(unavailable)
+			Expect(set(client, "other")).To(BeAssignableToTypeOf(&responses.SetSuccess{}))
+			Eventually(replicationErrors).Should(Receive(Equal(ReplicationError{
+				Region: "us-east-1", Operation: "Set", Err: unavailable,
+			})))
+		})
+
+		It("replicates the writes to an item in order", func() {
+			props.Replication = ReplicationAsync
+			// The first write is replicated slowly, and the second must wait for it.
+			secondary.setDelay = func(value string) time.Duration {
+				if value == "first" {
+					return 100 * time.Millisecond
+				}
+				return 0
+			}
+			client, err := NewFailoverCacheClient(props)
+			Expect(err).To(BeNil())
+
+			Expect(set(client, "first")).To(BeAssignableToTypeOf(&responses.SetSuccess{}))
+			Expect(set(client, "second")).To(BeAssignableToTypeOf(&responses.SetSuccess{}))
+			Eventually(func() string { return secondary.value("key") }).Should(Equal("second"))
+			Consistently(func() string { return secondary.value("key") }, 200*time.Millisecond).Should(Equal("second"))
+		})
+
+		It("does not replicate writes that cannot be applied again", func() {
+			props.Replication = ReplicationSync
+			client, err := NewFailoverCacheClient(props)
+			Expect(err).To(BeNil())
+
+			resp, err := client.Increment(context.Background(), &IncrementRequest{
+				CacheName: "cache", Field: String("counter"), Amount: 1,
+			})
+			Expect(err).To(BeNil())
+			Expect(resp).To(Equal(responses.NewIncrementSuccess(1)))
+			Expect(primary.incrementCount()).To(Equal(1))
+			Expect(secondary.incrementCount()).To(Equal(0))
+		})
+
+		It("does not replicate reads", func() {
+			props.Replication = ReplicationSync
+			client, err := NewFailoverCacheClient(props)
+			Expect(err).To(BeNil())
+			Expect(get(client)).Error().To(BeNil())
+			Expect(secondary.getCount()).To(Equal(0))
+		})
+	})
+
+	It("shuts down every region", func() {
+		client, err := NewFailoverCacheClient(props)
+		Expect(err).To(BeNil())
+		summary, err := Shutdown(context.Background(), client)
+		Expect(err).To(BeNil())
+		Expect(summary.Abandoned()).To(Equal(0))
+		Expect(primary.closed).To(BeTrue())
+		Expect(secondary.closed).To(BeTrue())
+		Expect(get(client)).Error().To(HaveMomentoErrorCode(ClientClosedError))
+	})
+})
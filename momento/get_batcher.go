@@ -0,0 +1,211 @@
+package momento
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config/batching"
+	"github.com/momentohq/client-sdk-go/config/middleware"
+	"github.com/momentohq/client-sdk-go/responses"
+)
+
+// getBatcher collects concurrent Get requests to the same cache and sends them as a single GetBatch request.
+type getBatcher struct {
+	props batching.Props
+	// sendBatch sends a GetBatch request on behalf of the batched Get requests.
+	sendBatch func(ctx context.Context, r *GetBatchRequest) (interface{}, error)
+	mutex     sync.Mutex
+	pending   map[getBatchKey]*pendingGetBatch
+}
+
+// getBatchKey identifies the Get requests that may be batched together.
+type getBatchKey struct {
+	cacheName string
+	partition string
+}
+
+type pendingGetBatch struct {
+	key getBatchKey
+	// ctx is the context of the first request in the batch, whose values are used to send it.
+	ctx      context.Context
+	keys     [][]byte
+	waiters  map[string][]*getWaiter
+	requests int
+	timer    *time.Timer
+}
+
+type getWaiter struct {
+	ctx    context.Context
+	result chan getResult
+}
+
+type getResult struct {
+	resp responses.GetResponse
+	err  error
+}
+
+func newGetBatcher(props batching.Props, sendBatch func(ctx context.Context, r *GetBatchRequest) (interface{}, error)) *getBatcher {
+	return &getBatcher{
+		props:     props.WithDefaults(),
+		sendBatch: sendBatch,
+		pending:   make(map[getBatchKey]*pendingGetBatch),
+	}
+}
+
+// middlewareBatchingConflict returns why Gets cannot be batched with the given middleware, or an empty
+// string if they can. A batch is sent with the context of its first Get, so unless props partitions the
+// Gets, middleware that reads the context, such as a namespace middleware, would apply that Get's values
+// to every Get in the batch. Middleware that handles Gets but not GetBatches would be skipped for batched
+// Gets.
+func middlewareBatchingConflict(props batching.Props, middlewares []middleware.Middleware) string {
+	getType := reflect.TypeOf(&GetRequest{}).String()
+	getBatchType := reflect.TypeOf(&GetBatchRequest{}).String()
+	for _, mw := range middlewares {
+		if _, ok := mw.(middleware.ContextMiddleware); ok && props.Partition == nil {
+			return fmt.Sprintf("middleware %T reads the context of each request", mw)
+		}
+		if includeTypes := mw.GetIncludeTypes(); includeTypes != nil && includeTypes[getType] && !includeTypes[getBatchType] {
+			return fmt.Sprintf("middleware %T handles Get requests but not GetBatch requests", mw)
+		}
+	}
+	return ""
+}
+
+// get adds a Get request to a batch and waits for its response or for ctx to be done. The request must
+// already have been validated.
+func (b *getBatcher) get(ctx context.Context, r *GetRequest, key []byte) (responses.GetResponse, error) {
+	batchKey := getBatchKey{cacheName: r.CacheName}
+	if b.props.Partition != nil {
+		batchKey.partition = b.props.Partition(ctx)
+	}
+	waiter := &getWaiter{ctx: ctx, result: make(chan getResult, 1)}
+
+	b.mutex.Lock()
+	batch, ok := b.pending[batchKey]
+	if !ok {
+		batch = &pendingGetBatch{key: batchKey, ctx: ctx, waiters: make(map[string][]*getWaiter)}
+		b.pending[batchKey] = batch
+		batch.timer = time.AfterFunc(b.props.Window, func() { b.flush(batch) })
+	}
+	if _, ok := batch.waiters[string(key)]; !ok {
+		batch.keys = append(batch.keys, key)
+	}
+	batch.waiters[string(key)] = append(batch.waiters[string(key)], waiter)
+	batch.requests++
+	full := len(batch.keys) >= b.props.MaxBatchSize
+	if full {
+		delete(b.pending, batchKey)
+		batch.timer.Stop()
+	}
+	b.mutex.Unlock()
+
+	if full {
+		go b.send(batch, batching.TriggerSize)
+	}
+
+	select {
+	case result := <-waiter.result:
+		return result.resp, result.err
+	case <-ctx.Done():
+		return nil, contextError(ctx)
+	}
+}
+
+// flush sends a batch whose window has elapsed, unless it was already sent because it was full.
+func (b *getBatcher) flush(batch *pendingGetBatch) {
+	b.mutex.Lock()
+	if b.pending[batch.key] != batch {
+		b.mutex.Unlock()
+		return
+	}
+	delete(b.pending, batch.key)
+	b.mutex.Unlock()
+	b.send(batch, batching.TriggerWindow)
+}
+
+// send sends a batch and delivers each key's response to the requests waiting for it. Keys whose requests
+// have all given up are left out.
+func (b *getBatcher) send(batch *pendingGetBatch, trigger batching.Trigger) {
+	keys := make([]Value, 0, len(batch.keys))
+	requests := 0
+	for _, key := range batch.keys {
+		waiting := 0
+		for _, waiter := range batch.waiters[string(key)] {
+			if waiter.ctx.Err() == nil {
+				waiting++
+			}
+		}
+		if waiting > 0 {
+			keys = append(keys, Bytes(key))
+			requests += waiting
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	start := time.Now()
+	resp, err := b.sendBatch(detachedContext{parent: batch.ctx}, &GetBatchRequest{CacheName: batch.key.cacheName, Keys: keys})
+	var results []responses.GetResponse
+	if err == nil {
+		switch r := resp.(type) {
+		case responses.GetBatchSuccess:
+			results = r.Results()
+		case *responses.GetBatchSuccess:
+			results = r.Results()
+		}
+		if len(results) != len(keys) {
+			err = NewMomentoError(
+				UnknownServiceError,
+				fmt.Sprintf("GetBatch returned %d results for %d keys", len(results), len(keys)),
+				nil,
+			)
+		}
+	}
+
+	for i, key := range keys {
+		result := getResult{err: err}
+		if err == nil {
+			result.resp = results[i]
+		}
+		for _, waiter := range batch.waiters[string(key.asBytes())] {
+			waiter.result <- result
+		}
+	}
+
+	if b.props.OnBatch != nil {
+		b.props.OnBatch(batching.Batch{
+			CacheName: batch.key.cacheName,
+			Keys:      len(keys),
+			Requests:  requests,
+			Trigger:   trigger,
+			Latency:   time.Since(start),
+			Err:       err,
+		})
+	}
+}
+
+// contextError returns the error for a request whose context is done.
+func contextError(ctx context.Context) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return NewMomentoError(TimeoutError, "context deadline exceeded while waiting for a batched response", ctx.Err())
+	}
+	return NewMomentoError(CanceledError, "context was cancelled while waiting for a batched response", ctx.Err())
+}
+
+// detachedContext carries the values of its parent but not its deadline or cancellation, so that a batch is
+// not cancelled when the request that started it gives up.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (c detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (c detachedContext) Done() <-chan struct{} { return nil }
+
+func (c detachedContext) Err() error { return nil }
+
+func (c detachedContext) Value(key any) any { return c.parent.Value(key) }
@@ -0,0 +1,198 @@
+package momento
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/momentohq/client-sdk-go/config/batching"
+	"github.com/momentohq/client-sdk-go/responses"
+)
+
+// fakeBatchSender records the GetBatch requests of a getBatcher and answers them with respond, or with a
+// hit whose value is the key if respond is nil.
+type fakeBatchSender struct {
+	mutex    sync.Mutex
+	requests []*GetBatchRequest
+	respond  func(r *GetBatchRequest) (interface{}, error)
+}
+
+func (s *fakeBatchSender) sendBatch(_ context.Context, r *GetBatchRequest) (interface{}, error) {
+	s.mutex.Lock()
+	s.requests = append(s.requests, r)
+	s.mutex.Unlock()
+	if s.respond != nil {
+		return s.respond(r)
+	}
+	results := make([]responses.GetResponse, 0, len(r.Keys))
+	keys := make([][]byte, 0, len(r.Keys))
+	for _, key := range r.Keys {
+		results = append(results, responses.NewGetHit(key.asBytes()))
+		keys = append(keys, key.asBytes())
+	}
+	return *responses.NewGetBatchSuccess(results, keys), nil
+}
+
+// batchKeys returns the keys of each GetBatch request sent so far.
+func (s *fakeBatchSender) batchKeys() [][]string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	batches := make([][]string, 0, len(s.requests))
+	for _, r := range s.requests {
+		keys := make([]string, 0, len(r.Keys))
+		for _, key := range r.Keys {
+			keys = append(keys, string(key.asBytes()))
+		}
+		batches = append(batches, keys)
+	}
+	return batches
+}
+
+type getOutcome struct {
+	resp responses.GetResponse
+	err  error
+}
+
+// getConcurrently sends a Get request for each key at the same time and returns their outcomes in order.
+func getConcurrently(b *getBatcher, keys ...string) []getOutcome {
+	outcomes := make([]getOutcome, len(keys))
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func(i int, key string) {
+			defer wg.Done()
+			resp, err := b.get(context.Background(), &GetRequest{CacheName: "cache", Key: String(key)}, []byte(key))
+			outcomes[i] = getOutcome{resp: resp, err: err}
+		}(i, key)
+	}
+	wg.Wait()
+	return outcomes
+}
+
+func errorCode(err error) string {
+	if momentoErr, ok := err.(MomentoError); ok {
+		return momentoErr.Code()
+	}
+	return ""
+}
+
+var _ = Describe("get-batcher", func() {
+	var (
+		sender *fakeBatchSender
+		// batches receives the batches reported to OnBatch.
+		batches chan batching.Batch
+	)
+
+	newBatcher := func(props batching.Props) *getBatcher {
+		props.OnBatch = func(batch batching.Batch) { batches <- batch }
+		return newGetBatcher(props, sender.sendBatch)
+	}
+
+	BeforeEach(func() {
+		sender = &fakeBatchSender{}
+		batches = make(chan batching.Batch, 10)
+	})
+
+	It("sends the requests of a window as one batch", func() {
+		b := newBatcher(batching.Props{Window: 100 * time.Millisecond})
+
+		outcomes := getConcurrently(b, "a", "b", "c")
+		for i, key := range []string{"a", "b", "c"} {
+			Expect(outcomes[i].err).To(BeNil())
+			Expect(outcomes[i].resp.(*responses.GetHit).ValueString()).To(Equal(key))
+		}
+		Expect(sender.batchKeys()).To(HaveLen(1))
+		Expect(sender.batchKeys()[0]).To(ConsistOf("a", "b", "c"))
+
+		var batch batching.Batch
+		Eventually(batches).Should(Receive(&batch))
+		Expect(batch.Trigger).To(Equal(batching.TriggerWindow))
+		Expect(batch.Keys).To(Equal(3))
+	})
+
+	It("sends a batch as soon as it is full", func() {
+		b := newBatcher(batching.Props{Window: time.Hour, MaxBatchSize: 2})
+
+		outcomes := getConcurrently(b, "a", "b")
+		Expect(outcomes[0].err).To(BeNil())
+		Expect(outcomes[1].err).To(BeNil())
+
+		var batch batching.Batch
+		Eventually(batches).Should(Receive(&batch))
+		Expect(batch.Trigger).To(Equal(batching.TriggerSize))
+		Expect(batch.Keys).To(Equal(2))
+	})
+
+	It("fans the response for a key out to every request for it", func() {
+		b := newBatcher(batching.Props{Window: 100 * time.Millisecond})
+
+		outcomes := getConcurrently(b, "a", "a", "b", "a")
+		for i, key := range []string{"a", "a", "b", "a"} {
+			Expect(outcomes[i].err).To(BeNil())
+			Expect(outcomes[i].resp.(*responses.GetHit).ValueString()).To(Equal(key))
+		}
+		Expect(sender.batchKeys()).To(HaveLen(1))
+		Expect(sender.batchKeys()[0]).To(ConsistOf("a", "b"))
+
+		var batch batching.Batch
+		Eventually(batches).Should(Receive(&batch))
+		Expect(batch.Keys).To(Equal(2))
+		Expect(batch.Requests).To(Equal(4))
+	})
+
+	It("leaves out keys whose requests have given up", func() {
+		b := newBatcher(batching.Props{Window: 50 * time.Millisecond})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := b.get(ctx, &GetRequest{CacheName: "cache", Key: String("gone")}, []byte("gone"))
+		Expect(errorCode(err)).To(Equal(CanceledError))
+
+		outcomes := getConcurrently(b, "kept")
+		Expect(outcomes[0].err).To(BeNil())
+		Expect(sender.batchKeys()).To(Equal([][]string{{"kept"}}))
+	})
+
+	It("does not send a batch whose requests have all given up", func() {
+		b := newBatcher(batching.Props{Window: 10 * time.Millisecond})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := b.get(ctx, &GetRequest{CacheName: "cache", Key: String("gone")}, []byte("gone"))
+		Expect(errorCode(err)).To(Equal(CanceledError))
+		Consistently(sender.batchKeys, 50*time.Millisecond).Should(BeEmpty())
+	})
+
+	It("fails every request in the batch with the batch's error", func() {
+		sender.respond = func(*GetBatchRequest) (interface{}, error) {
+			return nil, NewMomentoError(ServerUnavailableError, "unavailable", nil)
+		}
+		b := newBatcher(batching.Props{Window: 100 * time.Millisecond})
+
+		outcomes := getConcurrently(b, "a", "b", "a")
+		for _, outcome := range outcomes {
+			Expect(errorCode(outcome.err)).To(Equal(ServerUnavailableError))
+		}
+
+		var batch batching.Batch
+		Eventually(batches).Should(Receive(&batch))
+		Expect(errorCode(batch.Err)).To(Equal(ServerUnavailableError))
+	})
+
+	It("fails the batch when the number of results does not match its keys", func() {
+		sender.respond = func(*GetBatchRequest) (interface{}, error) {
+			return *responses.NewGetBatchSuccess(
+				[]responses.GetResponse{responses.NewGetHit([]byte("a"))}, [][]byte{[]byte("a")},
+			), nil
+		}
+		b := newBatcher(batching.Props{Window: 100 * time.Millisecond})
+
+		outcomes := getConcurrently(b, "a", "b")
+		for _, outcome := range outcomes {
+			Expect(errorCode(outcome.err)).To(Equal(UnknownServiceError))
+		}
+	})
+})
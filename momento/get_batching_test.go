@@ -0,0 +1,141 @@
+package momento_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/config/batching"
+	"github.com/momentohq/client-sdk-go/config/middleware"
+	"github.com/momentohq/client-sdk-go/config/middleware/impl"
+	"github.com/momentohq/client-sdk-go/config/retry"
+	pb "github.com/momentohq/client-sdk-go/internal/protos"
+	. "github.com/momentohq/client-sdk-go/momento"
+	helpers "github.com/momentohq/client-sdk-go/momento/test_helpers"
+	"github.com/momentohq/client-sdk-go/responses"
+)
+
+var _ = Describe("get-batching", func() {
+	const window = 100 * time.Millisecond
+
+	var (
+		server *helpers.FakeCacheServer
+		// gets and getBatches count the Get and GetBatch requests the server has received.
+		gets       atomic.Int32
+		getBatches atomic.Int32
+	)
+
+	// hit answers a Get with the key the server received as its value.
+	hit := func(key []byte) *pb.XGetResponse {
+		return &pb.XGetResponse{Result: pb.ECacheResult_Hit, CacheBody: key}
+	}
+
+	newClient := func(batchingProps batching.Props, middlewares ...middleware.Middleware) CacheClient {
+		credentialProvider, err := server.CredentialProvider()
+		Expect(err).To(BeNil())
+		client, err := NewCacheClient(
+			config.LaptopLatest().WithAutoBatching(batchingProps).WithMiddleware(middlewares),
+			credentialProvider,
+			time.Minute,
+		)
+		Expect(err).To(BeNil())
+		DeferCleanup(client.Close)
+		return client
+	}
+
+	// getConcurrently sends a Get request for key with each context at the same time and returns the values
+	// of their hits in order.
+	getConcurrently := func(client CacheClient, key string, contexts ...context.Context) []string {
+		values := make([]string, len(contexts))
+		var wg sync.WaitGroup
+		for i, ctx := range contexts {
+			wg.Add(1)
+			go func(i int, ctx context.Context) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				resp, err := client.Get(ctx, &GetRequest{CacheName: "cache", Key: String(key)})
+				Expect(err).To(BeNil())
+				values[i] = resp.(*responses.GetHit).ValueString()
+			}(i, ctx)
+		}
+		wg.Wait()
+		return values
+	}
+
+	BeforeEach(func() {
+		gets.Store(0)
+		getBatches.Store(0)
+		var err error
+		server, err = helpers.NewFakeCacheServer(helpers.FakeCacheServerProps{
+			OnGet: func(_ context.Context, req *pb.XGetRequest) (*pb.XGetResponse, error) {
+				gets.Add(1)
+				return hit(req.CacheKey), nil
+			},
+			OnGetBatch: func(_ context.Context, req *pb.XGetBatchRequest) ([]*pb.XGetResponse, error) {
+				getBatches.Add(1)
+				results := make([]*pb.XGetResponse, 0, len(req.Items))
+				for _, item := range req.Items {
+					results = append(results, hit(item.CacheKey))
+				}
+				return results, nil
+			},
+		})
+		Expect(err).To(BeNil())
+		DeferCleanup(server.Close)
+	})
+
+	It("batches concurrent Gets", func() {
+		client := newClient(batching.Props{Window: window})
+		Expect(getConcurrently(client, "key", context.Background(), context.Background())).To(Equal([]string{"key", "key"}))
+		Expect(getBatches.Load()).To(Equal(int32(1)))
+		Expect(gets.Load()).To(BeZero())
+	})
+
+	It("sends Gets from different namespaces with their own namespace", func() {
+		client := newClient(batching.Props{Window: window}, impl.NewNamespaceMiddleware(impl.NamespaceMiddlewareProps{}))
+		values := getConcurrently(client, "key",
+			impl.WithNamespace(context.Background(), "tenant-a"),
+			impl.WithNamespace(context.Background(), "tenant-b"),
+		)
+		Expect(values).To(Equal([]string{"tenant-a:key", "tenant-b:key"}))
+		Expect(getBatches.Load()).To(BeZero())
+	})
+
+	It("batches Gets from different namespaces separately when partitioned by namespace", func() {
+		client := newClient(
+			batching.Props{Window: window, Partition: impl.NamespaceFromContext},
+			impl.NewNamespaceMiddleware(impl.NamespaceMiddlewareProps{}),
+		)
+		values := getConcurrently(client, "key",
+			impl.WithNamespace(context.Background(), "tenant-a"),
+			impl.WithNamespace(context.Background(), "tenant-b"),
+			impl.WithNamespace(context.Background(), "tenant-a"),
+		)
+		Expect(values).To(Equal([]string{"tenant-a:key", "tenant-b:key", "tenant-a:key"}))
+		Expect(getBatches.Load()).To(Equal(int32(2)))
+		Expect(gets.Load()).To(BeZero())
+	})
+
+	It("does not batch Gets for middleware that handles Gets but not GetBatches", func() {
+		client := newClient(
+			batching.Props{Window: window},
+			impl.NewInFlightRequestCountMiddleware(middleware.Props{IncludeTypes: []interface{}{GetRequest{}}}),
+		)
+		Expect(getConcurrently(client, "key", context.Background(), context.Background())).To(Equal([]string{"key", "key"}))
+		Expect(gets.Load()).To(Equal(int32(2)))
+		Expect(getBatches.Load()).To(BeZero())
+	})
+
+	It("does not batch Gets with a retry observer of their own", func() {
+		client := newClient(batching.Props{Window: window})
+		ctx := retry.WithOnRetry(context.Background(), func(retry.RetryEvent) {})
+		Expect(getConcurrently(client, "key", ctx, ctx)).To(Equal([]string{"key", "key"}))
+		Expect(gets.Load()).To(Equal(int32(2)))
+		Expect(getBatches.Load()).To(BeZero())
+	})
+})
@@ -0,0 +1,98 @@
+package momento
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config/retry"
+)
+
+// gRPC methods of the requests that may be hedged. Whether they are hedged is decided by the retry
+// eligibility rules, so that only idempotent requests are ever sent twice.
+const (
+	getMethod           = "/cache_client.Scs/Get"
+	getBatchMethod      = "/cache_client.Scs/GetBatch"
+	dictionaryGetMethod = "/cache_client.Scs/DictionaryGet"
+)
+
+type hedgeResult struct {
+	resp interface{}
+	err  error
+}
+
+// makeReadRequest sends a read request, hedging it if hedging is enabled and the request is idempotent.
+// A hedged request is sent on one data client and, if it has not completed after the hedging delay and the
+// hedge budget allows, sent again on a different data client. The first successful response is returned
+// and the other request is cancelled. If the request fails before the delay it is not hedged, as failures
+// are handled by the retry strategy.
+func (c defaultScsClient) makeReadRequest(ctx context.Context, r requester, grpcMethod string) (interface{}, error) {
+	if c.hedger == nil || !retry.IsIdempotentMethod(grpcMethod) {
+		return c.getNextDataClient().makeRequest(ctx, r)
+	}
+
+	primary := c.getNextDataClient()
+	start := time.Now()
+	delay, ok := c.hedger.Delay()
+	if !ok {
+		resp, err := primary.makeRequest(ctx, r)
+		if err == nil {
+			c.hedger.RecordLatency(time.Since(start))
+		}
+		return resp, err
+	}
+
+	// The hedge needs its own copy of the request, as sending a request stores state on it. The copy is
+	// taken before the primary request is sent so that it does not race with it.
+	hedgeRequest := copyRequester(r)
+	hedgeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// The channel is buffered so that the request that loses the race does not block once it is cancelled.
+	results := make(chan hedgeResult, 2)
+	send := func(client *scsDataClient, request requester) {
+		resp, err := client.makeRequest(hedgeCtx, request)
+		results <- hedgeResult{resp: resp, err: err}
+	}
+	go send(primary, r)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	pending := 1
+	var firstErr error
+	for {
+		select {
+		case <-timer.C:
+			if !c.hedger.TryAcquire() {
+				continue
+			}
+			c.logger.Debug("Hedging %s request to cache %s after %s", r.requestName(), r.cacheName(), delay)
+			pending++
+			go send(c.getOtherDataClient(primary), hedgeRequest)
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				c.hedger.RecordLatency(time.Since(start))
+				return result.resp, nil
+			}
+			if firstErr == nil {
+				firstErr = result.err
+			}
+			if pending == 0 {
+				return nil, firstErr
+			}
+		}
+	}
+}
+
+// getOtherDataClient returns the next data client that is not client.
+func (c defaultScsClient) getOtherDataClient(client *scsDataClient) *scsDataClient {
+	return c.dataClients.other(client)
+}
+
+// copyRequester returns a shallow copy of a request.
+func copyRequester(r requester) requester {
+	original := reflect.ValueOf(r).Elem()
+	copied := reflect.New(original.Type())
+	copied.Elem().Set(original)
+	return copied.Interface().(requester)
+}
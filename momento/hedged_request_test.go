@@ -0,0 +1,130 @@
+package momento_test
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/config/hedging"
+	"github.com/momentohq/client-sdk-go/config/retry"
+	pb "github.com/momentohq/client-sdk-go/internal/protos"
+	. "github.com/momentohq/client-sdk-go/momento"
+	helpers "github.com/momentohq/client-sdk-go/momento/test_helpers"
+	"github.com/momentohq/client-sdk-go/responses"
+)
+
+// getHandler answers the call-th Get request received by a fake cache server, counting from 1.
+type getHandler func(ctx context.Context, call int32) (*pb.XGetResponse, error)
+
+var _ = Describe("hedged-requests", func() {
+	const hedgeDelay = 50 * time.Millisecond
+
+	var (
+		server *helpers.FakeCacheServer
+		client CacheClient
+		// calls counts the Get requests the server has received.
+		calls atomic.Int32
+		// onGet holds the getHandler that answers the server's Get requests. It is stored atomically as
+		// specs replace it while the server is running.
+		onGet atomic.Value
+	)
+
+	miss := &pb.XGetResponse{Result: pb.ECacheResult_Miss}
+	get := func() (responses.GetResponse, error) {
+		return client.Get(context.Background(), &GetRequest{CacheName: "cache", Key: String("key")})
+	}
+
+	BeforeEach(func() {
+		calls.Store(0)
+		onGet.Store(getHandler(func(context.Context, int32) (*pb.XGetResponse, error) { return miss, nil }))
+		var err error
+		server, err = helpers.NewFakeCacheServer(helpers.FakeCacheServerProps{
+			OnGet: func(ctx context.Context, _ *pb.XGetRequest) (*pb.XGetResponse, error) {
+				call := calls.Add(1)
+				return onGet.Load().(getHandler)(ctx, call)
+			},
+		})
+		Expect(err).To(BeNil())
+		DeferCleanup(server.Close)
+
+		credentialProvider, err := server.CredentialProvider()
+		Expect(err).To(BeNil())
+		client, err = NewCacheClient(
+			config.LaptopLatest().
+				WithNumGrpcChannels(2).
+				WithRetryStrategy(retry.NewNeverRetryStrategy()).
+				// Each successful request earns a whole hedge, and at most one is banked.
+				WithHedging(hedging.Props{Delay: hedgeDelay, BudgetRatio: 1, MaxBudget: 1}),
+			credentialProvider,
+			time.Minute,
+		)
+		Expect(err).To(BeNil())
+		DeferCleanup(client.Close)
+	})
+
+	It("does not hedge requests that complete before the delay", func() {
+		for i := 0; i < 3; i++ {
+			resp, err := get()
+			Expect(err).To(BeNil())
+			Expect(resp).To(BeAssignableToTypeOf(&responses.GetMiss{}))
+		}
+		Expect(calls.Load()).To(Equal(int32(3)))
+	})
+
+	It("hedges a slow request after the delay and cancels the loser", func() {
+		// The first request builds up the hedge budget.
+		_, err := get()
+		Expect(err).To(BeNil())
+
+		cancelled := make(chan struct{})
+		onGet.Store(getHandler(func(ctx context.Context, call int32) (*pb.XGetResponse, error) {
+			if call == 2 {
+				<-ctx.Done()
+				close(cancelled)
+				return nil, status.FromContextError(ctx.Err()).Err()
+			}
+			return &pb.XGetResponse{Result: pb.ECacheResult_Hit, CacheBody: []byte("hedged")}, nil
+		}))
+
+		start := time.Now()
+		resp, err := get()
+		Expect(err).To(BeNil())
+		Expect(time.Since(start)).To(BeNumerically(">=", hedgeDelay))
+		Expect(resp).To(BeAssignableToTypeOf(&responses.GetHit{}))
+		Expect(resp.(*responses.GetHit).ValueString()).To(Equal("hedged"))
+		Expect(calls.Load()).To(Equal(int32(3)))
+		Eventually(cancelled).Should(BeClosed())
+	})
+
+	It("only hedges requests the budget allows", func() {
+		onGet.Store(getHandler(func(context.Context, int32) (*pb.XGetResponse, error) {
+			time.Sleep(2 * hedgeDelay)
+			return miss, nil
+		}))
+
+		// The first request has no budget to hedge with, and earns the budget for the second.
+		_, err := get()
+		Expect(err).To(BeNil())
+		Expect(calls.Load()).To(Equal(int32(1)))
+		_, err = get()
+		Expect(err).To(BeNil())
+		Expect(calls.Load()).To(Equal(int32(3)))
+	})
+
+	It("returns the request's error when the hedge is rejected", func() {
+		onGet.Store(getHandler(func(context.Context, int32) (*pb.XGetResponse, error) {
+			time.Sleep(2 * hedgeDelay)
+			return nil, status.Error(codes.PermissionDenied, "denied")
+		}))
+
+		_, err := get()
+		Expect(err).To(HaveMomentoErrorCode(PermissionError))
+		Expect(calls.Load()).To(Equal(int32(1)))
+	})
+})
@@ -22,17 +22,20 @@ type authClient struct {
 }
 
 func newAuthClient(request *models.AuthClientRequest) (*authClient, momentoerrors.MomentoSvcErr) {
-	// NOTE: This is hard-coded for now but we may want to expose it via TopicConfiguration in the future,
-	// as we do with some of the other clients. Defaults to keep-alive pings enabled.
-	grpcConfig := config.NewStaticGrpcConfiguration(&config.GrpcConfigurationProps{})
+	grpcConfig := request.GrpcConfiguration
+	if grpcConfig == nil {
+		// Defaults to keep-alive pings enabled.
+		grpcConfig = config.NewStaticGrpcConfiguration(&config.GrpcConfigurationProps{})
+	}
 	authManager, err := grpcmanagers.NewAuthGrpcManager(&models.AuthGrpcManagerRequest{
 		CredentialProvider: request.CredentialProvider,
 		GrpcConfiguration:  grpcConfig,
+		Channels:           request.Channels,
 	})
 	if err != nil {
 		return nil, momentoerrors.ConvertSvcErr(err)
 	}
-	return &authClient{grpcManager: authManager, grpcClient: pb.NewAuthClient(authManager.Conn)}, nil
+	return &authClient{grpcManager: authManager, grpcClient: pb.NewAuthClient(authManager.Channel)}, nil
 }
 
 func (client *authClient) Close() {
@@ -42,7 +45,7 @@ func (client *authClient) Close() {
 func (client *authClient) RefreshApiKey(ctx context.Context, request *RefreshApiKeyRequest) (auth_responses.RefreshApiKeyResponse, MomentoError) {
 	grpc_request := &pb.XRefreshApiTokenRequest{
 		RefreshToken: request.RefreshToken,
-		ApiKey:       client.grpcManager.AuthToken,
+		ApiKey:       client.grpcManager.CredentialProvider.GetAuthToken(),
 	}
 
 	resp, err := client.grpcClient.RefreshApiToken(ctx, grpc_request)
@@ -79,7 +82,7 @@ func (client *authClient) GenerateApiKey(ctx context.Context, request *GenerateA
 
 	grpc_request := &pb.XGenerateApiTokenRequest{
 		Permissions: permissions,
-		AuthToken:   client.grpcManager.AuthToken,
+		AuthToken:   client.grpcManager.CredentialProvider.GetAuthToken(),
 	}
 
 	if request.ExpiresIn.DoesExpire() {
@@ -39,10 +39,16 @@ type leaderboard struct {
 	cacheName             string
 	leaderboardName       string
 	leaderboardDataClient *leaderboardDataClient
+	operations            *operationTracker
 }
 
 // Delete removes all elements from the leaderboard.
 func (l *leaderboard) Delete(ctx context.Context) (responses.LeaderboardDeleteResponse, error) {
+	end, trackErr := l.operations.begin("LeaderboardDelete")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	r := &LeaderboardInternalDeleteRequest{
 		CacheName:       l.cacheName,
 		LeaderboardName: l.leaderboardName,
@@ -55,6 +61,11 @@ func (l *leaderboard) Delete(ctx context.Context) (responses.LeaderboardDeleteRe
 
 // FetchByRank gets all elements that fall within the specified min and max ranks.
 func (l *leaderboard) FetchByRank(ctx context.Context, request LeaderboardFetchByRankRequest) (responses.LeaderboardFetchResponse, error) {
+	end, trackErr := l.operations.begin("LeaderboardFetchByRank")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	if request.StartRank >= request.EndRank {
 		return nil, momentoerrors.NewMomentoSvcErr(momentoerrors.InvalidArgumentError, "start rank must be less than end rank", nil)
 	}
@@ -76,6 +87,11 @@ func (l *leaderboard) FetchByRank(ctx context.Context, request LeaderboardFetchB
 // will be returned in alphanumerical order based on their ID (e.g. IDs of elements with the same score would be
 // returned in the order [1, 10, 123, 2, 234, ...] rather than [1, 2, 10, 123, 234, ...]).
 func (l *leaderboard) FetchByScore(ctx context.Context, request LeaderboardFetchByScoreRequest) (responses.LeaderboardFetchResponse, error) {
+	end, trackErr := l.operations.begin("LeaderboardFetchByScore")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	if request.MinScore != nil && request.MaxScore != nil && *request.MinScore >= *request.MaxScore {
 		return nil, momentoerrors.NewMomentoSvcErr(momentoerrors.InvalidArgumentError, "min score must be less than max score", nil)
 	}
@@ -97,6 +113,11 @@ func (l *leaderboard) FetchByScore(ctx context.Context, request LeaderboardFetch
 
 // GetRank fetches elements (with their rank, score, and ID) given a list of element IDs.
 func (l *leaderboard) GetRank(ctx context.Context, request LeaderboardGetRankRequest) (responses.LeaderboardFetchResponse, error) {
+	end, trackErr := l.operations.begin("LeaderboardGetRank")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	r := &LeaderboardInternalGetRankRequest{
 		CacheName:       l.cacheName,
 		LeaderboardName: l.leaderboardName,
@@ -112,6 +133,11 @@ func (l *leaderboard) GetRank(ctx context.Context, request LeaderboardGetRankReq
 
 // Length gets the number of entries in the leaderboard.
 func (l *leaderboard) Length(ctx context.Context) (responses.LeaderboardLengthResponse, error) {
+	end, trackErr := l.operations.begin("LeaderboardLength")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	r := &LeaderboardInternalLengthRequest{
 		CacheName:       l.cacheName,
 		LeaderboardName: l.leaderboardName,
@@ -125,6 +151,11 @@ func (l *leaderboard) Length(ctx context.Context) (responses.LeaderboardLengthRe
 
 // RemoveElements deletes elements with the specified IDs from the leaderboard.
 func (l *leaderboard) RemoveElements(ctx context.Context, request LeaderboardRemoveElementsRequest) (responses.LeaderboardRemoveElementsResponse, error) {
+	end, trackErr := l.operations.begin("LeaderboardRemoveElements")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	if len(request.Ids) == 0 {
 		return nil, momentoerrors.NewMomentoSvcErr(momentoerrors.InvalidArgumentError, "List of elements to remove cannot be empty", nil)
 	}
@@ -142,6 +173,11 @@ func (l *leaderboard) RemoveElements(ctx context.Context, request LeaderboardRem
 // Upsert inserts elements if they do not already exist in the leaderboard and updates elements if they do
 // already exist. There are no partial failures; an upsert call will either succeed or fail.
 func (l *leaderboard) Upsert(ctx context.Context, request LeaderboardUpsertRequest) (responses.LeaderboardUpsertResponse, error) {
+	end, trackErr := l.operations.begin("LeaderboardUpsert")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	if len(request.Elements) == 0 {
 		return nil, momentoerrors.NewMomentoSvcErr(momentoerrors.InvalidArgumentError, "List of elements to upsert cannot be empty", nil)
 	}
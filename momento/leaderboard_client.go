@@ -6,6 +6,7 @@ import (
 	"github.com/momentohq/client-sdk-go/auth"
 	"github.com/momentohq/client-sdk-go/config"
 	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/internal/grpcmanagers/channelpool"
 	"github.com/momentohq/client-sdk-go/internal/models"
 	"github.com/momentohq/client-sdk-go/utils"
 )
@@ -21,13 +22,21 @@ type previewLeaderboardClient struct {
 	credentialProvider    auth.CredentialProvider
 	leaderboardDataClient *leaderboardDataClient
 	log                   logger.MomentoLogger
+	operations            *operationTracker
 }
 
 // NewPreviewLeaderboardClient creates a new instance of a Preview Leaderboard Client.
 func NewPreviewLeaderboardClient(leaderboardConfiguration config.LeaderboardConfiguration, credentialProvider auth.CredentialProvider) (PreviewLeaderboardClient, error) {
+	return newPreviewLeaderboardClient(leaderboardConfiguration, credentialProvider, nil)
+}
+
+// newPreviewLeaderboardClient creates a leaderboard client whose connection is leased from channels, if it is
+// not nil.
+func newPreviewLeaderboardClient(leaderboardConfiguration config.LeaderboardConfiguration, credentialProvider auth.CredentialProvider, channels *channelpool.Pool) (PreviewLeaderboardClient, error) {
 	dataClient, err := newLeaderboardDataClient(&models.LeaderboardClientRequest{
 		CredentialProvider: credentialProvider,
 		Configuration:      leaderboardConfiguration,
+		Channels:           channels.NewGroup(),
 	})
 	if err != nil {
 		return nil, err
@@ -37,6 +46,7 @@ func NewPreviewLeaderboardClient(leaderboardConfiguration config.LeaderboardConf
 		credentialProvider:    credentialProvider,
 		leaderboardDataClient: dataClient,
 		log:                   leaderboardConfiguration.GetLoggerFactory().GetLogger("topic-client"),
+		operations:            newOperationTracker("leaderboard client"),
 	}
 	return client, nil
 }
@@ -53,11 +63,23 @@ func (c previewLeaderboardClient) Leaderboard(ctx context.Context, request *Lead
 		cacheName:             request.CacheName,
 		leaderboardName:       request.LeaderboardName,
 		leaderboardDataClient: c.leaderboardDataClient,
+		operations:            c.operations,
 	}
 	return newLeaderboard, nil
 }
 
+func (c previewLeaderboardClient) Shutdown(ctx context.Context) (ShutdownSummary, error) {
+	c.log.Info("Shutting down leaderboard client")
+	summary, err := c.operations.shutdown(ctx)
+	if err != nil {
+		c.log.Warn("Leaderboard client shut down before its requests finished: %s", summary)
+	}
+	c.Close()
+	return summary, err
+}
+
 // Close will shut down the leaderboard client and related resources.
 func (c previewLeaderboardClient) Close() {
+	c.operations.reject()
 	c.leaderboardDataClient.close()
 }
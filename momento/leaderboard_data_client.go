@@ -23,6 +23,7 @@ func newLeaderboardDataClient(request *models.LeaderboardClientRequest) (*leader
 	grpcManager, err := grpcmanagers.NewLeaderboardGrpcManager(&models.LeaderboardGrpcManagerRequest{
 		CredentialProvider: request.CredentialProvider,
 		GrpcConfiguration:  request.Configuration.GetTransportStrategy().GetGrpcConfig(),
+		Channels:           request.Channels,
 	})
 	if err != nil {
 		return nil, err
@@ -30,7 +31,7 @@ func newLeaderboardDataClient(request *models.LeaderboardClientRequest) (*leader
 	return &leaderboardDataClient{
 		requestTimeout:         request.Configuration.GetClientSideTimeout(),
 		leaderboardGrpcManager: grpcManager,
-		leaderboardClient:      pb.NewLeaderboardClient(grpcManager.Conn),
+		leaderboardClient:      pb.NewLeaderboardClient(grpcManager.Channel),
 	}, nil
 }
 
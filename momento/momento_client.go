@@ -0,0 +1,306 @@
+package momento
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/auth"
+	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/config/health"
+	"github.com/momentohq/client-sdk-go/internal/grpcmanagers/channelpool"
+)
+
+// MomentoClientProps configures a MomentoClient. The configuration of a service that is nil selects its
+// default.
+type MomentoClientProps struct {
+	// CredentialProvider authenticates the requests of every service. Required.
+	CredentialProvider auth.CredentialProvider
+	// CacheConfiguration configures the cache client. Defaults to config.LaptopLatest().
+	CacheConfiguration config.Configuration
+	// CacheName is the cache the cache client sends requests without a cache name to.
+	CacheName string
+	// DefaultTtl is the TTL of cache items written without one. Required to use the cache client.
+	DefaultTtl time.Duration
+	// EagerConnectTimeout is how long the cache client waits for its channels to connect when it is
+	// created. Defaults to 0, which connects them on their first request.
+	EagerConnectTimeout time.Duration
+	// TopicsConfiguration configures the topic client. Defaults to config.TopicsDefault().
+	TopicsConfiguration config.TopicsConfiguration
+	// LeaderboardConfiguration configures the leaderboard client. Defaults to config.LeaderboardDefault().
+	LeaderboardConfiguration config.LeaderboardConfiguration
+	// StorageConfiguration configures the storage client. Defaults to config.StorageLaptopLatest().
+	StorageConfiguration config.StorageConfiguration
+	// AuthConfiguration configures the auth client. Defaults to config.AuthDefault().
+	AuthConfiguration config.AuthConfiguration
+}
+
+// MomentoClientStats describes the gRPC connections of a MomentoClient's services.
+type MomentoClientStats struct {
+	// Connections is the number of open connections, by endpoint.
+	Connections map[string]int
+	// Channels is the number of client channels using the connections. It exceeds the number of
+	// connections when services share them.
+	Channels int
+}
+
+// TotalConnections returns the number of open connections to every endpoint.
+func (s MomentoClientStats) TotalConnections() int {
+	total := 0
+	for _, count := range s.Connections {
+		total += count
+	}
+	return total
+}
+
+func (s MomentoClientStats) String() string {
+	endpoints := make([]string, 0, len(s.Connections))
+	for endpoint := range s.Connections {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	connections := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		connections = append(connections, fmt.Sprintf("%s: %d", endpoint, s.Connections[endpoint]))
+	}
+	return fmt.Sprintf("MomentoClientStats{Connections: {%s}, Channels: %d}", strings.Join(connections, ", "), s.Channels)
+}
+
+// MomentoClient gives access to every Momento service with one credential provider and one set of gRPC
+// connections. Services whose channels are dialed to the same endpoint with the same settings share
+// connections, except for the channels of topic subscriptions, which hold their streams open.
+//
+// The clients it returns are views that are created on first use and are closed by the MomentoClient:
+// calling Close or Shutdown on one of them does nothing.
+type MomentoClient interface {
+	// Cache returns the cache client.
+	Cache() (CacheClient, error)
+	// Topics returns the topic client.
+	Topics() (TopicClient, error)
+	// Leaderboards returns the leaderboard client.
+	Leaderboards() (PreviewLeaderboardClient, error)
+	// Storage returns the storage client.
+	//
+	// WARNING: the API for this client is not yet stable and may change without notice.
+	Storage() (PreviewStorageClient, error)
+	// Auth returns the auth client.
+	Auth() (AuthClient, error)
+
+	// Stats returns the connections the services use.
+	Stats() MomentoClientStats
+
+	// Shutdown shuts down the services gracefully, as their own Shutdown does, then closes the
+	// connections. The summary adds up what each of them abandoned.
+	Shutdown(ctx context.Context) (ShutdownSummary, error)
+
+	// Close closes the services and their connections.
+	Close()
+}
+
+// serviceClient is what the clients of every service have in common.
+type serviceClient interface {
+	Close()
+}
+
+type momentoClient struct {
+	props    MomentoClientProps
+	channels *channelpool.Pool
+
+	mutex  sync.Mutex
+	closed bool
+	// services are the clients the views below were created for, which the MomentoClient closes.
+	services     []serviceClient
+	cache        CacheClient
+	topics       TopicClient
+	leaderboards PreviewLeaderboardClient
+	storage      PreviewStorageClient
+	auth         AuthClient
+}
+
+// NewMomentoClient returns a MomentoClient for the services of props.CredentialProvider. Creating it opens
+// no connections; each service connects when its client is first requested.
+func NewMomentoClient(props MomentoClientProps) (MomentoClient, error) {
+	if props.CredentialProvider == nil {
+		return nil, NewMomentoError(InvalidArgumentError, "a credential provider must be provided", nil)
+	}
+	if props.CacheConfiguration == nil {
+		props.CacheConfiguration = config.LaptopLatest()
+	}
+	if props.TopicsConfiguration == nil {
+		props.TopicsConfiguration = config.TopicsDefault()
+	}
+	if props.LeaderboardConfiguration == nil {
+		props.LeaderboardConfiguration = config.LeaderboardDefault()
+	}
+	if props.StorageConfiguration == nil {
+		props.StorageConfiguration = config.StorageLaptopLatest()
+	}
+	if props.AuthConfiguration == nil {
+		props.AuthConfiguration = config.AuthDefault()
+	}
+	return &momentoClient{props: props, channels: channelpool.NewPool()}, nil
+}
+
+// getOrCreate returns the client stored in *client, creating it with create if it does not exist yet. A
+// failure to create it is not remembered, so that the next call tries again.
+func getOrCreate[T any](c *momentoClient, client *T, create func() (T, error)) (T, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var none T
+	if c.closed {
+		return none, NewMomentoError(ClientClosedError, "the Momento client is shutting down or closed", nil)
+	}
+	if any(*client) != nil {
+		return *client, nil
+	}
+	created, err := create()
+	if err != nil {
+		return none, err
+	}
+	*client = created
+	return created, nil
+}
+
+func (c *momentoClient) Cache() (CacheClient, error) {
+	return getOrCreate(c, &c.cache, func() (CacheClient, error) {
+		client, err := commonCacheClient(CacheClientProps{
+			CacheName:           c.props.CacheName,
+			Configuration:       c.props.CacheConfiguration,
+			CredentialProvider:  c.props.CredentialProvider,
+			DefaultTtl:          c.props.DefaultTtl,
+			EagerConnectTimeout: c.props.EagerConnectTimeout,
+		}, c.channels)
+		if err != nil {
+			return nil, err
+		}
+		c.services = append(c.services, client)
+		return cacheClientView{client}, nil
+	})
+}
+
+func (c *momentoClient) Topics() (TopicClient, error) {
+	return getOrCreate(c, &c.topics, func() (TopicClient, error) {
+		client, err := newTopicClient(c.props.TopicsConfiguration, c.props.CredentialProvider, c.channels)
+		if err != nil {
+			return nil, err
+		}
+		c.services = append(c.services, client)
+		return topicClientView{client}, nil
+	})
+}
+
+func (c *momentoClient) Leaderboards() (PreviewLeaderboardClient, error) {
+	return getOrCreate(c, &c.leaderboards, func() (PreviewLeaderboardClient, error) {
+		client, err := newPreviewLeaderboardClient(c.props.LeaderboardConfiguration, c.props.CredentialProvider, c.channels)
+		if err != nil {
+			return nil, err
+		}
+		c.services = append(c.services, client)
+		return leaderboardClientView{client}, nil
+	})
+}
+
+func (c *momentoClient) Storage() (PreviewStorageClient, error) {
+	return getOrCreate(c, &c.storage, func() (PreviewStorageClient, error) {
+		client, err := newPreviewStorageClient(c.props.StorageConfiguration, c.props.CredentialProvider, c.channels)
+		if err != nil {
+			return nil, err
+		}
+		c.services = append(c.services, client)
+		return storageClientView{client}, nil
+	})
+}
+
+func (c *momentoClient) Auth() (AuthClient, error) {
+	return getOrCreate(c, &c.auth, func() (AuthClient, error) {
+		client, err := newAuthClientWithChannels(c.props.AuthConfiguration, c.props.CredentialProvider, c.channels)
+		if err != nil {
+			return nil, err
+		}
+		c.services = append(c.services, client)
+		return authClientView{client}, nil
+	})
+}
+
+func (c *momentoClient) Stats() MomentoClientStats {
+	stats := c.channels.Stats()
+	return MomentoClientStats{Connections: stats.Connections, Channels: stats.Leases}
+}
+
+func (c *momentoClient) Shutdown(ctx context.Context) (ShutdownSummary, error) {
+	summary := ShutdownSummary{AbandonedOperations: map[string]int{}}
+	var err error
+	for _, service := range c.close() {
+		serviceSummary, serviceErr := Shutdown(ctx, service)
+		for name, count := range serviceSummary.AbandonedOperations {
+			summary.AbandonedOperations[name] += count
+		}
+		summary.ClosedSubscriptions += serviceSummary.ClosedSubscriptions
+		if err == nil {
+			err = serviceErr
+		}
+	}
+	_ = c.channels.Close()
+	return summary, err
+}
+
+func (c *momentoClient) Close() {
+	for _, service := range c.close() {
+		service.Close()
+	}
+	_ = c.channels.Close()
+}
+
+// close rejects requests for clients from now on and returns the clients of the services created so far.
+func (c *momentoClient) close() []serviceClient {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.closed = true
+	services := c.services
+	c.services = nil
+	return services
+}
+
+// The views returned by a MomentoClient leave closing their clients to it.
+
+type cacheClientView struct{ CacheClient }
+
+func (v cacheClientView) Health() health.Health {
+	return Health(v.CacheClient)
+}
+
+func (cacheClientView) Shutdown(context.Context) (ShutdownSummary, error) {
+	return ShutdownSummary{}, nil
+}
+func (cacheClientView) Close() {}
+
+type topicClientView struct{ TopicClient }
+
+func (topicClientView) Shutdown(context.Context) (ShutdownSummary, error) {
+	return ShutdownSummary{}, nil
+}
+func (topicClientView) Close() {}
+
+type leaderboardClientView struct{ PreviewLeaderboardClient }
+
+func (leaderboardClientView) Shutdown(context.Context) (ShutdownSummary, error) {
+	return ShutdownSummary{}, nil
+}
+func (leaderboardClientView) Close() {}
+
+type storageClientView struct{ PreviewStorageClient }
+
+func (storageClientView) Shutdown(context.Context) (ShutdownSummary, error) {
+	return ShutdownSummary{}, nil
+}
+func (storageClientView) Close() {}
+
+type authClientView struct{ AuthClient }
+
+func (authClientView) Shutdown(context.Context) (ShutdownSummary, error) {
+	return ShutdownSummary{}, nil
+}
+func (authClientView) Close() {}
@@ -0,0 +1,104 @@
+package momento_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/momentohq/client-sdk-go/auth"
+	"github.com/momentohq/client-sdk-go/config"
+	. "github.com/momentohq/client-sdk-go/momento"
+)
+
+var _ = Describe("momento-client", func() {
+	var credentialProvider auth.CredentialProvider
+
+	BeforeEach(func() {
+		// The clients connect on their first request, so no server needs to listen on the endpoint.
+		var err error
+		credentialProvider, err = auth.NewMomentoLocalProvider(&auth.MomentoLocalConfig{Port: 9})
+		Expect(err).To(BeNil())
+	})
+
+	It("shares connections between services dialed with the same settings", func() {
+		cacheConfig := config.LaptopLatest().WithNumGrpcChannels(3)
+		client, err := NewMomentoClient(MomentoClientProps{
+			CredentialProvider:       credentialProvider,
+			CacheConfiguration:       cacheConfig,
+			DefaultTtl:               time.Minute,
+			LeaderboardConfiguration: config.LeaderboardDefault().WithTransportStrategy(cacheConfig.GetTransportStrategy()),
+			StorageConfiguration: config.StorageLaptopLatest().
+				WithTransportStrategy(cacheConfig.GetTransportStrategy()).
+				WithNumGrpcChannels(2),
+		})
+		Expect(err).To(BeNil())
+		defer client.Close()
+		Expect(client.Stats().TotalConnections()).To(BeZero())
+
+		cacheClient, err := client.Cache()
+		Expect(err).To(BeNil())
+		// Each of the three data channels has a connection of its own, which the ping channel shares. The
+		// control channel disables keepalives, so it has another.
+		stats := client.Stats()
+		Expect(stats.Connections).To(Equal(map[string]int{"127.0.0.1:9": 4}))
+		Expect(stats.Channels).To(Equal(5))
+
+		_, err = client.Leaderboards()
+		Expect(err).To(BeNil())
+		_, err = client.Storage()
+		Expect(err).To(BeNil())
+		stats = client.Stats()
+		Expect(stats.TotalConnections()).To(Equal(4))
+		Expect(stats.Channels).To(Equal(9))
+
+		again, err := client.Cache()
+		Expect(err).To(BeNil())
+		Expect(again).To(BeIdenticalTo(cacheClient))
+		cacheClient.Close()
+		Expect(client.Stats()).To(Equal(stats))
+	})
+
+	It("does not share the channels of topic subscriptions", func() {
+		client, err := NewMomentoClient(MomentoClientProps{
+			CredentialProvider:  credentialProvider,
+			TopicsConfiguration: config.TopicsDefault().WithNumStreamGrpcChannels(2).WithNumUnaryGrpcChannels(1),
+		})
+		Expect(err).To(BeNil())
+		defer client.Close()
+
+		_, err = client.Topics()
+		Expect(err).To(BeNil())
+		stats := client.Stats()
+		Expect(stats.TotalConnections()).To(Equal(3))
+		Expect(stats.Channels).To(Equal(3))
+	})
+
+	It("closes every service and connection", func() {
+		client, err := NewMomentoClient(MomentoClientProps{
+			CredentialProvider: credentialProvider,
+			DefaultTtl:         time.Minute,
+		})
+		Expect(err).To(BeNil())
+		_, err = client.Cache()
+		Expect(err).To(BeNil())
+		_, err = client.Auth()
+		Expect(err).To(BeNil())
+		Expect(client.Stats().TotalConnections()).To(BeNumerically(">", 0))
+
+		summary, err := client.Shutdown(context.Background())
+		Expect(err).To(BeNil())
+		Expect(summary.Abandoned()).To(BeZero())
+		Expect(client.Stats().TotalConnections()).To(BeZero())
+		Expect(client.Stats().Channels).To(BeZero())
+
+		_, err = client.Cache()
+		Expect(err).To(HaveMomentoErrorCode(ClientClosedError))
+	})
+
+	It("requires a credential provider", func() {
+		_, err := NewMomentoClient(MomentoClientProps{})
+		Expect(err).To(HaveMomentoErrorCode(InvalidArgumentError))
+	})
+})
@@ -0,0 +1,342 @@
+package momento
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/momentohq/client-sdk-go/internal"
+)
+
+// OperationKind is the kind of access an Operation needs.
+type OperationKind int64
+
+const (
+	// CacheRead reads cache items, e.g. Get, DictionaryFetch or SetContainsElements.
+	CacheRead OperationKind = iota
+	// CacheWrite modifies cache items, e.g. Set, Delete or ListPushBack.
+	CacheWrite
+	// TopicPublish publishes to a topic.
+	TopicPublish
+	// TopicSubscribe subscribes to a topic.
+	TopicSubscribe
+)
+
+func (k OperationKind) String() string {
+	switch k {
+	case CacheRead:
+		return "CacheRead"
+	case CacheWrite:
+		return "CacheWrite"
+	case TopicPublish:
+		return "TopicPublish"
+	case TopicSubscribe:
+		return "TopicSubscribe"
+	}
+	return fmt.Sprintf("OperationKind(%d)", int64(k))
+}
+
+func (k OperationKind) isTopicOperation() bool {
+	return k == TopicPublish || k == TopicSubscribe
+}
+
+// Operation is a data plane request to evaluate against a scope with Allows.
+type Operation struct {
+	Kind  OperationKind
+	Cache string
+	// Topic is the topic name for TopicPublish and TopicSubscribe operations.
+	Topic string
+	// Key is the item key for CacheRead and CacheWrite operations. Operations without a key, such as
+	// those that span the whole cache, are only allowed by permissions that cover all items.
+	Key []byte
+}
+
+func (o Operation) String() string {
+	if o.Kind.isTopicOperation() {
+		return fmt.Sprintf("%s(cache=%s, topic=%s)", o.Kind, o.Cache, o.Topic)
+	}
+	if o.Key == nil {
+		return fmt.Sprintf("%s(cache=%s)", o.Kind, o.Cache)
+	}
+	return fmt.Sprintf("%s(cache=%s, key=%q)", o.Kind, o.Cache, o.Key)
+}
+
+// Allows reports whether a token with the given scope may perform the operation. The scope may be a
+// PermissionScope or a DisposableTokenScope. The evaluation is local and mirrors the server's semantics:
+//
+//   - A cache permission allows reads when its role is ReadWrite or ReadOnly, and writes when its role is
+//     ReadWrite or WriteOnly. Cache permissions do not grant access to topics.
+//   - A topic permission allows publishing when its role is PublishSubscribe or PublishOnly, and subscribing
+//     when its role is PublishSubscribe or SubscribeOnly.
+//   - A disposable token permission additionally restricts the items it applies to by key or key prefix.
+//
+// An operation is allowed if any permission in the scope allows it. Unknown scopes allow nothing.
+func Allows(scope interface{}, operation Operation) bool {
+	_, ok := matchingPermission(scope, operation)
+	return ok
+}
+
+// matchingPermission returns the first permission in the scope that allows the operation.
+func matchingPermission(scope interface{}, operation Operation) (Permission, bool) {
+	switch s := scope.(type) {
+	case internal.InternalSuperUserPermissions:
+		// The server accepts no other predefined scope, so PredefinedScope{} allows nothing.
+		return nil, true
+	case Permissions:
+		for _, permission := range s.Permissions {
+			if permissionAllows(permission, operation) {
+				return permission, true
+			}
+		}
+	case DisposableTokenCachePermissions:
+		for _, permission := range s.Permissions {
+			if permissionAllows(permission, operation) {
+				return permission, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func permissionAllows(permission Permission, operation Operation) bool {
+	switch p := permission.(type) {
+	case CachePermission:
+		return !operation.Kind.isTopicOperation() &&
+			cacheRoleAllows(p.Role, operation.Kind) &&
+			cacheSelectorMatches(p.Cache, operation.Cache)
+	case DisposableTokenCachePermission:
+		return !operation.Kind.isTopicOperation() &&
+			cacheRoleAllows(p.Role, operation.Kind) &&
+			cacheSelectorMatches(p.Cache, operation.Cache) &&
+			cacheItemSelectorMatches(p.Item, operation.Key)
+	case TopicPermission:
+		return operation.Kind.isTopicOperation() &&
+			topicRoleAllows(p.Role, operation.Kind) &&
+			cacheSelectorMatches(p.Cache, operation.Cache) &&
+			topicSelectorMatches(p.Topic, operation.Topic)
+	}
+	return false
+}
+
+func cacheRoleAllows(role CacheRole, kind OperationKind) bool {
+	switch role {
+	case ReadWrite:
+		return true
+	case ReadOnly:
+		return kind == CacheRead
+	case WriteOnly:
+		return kind == CacheWrite
+	}
+	return false
+}
+
+func topicRoleAllows(role TopicRole, kind OperationKind) bool {
+	switch role {
+	case PublishSubscribe:
+		return true
+	case PublishOnly:
+		return kind == TopicPublish
+	case SubscribeOnly:
+		return kind == TopicSubscribe
+	}
+	return false
+}
+
+func cacheSelectorMatches(selector CacheSelector, cacheName string) bool {
+	if selector == nil {
+		return false
+	}
+	return selector.IsAllCaches() || selector.CacheName() == cacheName
+}
+
+func topicSelectorMatches(selector TopicSelector, topicName string) bool {
+	switch s := selector.(type) {
+	case AllTopics:
+		return true
+	case TopicNamePrefix:
+		return strings.HasPrefix(topicName, s.NamePrefix)
+	case TopicName:
+		return s.Name == topicName
+	}
+	return false
+}
+
+func cacheItemSelectorMatches(selector CacheItemSelector, key []byte) bool {
+	switch s := selector.(type) {
+	case AllCacheItems:
+		return true
+	case CacheItemKey:
+		return key != nil && bytes.Equal(s.Key, key)
+	case CacheItemKeyPrefix:
+		return key != nil && bytes.HasPrefix(key, s.KeyPrefix)
+	}
+	return false
+}
+
+// Decision is the outcome of evaluating an Operation against a scope.
+type Decision struct {
+	Operation Operation
+	Allowed   bool
+	// Reason describes the permission that allowed the operation, or why it was denied.
+	Reason string
+}
+
+func (d Decision) String() string {
+	verdict := "denied"
+	if d.Allowed {
+		verdict = "allowed"
+	}
+	return fmt.Sprintf("%s: %s (%s)", d.Operation, verdict, d.Reason)
+}
+
+// Explanation lists which of a set of operations a scope allows and which it denies.
+type Explanation struct {
+	Allowed []Decision
+	Denied  []Decision
+}
+
+func (e Explanation) String() string {
+	var sb strings.Builder
+	sb.WriteString("can:\n")
+	for _, d := range e.Allowed {
+		fmt.Fprintf(&sb, "  %s\n", d)
+	}
+	sb.WriteString("cannot:\n")
+	for _, d := range e.Denied {
+		fmt.Fprintf(&sb, "  %s\n", d)
+	}
+	return sb.String()
+}
+
+// Explain evaluates each operation against the scope and reports what it can and cannot do, along with the
+// permission responsible for each allowed operation.
+func Explain(scope interface{}, operations ...Operation) Explanation {
+	var explanation Explanation
+	for _, operation := range operations {
+		decision := decide(scope, operation)
+		if decision.Allowed {
+			explanation.Allowed = append(explanation.Allowed, decision)
+		} else {
+			explanation.Denied = append(explanation.Denied, decision)
+		}
+	}
+	return explanation
+}
+
+// ScopeDifference is an operation that one scope allows and the other denies.
+type ScopeDifference struct {
+	Operation Operation
+	// Before and After are the decisions of the first and second scope passed to DiffScopes.
+	Before Decision
+	After  Decision
+}
+
+func (d ScopeDifference) String() string {
+	if d.After.Allowed {
+		return fmt.Sprintf("+ %s (%s)", d.Operation, d.After.Reason)
+	}
+	return fmt.Sprintf("- %s (%s)", d.Operation, d.Before.Reason)
+}
+
+// DiffScopes evaluates each operation against both scopes and returns the operations where they disagree,
+// e.g. to check that a change to a token vending policy grants or revokes exactly what was intended.
+func DiffScopes(before interface{}, after interface{}, operations ...Operation) []ScopeDifference {
+	var differences []ScopeDifference
+	for _, operation := range operations {
+		beforeDecision := decide(before, operation)
+		afterDecision := decide(after, operation)
+		if beforeDecision.Allowed != afterDecision.Allowed {
+			differences = append(differences, ScopeDifference{
+				Operation: operation,
+				Before:    beforeDecision,
+				After:     afterDecision,
+			})
+		}
+	}
+	return differences
+}
+
+func decide(scope interface{}, operation Operation) Decision {
+	permission, ok := matchingPermission(scope, operation)
+	if !ok {
+		return Decision{Operation: operation, Allowed: false, Reason: "no permission in scope matches"}
+	}
+	return Decision{Operation: operation, Allowed: true, Reason: describePermission(permission)}
+}
+
+// describePermission returns a short, human-readable form of a permission.
+func describePermission(permission Permission) string {
+	switch p := permission.(type) {
+	case nil:
+		return "predefined scope"
+	case CachePermission:
+		return fmt.Sprintf("cache %s on %s", describeCacheRole(p.Role), describeCacheSelector(p.Cache))
+	case DisposableTokenCachePermission:
+		return fmt.Sprintf(
+			"cache %s on %s, %s", describeCacheRole(p.Role), describeCacheSelector(p.Cache), describeCacheItemSelector(p.Item),
+		)
+	case TopicPermission:
+		return fmt.Sprintf(
+			"topic %s on %s, %s", describeTopicRole(p.Role), describeCacheSelector(p.Cache), describeTopicSelector(p.Topic),
+		)
+	}
+	return fmt.Sprintf("%T", permission)
+}
+
+func describeCacheRole(role CacheRole) string {
+	switch role {
+	case ReadWrite:
+		return "read-write"
+	case ReadOnly:
+		return "read-only"
+	case WriteOnly:
+		return "write-only"
+	}
+	return fmt.Sprintf("role %d", int64(role))
+}
+
+func describeTopicRole(role TopicRole) string {
+	switch role {
+	case PublishSubscribe:
+		return "publish-subscribe"
+	case PublishOnly:
+		return "publish-only"
+	case SubscribeOnly:
+		return "subscribe-only"
+	}
+	return fmt.Sprintf("role %d", int64(role))
+}
+
+func describeCacheSelector(selector CacheSelector) string {
+	if selector == nil {
+		return "no caches"
+	}
+	if selector.IsAllCaches() {
+		return "all caches"
+	}
+	return fmt.Sprintf("cache %q", selector.CacheName())
+}
+
+func describeTopicSelector(selector TopicSelector) string {
+	switch s := selector.(type) {
+	case AllTopics:
+		return "all topics"
+	case TopicNamePrefix:
+		return fmt.Sprintf("topics prefixed %q", s.NamePrefix)
+	case TopicName:
+		return fmt.Sprintf("topic %q", s.Name)
+	}
+	return fmt.Sprintf("%T", selector)
+}
+
+func describeCacheItemSelector(selector CacheItemSelector) string {
+	switch s := selector.(type) {
+	case AllCacheItems:
+		return "all items"
+	case CacheItemKey:
+		return fmt.Sprintf("key %q", s.Key)
+	case CacheItemKeyPrefix:
+		return fmt.Sprintf("keys prefixed %q", s.KeyPrefix)
+	}
+	return fmt.Sprintf("%T", selector)
+}
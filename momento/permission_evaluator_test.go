@@ -0,0 +1,88 @@
+package momento_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/momentohq/client-sdk-go/internal"
+	. "github.com/momentohq/client-sdk-go/momento"
+)
+
+var _ = Describe("auth permission-evaluator", Label(AUTH_SERVICE_LABEL), func() {
+	Describe("Allows", func() {
+		It("applies cache roles", func() {
+			scope := CacheReadOnly(CacheName{Name: "my-cache"})
+			Expect(Allows(scope, Operation{Kind: CacheRead, Cache: "my-cache", Key: []byte("k")})).To(BeTrue())
+			Expect(Allows(scope, Operation{Kind: CacheWrite, Cache: "my-cache", Key: []byte("k")})).To(BeFalse())
+			Expect(Allows(scope, Operation{Kind: CacheRead, Cache: "other-cache", Key: []byte("k")})).To(BeFalse())
+
+			scope = CacheWriteOnly(AllCaches{})
+			Expect(Allows(scope, Operation{Kind: CacheWrite, Cache: "any-cache"})).To(BeTrue())
+			Expect(Allows(scope, Operation{Kind: CacheRead, Cache: "any-cache"})).To(BeFalse())
+		})
+
+		It("does not grant topic access from cache permissions", func() {
+			scope := CacheReadWrite(AllCaches{})
+			Expect(Allows(scope, Operation{Kind: TopicPublish, Cache: "my-cache", Topic: "my-topic"})).To(BeFalse())
+		})
+
+		It("applies topic roles and selectors", func() {
+			scope := TopicNamePrefixPublishOnly(CacheName{Name: "my-cache"}, "chat-")
+			Expect(Allows(scope, Operation{Kind: TopicPublish, Cache: "my-cache", Topic: "chat-room"})).To(BeTrue())
+			Expect(Allows(scope, Operation{Kind: TopicSubscribe, Cache: "my-cache", Topic: "chat-room"})).To(BeFalse())
+			Expect(Allows(scope, Operation{Kind: TopicPublish, Cache: "my-cache", Topic: "news"})).To(BeFalse())
+
+			scope = TopicSubscribeOnly(AllCaches{}, TopicName{Name: "news"})
+			Expect(Allows(scope, Operation{Kind: TopicSubscribe, Cache: "my-cache", Topic: "news"})).To(BeTrue())
+			Expect(Allows(scope, Operation{Kind: TopicSubscribe, Cache: "my-cache", Topic: "news-2"})).To(BeFalse())
+		})
+
+		It("applies disposable token item selectors", func() {
+			scope := CacheKeyPrefixReadWrite(CacheName{Name: "my-cache"}, String("user-1/"))
+			Expect(Allows(scope, Operation{Kind: CacheWrite, Cache: "my-cache", Key: []byte("user-1/profile")})).To(BeTrue())
+			Expect(Allows(scope, Operation{Kind: CacheWrite, Cache: "my-cache", Key: []byte("user-2/profile")})).To(BeFalse())
+			Expect(Allows(scope, Operation{Kind: CacheRead, Cache: "my-cache"})).To(BeFalse())
+
+			scope = CacheKeyReadOnly(AllCaches{}, String("config"))
+			Expect(Allows(scope, Operation{Kind: CacheRead, Cache: "my-cache", Key: []byte("config")})).To(BeTrue())
+			Expect(Allows(scope, Operation{Kind: CacheRead, Cache: "my-cache", Key: []byte("config-2")})).To(BeFalse())
+		})
+
+		It("allows everything for AllDataReadWrite and the super user scope", func() {
+			operation := Operation{Kind: TopicSubscribe, Cache: "my-cache", Topic: "my-topic"}
+			Expect(Allows(AllDataReadWrite, operation)).To(BeTrue())
+			Expect(Allows(internal.InternalSuperUserPermissions{}, operation)).To(BeTrue())
+		})
+
+		It("allows nothing for predefined scopes the server does not accept", func() {
+			for _, operation := range []Operation{
+				{Kind: CacheRead, Cache: "my-cache", Key: []byte("k")},
+				{Kind: CacheWrite, Cache: "my-cache", Key: []byte("k")},
+				{Kind: TopicPublish, Cache: "my-cache", Topic: "my-topic"},
+			} {
+				Expect(Allows(PredefinedScope{}, operation)).To(BeFalse())
+			}
+		})
+	})
+
+	Describe("Explain and DiffScopes", func() {
+		read := Operation{Kind: CacheRead, Cache: "my-cache", Key: []byte("k")}
+		write := Operation{Kind: CacheWrite, Cache: "my-cache", Key: []byte("k")}
+		publish := Operation{Kind: TopicPublish, Cache: "my-cache", Topic: "my-topic"}
+
+		It("lists what a scope can and cannot do", func() {
+			explanation := Explain(CacheReadOnly(CacheName{Name: "my-cache"}), read, write, publish)
+			Expect(explanation.Allowed).To(HaveLen(1))
+			Expect(explanation.Allowed[0].Operation).To(Equal(read))
+			Expect(explanation.Allowed[0].Reason).To(Equal(`cache read-only on cache "my-cache"`))
+			Expect(explanation.Denied).To(HaveLen(2))
+		})
+
+		It("returns the operations two scopes disagree on", func() {
+			differences := DiffScopes(CacheReadOnly(CacheName{Name: "my-cache"}), CacheReadWrite(CacheName{Name: "my-cache"}), read, write, publish)
+			Expect(differences).To(HaveLen(1))
+			Expect(differences[0].Operation).To(Equal(write))
+			Expect(differences[0].After.Allowed).To(BeTrue())
+		})
+	})
+})
@@ -2,6 +2,7 @@ package momento
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/momentohq/client-sdk-go/config/logger"
@@ -51,8 +52,10 @@ func newPubSubClient(request *models.PubSubClientRequest) (*pubSubClient, moment
 	if request.TopicsConfiguration.GetNumUnaryGrpcChannels() > 0 {
 		numUnaryChannels = request.TopicsConfiguration.GetNumUnaryGrpcChannels()
 	}
+	unaryManagerProps := *topicManagerProps
+	unaryManagerProps.Channels = request.ChannelPool.NewGroup()
 	unaryPool, err := topic_manager_lists.NewStaticUnaryGrpcManagerPool(
-		topicManagerProps,
+		&unaryManagerProps,
 		numUnaryChannels,
 		request.Log,
 	)
@@ -60,12 +63,16 @@ func newPubSubClient(request *models.PubSubClientRequest) (*pubSubClient, moment
 		return nil, err
 	}
 
-	// Create pool of grpc channels for stream operations depending on static vs dynamic transport strategy
+	// Create pool of grpc channels for stream operations depending on static vs dynamic transport strategy.
+	// Subscriptions hold their streams open, so these channels are never shared with other clients, whose
+	// requests would otherwise contend with them for the connection's concurrent streams.
+	streamManagerProps := *topicManagerProps
+	streamManagerProps.Channels = request.ChannelPool.NewExclusiveGroup()
 	var streamPool topic_manager_lists.TopicGrpcConnectionPool
 	if request.TopicsConfiguration.GetMaxSubscriptions() > 0 {
 		request.Log.Debug("Creating dynamic stream manager list with max subscriptions: %d", request.TopicsConfiguration.GetMaxSubscriptions())
 		streamPool, err = topic_manager_lists.NewDynamicStreamGrpcManagerPool(
-			topicManagerProps,
+			&streamManagerProps,
 			request.TopicsConfiguration.GetMaxSubscriptions(),
 			request.Log,
 		)
@@ -76,7 +83,7 @@ func newPubSubClient(request *models.PubSubClientRequest) (*pubSubClient, moment
 		}
 		request.Log.Debug("Creating static stream manager list with num stream channels: %d", numStreamChannels)
 		streamPool, err = topic_manager_lists.NewStaticStreamGrpcManagerPool(
-			topicManagerProps,
+			&streamManagerProps,
 			numStreamChannels,
 			request.Log,
 		)
@@ -149,49 +156,119 @@ func (client *pubSubClient) topicPublish(ctx context.Context, request *TopicPubl
 		}
 	}
 
+	payload, err := topicPayloadFromValue(request.Value)
+	if err != nil {
+		return err
+	}
+	payload, err = client.applyPublishPayloadMiddleware(request.CacheName, request.TopicName, payload)
+	if err != nil {
+		return err
+	}
+
 	requestContext := internal.CreateTopicRequestContextFromMetadataMap(ctx, request.CacheName, requestMetadata)
 	topicManager, err := client.unaryGrpcConnectionPool.GetNextTopicGrpcManager()
 	if err != nil {
 		return err
 	}
+	topicValue := &pb.XTopicValue{}
+	if payload.IsText {
+		topicValue.Kind = &pb.XTopicValue_Text{Text: string(payload.Data)}
+	} else {
+		topicValue.Kind = &pb.XTopicValue_Binary{Binary: payload.Data}
+	}
+
 	var header, trailer metadata.MD
-	switch value := request.Value.(type) {
+	_, err = topicManager.StreamClient.Publish(requestContext, &pb.XPublishRequest{
+		CacheName: request.CacheName,
+		Topic:     request.TopicName,
+		Value:     topicValue,
+	}, grpc.Header(&header), grpc.Trailer(&trailer))
+	if err != nil {
+		return momentoerrors.ConvertSvcErr(err, header, trailer)
+	}
+	return nil
+}
+
+func topicPayloadFromValue(value TopicValue) (middleware.TopicPayload, error) {
+	switch v := value.(type) {
 	case String:
-		_, err := topicManager.StreamClient.Publish(requestContext, &pb.XPublishRequest{
-			CacheName: request.CacheName,
-			Topic:     request.TopicName,
-			Value: &pb.XTopicValue{
-				Kind: &pb.XTopicValue_Text{
-					Text: value.asString(),
-				},
-			},
-		}, grpc.Header(&header), grpc.Trailer(&trailer))
-		if err != nil {
-			return momentoerrors.ConvertSvcErr(err, header, trailer)
-		}
-		return err
+		return middleware.TopicPayload{Data: []byte(v.asString()), IsText: true}, nil
 	case Bytes:
-		_, err := topicManager.StreamClient.Publish(requestContext, &pb.XPublishRequest{
-			CacheName: request.CacheName,
-			Topic:     request.TopicName,
-			Value: &pb.XTopicValue{
-				Kind: &pb.XTopicValue_Binary{
-					Binary: value.asBytes(),
-				},
-			},
-		}, grpc.Header(&header), grpc.Trailer(&trailer))
-		if err != nil {
-			return momentoerrors.ConvertSvcErr(err, header, trailer)
-		}
-		return err
+		return middleware.TopicPayload{Data: v.asBytes()}, nil
 	default:
-		return momentoerrors.NewMomentoSvcErr(
+		return middleware.TopicPayload{}, momentoerrors.NewMomentoSvcErr(
 			momentoerrors.InvalidArgumentError,
 			"error encoding topic value only support []byte or string currently", nil,
 		)
 	}
 }
 
+func (client *pubSubClient) applyPublishPayloadMiddleware(
+	cacheName string, topicName string, payload middleware.TopicPayload,
+) (middleware.TopicPayload, error) {
+	for _, mw := range client.middleware {
+		if pmw, ok := mw.(middleware.TopicPayloadMiddleware); ok {
+			newPayload, err := pmw.OnPublishPayload(cacheName, topicName, payload)
+			if err != nil {
+				if momentoErr, ok := err.(MomentoError); ok {
+					return middleware.TopicPayload{}, momentoErr
+				}
+				return middleware.TopicPayload{}, momentoerrors.NewMomentoSvcErr(
+					momentoerrors.ClientSdkError,
+					fmt.Sprintf("topic middleware %T failed to process publish payload", mw),
+					err,
+				)
+			}
+			payload = newPayload
+		}
+	}
+	return payload, nil
+}
+
+func (client *pubSubClient) applyTopicNameMiddleware(ctx context.Context, cacheName string, topicName string) (string, error) {
+	for _, mw := range client.middleware {
+		if nmw, ok := mw.(middleware.TopicNameMiddleware); ok {
+			newTopicName, err := nmw.OnTopicName(ctx, cacheName, topicName)
+			if err != nil {
+				if momentoErr, ok := err.(MomentoError); ok {
+					return "", momentoErr
+				}
+				return "", momentoerrors.NewMomentoSvcErr(
+					momentoerrors.ClientSdkError,
+					fmt.Sprintf("topic middleware %T failed to process topic name", mw),
+					err,
+				)
+			}
+			topicName = newTopicName
+		}
+	}
+	return topicName, nil
+}
+
+// Subscription payloads are passed through the payload middlewares in reverse order so that
+// transformations applied on publish are undone in the opposite order.
+func (client *pubSubClient) applySubscriptionPayloadMiddleware(
+	cacheName string, topicName string, payload middleware.TopicPayload,
+) (middleware.TopicPayload, error) {
+	for i := len(client.middleware) - 1; i >= 0; i-- {
+		if pmw, ok := client.middleware[i].(middleware.TopicPayloadMiddleware); ok {
+			newPayload, err := pmw.OnSubscriptionPayload(cacheName, topicName, payload)
+			if err != nil {
+				if momentoErr, ok := err.(MomentoError); ok {
+					return middleware.TopicPayload{}, momentoErr
+				}
+				return middleware.TopicPayload{}, momentoerrors.NewMomentoSvcErr(
+					momentoerrors.ClientSdkError,
+					fmt.Sprintf("topic middleware %T failed to process subscription payload", client.middleware[i]),
+					err,
+				)
+			}
+			payload = newPayload
+		}
+	}
+	return payload, nil
+}
+
 func (client *pubSubClient) close() {
 	client.streamGrpcConnectionPool.Close()
 	client.unaryGrpcConnectionPool.Close()
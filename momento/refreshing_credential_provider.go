@@ -0,0 +1,352 @@
+package momento
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/auth"
+	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/config/logger"
+	responses "github.com/momentohq/client-sdk-go/responses/auth"
+)
+
+const (
+	defaultRefreshBefore         = 24 * time.Hour
+	defaultRefreshJitter         = 0.1
+	defaultRefreshRetryInitial   = time.Second
+	defaultRefreshRetryMax       = 5 * time.Minute
+	defaultRefreshRequestTimeout = 30 * time.Second
+)
+
+// RefreshedApiKey is an API key obtained by a RefreshingCredentialProvider, together with the refresh token
+// needed to refresh it again.
+type RefreshedApiKey struct {
+	ApiKey       string    `json:"api_key"`
+	RefreshToken string    `json:"refresh_token"`
+	Endpoint     string    `json:"endpoint"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// ApiKeySink persists the API keys obtained by a RefreshingCredentialProvider, so that a restarted process
+// can use the latest key and refresh token rather than ones that have been superseded.
+type ApiKeySink interface {
+	Save(ctx context.Context, key RefreshedApiKey) error
+}
+
+// ApiKeySinkFunc adapts a function to an ApiKeySink.
+type ApiKeySinkFunc func(ctx context.Context, key RefreshedApiKey) error
+
+func (f ApiKeySinkFunc) Save(ctx context.Context, key RefreshedApiKey) error {
+	return f(ctx, key)
+}
+
+type fileApiKeySink struct {
+	path string
+}
+
+// NewFileApiKeySink returns an ApiKeySink that writes each key as JSON to the file at path, readable only by
+// its owner. The file is replaced atomically, so it always holds a complete key.
+func NewFileApiKeySink(path string) ApiKeySink {
+	return &fileApiKeySink{path: path}
+}
+
+func (s *fileApiKeySink) Save(_ context.Context, key RefreshedApiKey) error {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if err := file.Chmod(0o600); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), s.path)
+}
+
+type RefreshingCredentialProviderProps struct {
+	// CredentialProvider provides the API key to start with.
+	CredentialProvider auth.CredentialProvider
+	// RefreshToken is the refresh token issued with the API key.
+	RefreshToken string
+	// AuthConfiguration configures the auth client used to refresh the key. Defaults to config.AuthDefault().
+	AuthConfiguration config.AuthConfiguration
+	// RefreshBefore is how long before the key expires it is refreshed. Keys whose remaining lifetime is
+	// shorter than this are refreshed halfway through it. Defaults to 24 hours.
+	RefreshBefore time.Duration
+	// Jitter is the largest fraction of the wait before a refresh that is randomly removed from it, so that
+	// processes started together do not refresh together. Defaults to 0.1.
+	Jitter float64
+	// RetryInitialDelay and RetryMaxDelay bound the exponential backoff between attempts when a refresh
+	// fails. Attempts continue until the key expires. Default to 1 second and 5 minutes.
+	RetryInitialDelay time.Duration
+	RetryMaxDelay     time.Duration
+	// Sink, if set, persists each new key and refresh token.
+	Sink ApiKeySink
+	// OnRefreshError, if set, is called each time a refresh fails.
+	OnRefreshError func(err error)
+}
+
+// RefreshingCredentialProvider is a CredentialProvider for long-running processes that refreshes its API key
+// with AuthClient.RefreshApiKey ahead of the key's expiry. Clients created with it use each new key without
+// being recreated, and listeners registered with OnUpdate are notified of each refresh. Close stops the
+// refreshes.
+type RefreshingCredentialProvider struct {
+	*auth.RotatingCredentialProvider
+	props      RefreshingCredentialProviderProps
+	log        logger.MomentoLogger
+	authClient AuthClient
+
+	// refreshMutex serializes refreshes, as each refresh token can only be used once.
+	refreshMutex sync.Mutex
+	mutex        sync.Mutex
+	refreshToken string
+	expiresAt    time.Time
+
+	refreshed chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewRefreshingCredentialProvider returns a RefreshingCredentialProvider and starts refreshing its key. Keys
+// that do not expire are never refreshed.
+// Example usage:
+//
+//	credentialProvider, err := momento.NewRefreshingCredentialProvider(momento.RefreshingCredentialProviderProps{
+//		CredentialProvider: initialProvider,
+//		RefreshToken:       refreshToken,
+//		Sink:               momento.NewFileApiKeySink("/var/lib/myapp/momento-key.json"),
+//	})
+//	defer credentialProvider.Close()
+//	client, err := momento.NewCacheClient(config.LaptopLatest(), credentialProvider, 60*time.Second)
+func NewRefreshingCredentialProvider(props RefreshingCredentialProviderProps) (*RefreshingCredentialProvider, error) {
+	if props.CredentialProvider == nil {
+		return nil, NewMomentoError(InvalidArgumentError, "credential provider is required", nil)
+	}
+	if props.RefreshToken == "" {
+		return nil, NewMomentoError(InvalidArgumentError, "refresh token is required", nil)
+	}
+	props = props.withDefaults()
+
+	rotating := auth.NewRotatingCredentialProvider(props.CredentialProvider)
+	// The auth client uses the rotating provider, so each refresh is authorized with the latest key.
+	authClient, err := NewAuthClient(props.AuthConfiguration, rotating)
+	if err != nil {
+		return nil, err
+	}
+	return newRefreshingCredentialProvider(props, rotating, authClient), nil
+}
+
+func (props RefreshingCredentialProviderProps) withDefaults() RefreshingCredentialProviderProps {
+	if props.AuthConfiguration == nil {
+		props.AuthConfiguration = config.AuthDefault()
+	}
+	if props.RefreshBefore <= 0 {
+		props.RefreshBefore = defaultRefreshBefore
+	}
+	if props.Jitter <= 0 || props.Jitter >= 1 {
+		props.Jitter = defaultRefreshJitter
+	}
+	if props.RetryInitialDelay <= 0 {
+		props.RetryInitialDelay = defaultRefreshRetryInitial
+	}
+	if props.RetryMaxDelay < props.RetryInitialDelay {
+		props.RetryMaxDelay = defaultRefreshRetryMax
+	}
+	return props
+}
+
+// newRefreshingCredentialProvider starts refreshing the key of rotating with authClient.
+func newRefreshingCredentialProvider(
+	props RefreshingCredentialProviderProps, rotating *auth.RotatingCredentialProvider, authClient AuthClient,
+) *RefreshingCredentialProvider {
+	p := &RefreshingCredentialProvider{
+		RotatingCredentialProvider: rotating,
+		props:                      props,
+		log:                        props.AuthConfiguration.GetLoggerFactory().GetLogger("refreshing-credential-provider"),
+		authClient:                 authClient,
+		refreshToken:               props.RefreshToken,
+		expiresAt:                  auth.ExpiresAt(props.CredentialProvider),
+		refreshed:                  make(chan struct{}, 1),
+		stop:                       make(chan struct{}),
+		done:                       make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// ExpiresAt returns when the current key expires, or the zero time if it does not expire.
+func (p *RefreshingCredentialProvider) ExpiresAt() time.Time {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.expiresAt
+}
+
+// Refresh refreshes the key immediately. A refresh that is already in progress, such as a scheduled one,
+// completes first, and this one then uses the refresh token it obtained.
+func (p *RefreshingCredentialProvider) Refresh(ctx context.Context) error {
+	p.refreshMutex.Lock()
+	defer p.refreshMutex.Unlock()
+
+	p.mutex.Lock()
+	refreshToken := p.refreshToken
+	p.mutex.Unlock()
+
+	resp, err := p.authClient.RefreshApiKey(ctx, &RefreshApiKeyRequest{RefreshToken: refreshToken})
+	if err != nil {
+		return err
+	}
+	success, ok := resp.(*responses.RefreshApiKeySuccess)
+	if !ok {
+		return NewMomentoError(UnknownServiceError, fmt.Sprintf("unexpected refresh response %T", resp), nil)
+	}
+	provider, err := auth.NewStringMomentoTokenProvider(success.ApiKey)
+	if err != nil {
+		return err
+	}
+
+	var expiresAt time.Time
+	if success.ExpiresAt.DoesExpire() {
+		expiresAt = time.Unix(success.ExpiresAt.Epoch(), 0)
+	}
+	// The key is persisted before it is used, so that it is not lost if the process stops in between.
+	if p.props.Sink != nil {
+		if err := p.props.Sink.Save(ctx, RefreshedApiKey{
+			ApiKey:       success.ApiKey,
+			RefreshToken: success.RefreshToken,
+			Endpoint:     success.Endpoint,
+			ExpiresAt:    expiresAt,
+		}); err != nil {
+			return NewMomentoError(ClientSdkError, "failed to persist refreshed API key", err)
+		}
+	}
+
+	p.mutex.Lock()
+	p.refreshToken = success.RefreshToken
+	p.expiresAt = expiresAt
+	p.mutex.Unlock()
+	// The endpoints are kept, as they may have been overridden, and only the token is rotated.
+	if err := p.Rotate(provider.GetAuthToken()); err != nil {
+		return err
+	}
+	p.log.Info("Refreshed API key; it expires at %s", expiresAt)
+
+	// Wake the refresh loop so that it schedules the next refresh from the new expiry.
+	select {
+	case p.refreshed <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Close stops refreshing the key and closes the auth client.
+func (p *RefreshingCredentialProvider) Close() {
+	p.closeOnce.Do(func() {
+		close(p.stop)
+		<-p.done
+		p.authClient.Close()
+	})
+}
+
+func (p *RefreshingCredentialProvider) run() {
+	defer close(p.done)
+	var retryDelay time.Duration
+	for {
+		expiresAt := p.ExpiresAt()
+		if expiresAt.IsZero() {
+			p.log.Debug("API key does not expire; not scheduling a refresh")
+			select {
+			case <-p.refreshed:
+				continue
+			case <-p.stop:
+				return
+			}
+		}
+
+		wait := retryDelay
+		if wait == 0 {
+			wait = p.refreshDelay(time.Until(expiresAt))
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-p.refreshed:
+			timer.Stop()
+			retryDelay = 0
+			continue
+		case <-p.stop:
+			timer.Stop()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), defaultRefreshRequestTimeout)
+		err := p.Refresh(ctx)
+		cancel()
+		if err == nil {
+			retryDelay = 0
+			continue
+		}
+
+		if p.props.OnRefreshError != nil {
+			p.props.OnRefreshError(err)
+		}
+		if time.Now().After(expiresAt) {
+			p.log.Error("Failed to refresh API key before it expired at %s: %s", expiresAt, err)
+			// Wait for a manual refresh, which may use a new refresh token.
+			select {
+			case <-p.refreshed:
+				retryDelay = 0
+				continue
+			case <-p.stop:
+				return
+			}
+		}
+		retryDelay = p.nextRetryDelay(retryDelay, time.Until(expiresAt))
+		p.log.Warn("Failed to refresh API key, retrying in %s: %s", retryDelay, err)
+	}
+}
+
+// refreshDelay returns how long to wait before refreshing a key with the given remaining lifetime.
+func (p *RefreshingCredentialProvider) refreshDelay(remaining time.Duration) time.Duration {
+	wait := remaining - p.props.RefreshBefore
+	if wait <= 0 {
+		wait = remaining / 2
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return wait - time.Duration(rand.Float64()*p.props.Jitter*float64(wait))
+}
+
+// nextRetryDelay doubles the delay between failed refreshes, within the configured bounds and the key's
+// remaining lifetime.
+func (p *RefreshingCredentialProvider) nextRetryDelay(previous time.Duration, remaining time.Duration) time.Duration {
+	delay := previous * 2
+	if delay < p.props.RetryInitialDelay {
+		delay = p.props.RetryInitialDelay
+	}
+	if delay > p.props.RetryMaxDelay {
+		delay = p.props.RetryMaxDelay
+	}
+	if remaining > 0 && delay > remaining {
+		delay = remaining
+	}
+	return delay
+}
@@ -0,0 +1,186 @@
+package momento
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/momentohq/client-sdk-go/auth"
+	responses "github.com/momentohq/client-sdk-go/responses/auth"
+	"github.com/momentohq/client-sdk-go/utils"
+)
+
+// refreshedTestApiKey is a valid API key for test.momentohq.com that refreshes return.
+const refreshedTestApiKey = "eyJhcGlfa2V5IjogImV5SjBlWEFpT2lKS1YxUWlMQ0poYkdjaU9pSklVekkxTmlKOS5leUpwYzNNaU9pSlBibXhwYm1VZ1NsZFVJRUoxYVd4a1pYSWlMQ0pwWVhRaU9qRTJOemd6TURVNE1USXNJbVY0Y0NJNk5EZzJOVFV4TlRReE1pd2lZWFZrSWpvaUlpd2ljM1ZpSWpvaWFuSnZZMnRsZEVCbGVHRnRjR3hsTG1OdmJTSjkuOEl5OHE4NExzci1EM1lDb19IUDRkLXhqSGRUOFVDSXV2QVljeGhGTXl6OCIsICJlbmRwb2ludCI6ICJ0ZXN0Lm1vbWVudG9ocS5jb20ifQ=="
+
+// expiringCredentialProvider is a credential provider whose key expires at a given time.
+type expiringCredentialProvider struct {
+	auth.CredentialProvider
+	expiresAt time.Time
+}
+
+func (p expiringCredentialProvider) ExpiresAt() time.Time {
+	return p.expiresAt
+}
+
+// fakeRefreshClient is an AuthClient that answers refreshes with the results of fail, or else with a key
+// that expires in an hour and a refresh token derived from the one it was given.
+type fakeRefreshClient struct {
+	AuthClient
+	// delay is how long each refresh takes.
+	delay time.Duration
+	// fail returns the error of the call-th refresh, counting from 1, or nil for it to succeed.
+	fail func(call int) error
+
+	mutex  sync.Mutex
+	tokens []string
+	times  []time.Time
+	closed atomic.Bool
+}
+
+func (c *fakeRefreshClient) RefreshApiKey(_ context.Context, r *RefreshApiKeyRequest) (responses.RefreshApiKeyResponse, error) {
+	c.mutex.Lock()
+	c.tokens = append(c.tokens, r.RefreshToken)
+	c.times = append(c.times, time.Now())
+	call := len(c.tokens)
+	c.mutex.Unlock()
+
+	time.Sleep(c.delay)
+	if c.fail != nil {
+		if err := c.fail(call); err != nil {
+			return nil, err
+		}
+	}
+	return &responses.RefreshApiKeySuccess{
+		ApiKey:       refreshedTestApiKey,
+		RefreshToken: r.RefreshToken + "+",
+		Endpoint:     "test.momentohq.com",
+		ExpiresAt:    utils.ExpiresAtFromEpoch(time.Now().Add(time.Hour).Unix()),
+	}, nil
+}
+
+func (c *fakeRefreshClient) Close() {
+	c.closed.Store(true)
+}
+
+// refreshTokens returns the refresh tokens the client has been asked to refresh with.
+func (c *fakeRefreshClient) refreshTokens() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]string{}, c.tokens...)
+}
+
+var _ = Describe("refreshing-credential-provider", func() {
+	var client *fakeRefreshClient
+
+	// newProvider returns a provider for a key that expires after lifetime, or never if it is zero.
+	newProvider := func(props RefreshingCredentialProviderProps, lifetime time.Duration) *RefreshingCredentialProvider {
+		initial, err := auth.NewMomentoLocalProvider(nil)
+		Expect(err).To(BeNil())
+		var expiresAt time.Time
+		if lifetime > 0 {
+			expiresAt = time.Now().Add(lifetime)
+		}
+		props.CredentialProvider = expiringCredentialProvider{CredentialProvider: initial, expiresAt: expiresAt}
+		props.RefreshToken = "token"
+		// A negligible jitter keeps the refreshes on schedule.
+		props.Jitter = 1e-9
+		provider := newRefreshingCredentialProvider(
+			props.withDefaults(), auth.NewRotatingCredentialProvider(props.CredentialProvider), client,
+		)
+		DeferCleanup(provider.Close)
+		return provider
+	}
+
+	BeforeEach(func() {
+		client = &fakeRefreshClient{}
+	})
+
+	It("schedules refreshes ahead of the key's expiry", func() {
+		provider := newProvider(RefreshingCredentialProviderProps{RefreshBefore: time.Hour}, 0)
+		Expect(provider.refreshDelay(10 * time.Hour)).To(BeNumerically("~", 9*time.Hour, time.Second))
+		// Keys that expire sooner than RefreshBefore are refreshed halfway through their lifetime.
+		Expect(provider.refreshDelay(30 * time.Minute)).To(BeNumerically("~", 15*time.Minute, time.Second))
+		Expect(provider.refreshDelay(-time.Minute)).To(BeZero())
+	})
+
+	It("refreshes the key and uses the new refresh token", func() {
+		provider := newProvider(RefreshingCredentialProviderProps{RefreshBefore: 100 * time.Millisecond}, 200*time.Millisecond)
+
+		refreshed, err := auth.NewStringMomentoTokenProvider(refreshedTestApiKey)
+		Expect(err).To(BeNil())
+		Eventually(client.refreshTokens).Should(Equal([]string{"token"}))
+		Eventually(provider.GetAuthToken).Should(Equal(refreshed.GetAuthToken()))
+		Expect(provider.ExpiresAt()).To(BeTemporally("~", time.Now().Add(time.Hour), 2*time.Second))
+		// The next refresh is due an hour from now.
+		Consistently(client.refreshTokens, 200*time.Millisecond).Should(HaveLen(1))
+
+		Expect(provider.Refresh(context.Background())).To(Succeed())
+		Expect(client.refreshTokens()).To(Equal([]string{"token", "token+"}))
+	})
+
+	It("does not refresh keys that do not expire", func() {
+		newProvider(RefreshingCredentialProviderProps{}, 0)
+		Consistently(client.refreshTokens, 100*time.Millisecond).Should(BeEmpty())
+	})
+
+	It("backs off between failed refreshes", func() {
+		var failures atomic.Int32
+		client.fail = func(call int) error {
+			if call <= 3 {
+				return NewMomentoError(ServerUnavailableError, "unavailable", nil)
+			}
+			return nil
+		}
+		provider := newProvider(RefreshingCredentialProviderProps{
+			RefreshBefore:     time.Hour,
+			RetryInitialDelay: 20 * time.Millisecond,
+			RetryMaxDelay:     time.Second,
+			OnRefreshError:    func(error) { failures.Add(1) },
+		}, 400*time.Millisecond)
+
+		Eventually(client.refreshTokens).Should(HaveLen(4))
+		Eventually(provider.ExpiresAt).Should(BeTemporally(">", time.Now().Add(time.Minute)))
+		Expect(failures.Load()).To(Equal(int32(3)))
+
+		client.mutex.Lock()
+		times := client.times
+		client.mutex.Unlock()
+		Expect(times[1].Sub(times[0])).To(BeNumerically(">=", 20*time.Millisecond))
+		Expect(times[2].Sub(times[1])).To(BeNumerically(">=", 40*time.Millisecond))
+
+		Expect(provider.nextRetryDelay(0, time.Hour)).To(Equal(20 * time.Millisecond))
+		Expect(provider.nextRetryDelay(800*time.Millisecond, time.Hour)).To(Equal(time.Second))
+		Expect(provider.nextRetryDelay(100*time.Millisecond, 50*time.Millisecond)).To(Equal(50 * time.Millisecond))
+	})
+
+	It("spends each refresh token once when refreshes overlap", func() {
+		client.delay = 50 * time.Millisecond
+		provider := newProvider(RefreshingCredentialProviderProps{}, 0)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				Expect(provider.Refresh(context.Background())).To(Succeed())
+			}()
+		}
+		wg.Wait()
+		Expect(client.refreshTokens()).To(Equal([]string{"token", "token+", "token++"}))
+	})
+
+	It("stops refreshing and closes the auth client when closed", func() {
+		provider := newProvider(RefreshingCredentialProviderProps{RefreshBefore: 50 * time.Millisecond}, 100*time.Millisecond)
+		provider.Close()
+		Expect(client.closed.Load()).To(BeTrue())
+		Consistently(client.refreshTokens, 150*time.Millisecond).Should(BeEmpty())
+		// Closing again does nothing.
+		provider.Close()
+	})
+})
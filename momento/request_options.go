@@ -0,0 +1,41 @@
+package momento
+
+import (
+	"context"
+	"time"
+
+	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/config/retry"
+	"github.com/momentohq/client-sdk-go/internal/models"
+)
+
+// RequestOptions override the client configuration for cache requests made with a context returned by
+// WithRequestOptions. Fields left at their zero value keep the configured setting.
+type RequestOptions struct {
+	// Timeout replaces the client side timeout of the configuration's transport strategy.
+	Timeout time.Duration
+	// RetryStrategy replaces the configuration's retry strategy. Use retry.NewNeverRetryStrategy() to
+	// disable retries.
+	RetryStrategy retry.Strategy
+	// ReadConcern replaces the configuration's read concern.
+	ReadConcern config.ReadConcern
+	// Metadata is sent with the request as additional gRPC metadata.
+	Metadata map[string]string
+}
+
+// WithRequestOptions returns a copy of ctx that carries options for the requests made with it. Options
+// already carried by ctx are kept unless they are set again, so that options can be layered:
+//
+//	latencyCtx := momento.WithRequestOptions(ctx, momento.RequestOptions{
+//		Timeout:       50 * time.Millisecond,
+//		RetryStrategy: retry.NewNeverRetryStrategy(),
+//	})
+//	resp, err := client.Get(latencyCtx, &momento.GetRequest{...})
+func WithRequestOptions(ctx context.Context, options RequestOptions) context.Context {
+	return models.WithRequestOverrides(ctx, models.RequestOverrides{
+		Timeout:       options.Timeout,
+		RetryStrategy: options.RetryStrategy,
+		ReadConcern:   options.ReadConcern,
+		Metadata:      options.Metadata,
+	})
+}
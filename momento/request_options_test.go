@@ -0,0 +1,215 @@
+package momento_test
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/config/batching"
+	"github.com/momentohq/client-sdk-go/config/channelselection"
+	"github.com/momentohq/client-sdk-go/config/circuitbreaker"
+	"github.com/momentohq/client-sdk-go/config/retry"
+	pb "github.com/momentohq/client-sdk-go/internal/protos"
+	. "github.com/momentohq/client-sdk-go/momento"
+	helpers "github.com/momentohq/client-sdk-go/momento/test_helpers"
+	"github.com/momentohq/client-sdk-go/responses"
+)
+
+// retryOnceStrategy retries a request once, without waiting, and counts how often it is consulted.
+type retryOnceStrategy struct {
+	consulted atomic.Int32
+}
+
+func (s *retryOnceStrategy) DetermineWhenToRetry(props retry.StrategyProps) *int {
+	s.consulted.Add(1)
+	if props.AttemptNumber > 1 {
+		return nil
+	}
+	delay := 0
+	return &delay
+}
+
+var _ = Describe("request-options", func() {
+	var (
+		server *helpers.FakeCacheServer
+		// calls counts the Get requests the server has received.
+		calls atomic.Int32
+		// onGet holds the getHandler that answers the server's Get requests.
+		onGet atomic.Value
+	)
+
+	newClient := func(cacheConfig config.Configuration) CacheClient {
+		credentialProvider, err := server.CredentialProvider()
+		Expect(err).To(BeNil())
+		client, err := NewCacheClient(cacheConfig, credentialProvider, time.Minute)
+		Expect(err).To(BeNil())
+		DeferCleanup(client.Close)
+		return client
+	}
+
+	getRequest := &GetRequest{CacheName: "cache", Key: String("key")}
+
+	BeforeEach(func() {
+		calls.Store(0)
+		onGet.Store(getHandler(func(context.Context, int32) (*pb.XGetResponse, error) {
+			return &pb.XGetResponse{Result: pb.ECacheResult_Miss}, nil
+		}))
+		var err error
+		server, err = helpers.NewFakeCacheServer(helpers.FakeCacheServerProps{
+			OnGet: func(ctx context.Context, _ *pb.XGetRequest) (*pb.XGetResponse, error) {
+				call := calls.Add(1)
+				return onGet.Load().(getHandler)(ctx, call)
+			},
+		})
+		Expect(err).To(BeNil())
+		DeferCleanup(server.Close)
+	})
+
+	It("applies the request's timeout", func() {
+		deadlines := make(chan time.Duration, 1)
+		onGet.Store(getHandler(func(ctx context.Context, _ int32) (*pb.XGetResponse, error) {
+			deadline, _ := ctx.Deadline()
+			deadlines <- time.Until(deadline)
+			<-ctx.Done()
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}))
+		client := newClient(config.LaptopLatest().
+			WithClientTimeout(time.Minute).
+			WithRetryStrategy(retry.NewNeverRetryStrategy()))
+
+		ctx := WithRequestOptions(context.Background(), RequestOptions{Timeout: 100 * time.Millisecond})
+		start := time.Now()
+		_, err := client.Get(ctx, getRequest)
+		Expect(err).To(HaveMomentoErrorCode(TimeoutError))
+		Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+		Expect(<-deadlines).To(BeNumerically("<=", 100*time.Millisecond))
+	})
+
+	It("does not count timeouts of the request's shorter timeout against the circuit breaker", func() {
+		onGet.Store(getHandler(func(ctx context.Context, _ int32) (*pb.XGetResponse, error) {
+			<-ctx.Done()
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}))
+		client := newClient(config.LaptopLatest().
+			WithRetryStrategy(retry.NewNeverRetryStrategy()).
+			WithCircuitBreaker(circuitbreaker.Props{MinimumRequests: 2}))
+
+		ctx := WithRequestOptions(context.Background(), RequestOptions{Timeout: 50 * time.Millisecond})
+		for i := 0; i < 3; i++ {
+			_, err := client.Get(ctx, getRequest)
+			Expect(err).To(HaveMomentoErrorCode(TimeoutError))
+		}
+		onGet.Store(getHandler(func(context.Context, int32) (*pb.XGetResponse, error) {
+			return &pb.XGetResponse{Result: pb.ECacheResult_Miss}, nil
+		}))
+		_, err := client.Get(context.Background(), getRequest)
+		Expect(err).To(BeNil())
+	})
+
+	Describe("with channel selection", func() {
+		var client CacheClient
+
+		BeforeEach(func() {
+			client = newClient(config.LaptopLatest().
+				WithNumGrpcChannels(1).
+				WithClientTimeout(100 * time.Millisecond).
+				WithRetryStrategy(retry.NewNeverRetryStrategy()).
+				WithChannelSelection(channelselection.Props{StallTimeout: 50 * time.Millisecond, CheckInterval: 10 * time.Millisecond}))
+		})
+
+		// getLong sends a Get with a timeout longer than the client's and returns the channel its error is
+		// sent on.
+		getLong := func() chan error {
+			errs := make(chan error, 1)
+			go func() {
+				ctx := WithRequestOptions(context.Background(), RequestOptions{Timeout: 5 * time.Second})
+				_, err := client.Get(ctx, getRequest)
+				errs <- err
+			}()
+			return errs
+		}
+
+		It("does not replace a channel whose requests are within their deadlines", func() {
+			onGet.Store(getHandler(func(context.Context, int32) (*pb.XGetResponse, error) {
+				time.Sleep(500 * time.Millisecond)
+				return &pb.XGetResponse{Result: pb.ECacheResult_Miss}, nil
+			}))
+			Eventually(getLong(), 2*time.Second).Should(Receive(BeNil()))
+		})
+
+		It("keeps a replaced channel open for the requests still within their deadlines", func() {
+			onGet.Store(getHandler(func(ctx context.Context, call int32) (*pb.XGetResponse, error) {
+				if call == 1 {
+					time.Sleep(500 * time.Millisecond)
+					return &pb.XGetResponse{Result: pb.ECacheResult_Miss}, nil
+				}
+				<-ctx.Done()
+				return nil, status.FromContextError(ctx.Err()).Err()
+			}))
+			errs := getLong()
+			Eventually(calls.Load).Should(Equal(int32(1)))
+			// The timeout stalls the channel, which is replaced while the long Get is in flight.
+			_, err := client.Get(context.Background(), getRequest)
+			Expect(err).To(HaveMomentoErrorCode(TimeoutError))
+			Eventually(errs, 2*time.Second).Should(Receive(BeNil()))
+		})
+	})
+
+	It("applies the request's retry strategy", func() {
+		onGet.Store(getHandler(func(context.Context, int32) (*pb.XGetResponse, error) {
+			return nil, status.Error(codes.Unavailable, "unavailable")
+		}))
+		client := newClient(config.LaptopLatest().WithRetryStrategy(retry.NewNeverRetryStrategy()))
+
+		_, err := client.Get(context.Background(), getRequest)
+		Expect(err).To(HaveMomentoErrorCode(ServerUnavailableError))
+		Expect(calls.Load()).To(Equal(int32(1)))
+
+		strategy := &retryOnceStrategy{}
+		ctx := WithRequestOptions(context.Background(), RequestOptions{RetryStrategy: strategy})
+		_, err = client.Get(ctx, getRequest)
+		Expect(err).To(HaveMomentoErrorCode(ServerUnavailableError))
+		Expect(calls.Load()).To(Equal(int32(3)))
+		Expect(strategy.consulted.Load()).To(Equal(int32(2)))
+	})
+
+	It("sends the request's read concern and metadata", func() {
+		headers := make(chan metadata.MD, 1)
+		onGet.Store(getHandler(func(ctx context.Context, _ int32) (*pb.XGetResponse, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			headers <- md
+			return &pb.XGetResponse{Result: pb.ECacheResult_Miss}, nil
+		}))
+		client := newClient(config.LaptopLatest().WithReadConcern(config.BALANCED))
+
+		ctx := WithRequestOptions(context.Background(), RequestOptions{
+			ReadConcern: config.CONSISTENT,
+			Metadata:    map[string]string{"tenant": "blue"},
+		})
+		_, err := client.Get(ctx, getRequest)
+		Expect(err).To(BeNil())
+		md := <-headers
+		Expect(md.Get("read-concern")).To(Equal([]string{string(config.CONSISTENT)}))
+		Expect(md.Get("tenant")).To(Equal([]string{"blue"}))
+	})
+
+	It("does not batch requests with options of their own", func() {
+		// The server cannot answer GetBatch requests, and the window would outlast the spec.
+		client := newClient(config.LaptopLatest().WithAutoBatching(batching.Props{Window: time.Hour}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		ctx = WithRequestOptions(ctx, RequestOptions{Timeout: time.Second})
+		resp, err := client.Get(ctx, getRequest)
+		Expect(err).To(BeNil())
+		Expect(resp).To(BeAssignableToTypeOf(&responses.GetMiss{}))
+		Expect(calls.Load()).To(Equal(int32(1)))
+	})
+})
@@ -0,0 +1,74 @@
+package momento_test
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/momentohq/client-sdk-go/config"
+	"github.com/momentohq/client-sdk-go/config/retry"
+	pb "github.com/momentohq/client-sdk-go/internal/protos"
+	. "github.com/momentohq/client-sdk-go/momento"
+	helpers "github.com/momentohq/client-sdk-go/momento/test_helpers"
+)
+
+var _ = Describe("retry-budget", func() {
+	var (
+		budget *retry.RetryBudget
+		client CacheClient
+	)
+
+	// newClient returns a client whose retries spend budget, for a server that fails each Get and asks for
+	// it to be retried after retryAfter.
+	newClient := func(clientTimeout time.Duration, retryAfter time.Duration) {
+		server, err := helpers.NewFakeCacheServer(helpers.FakeCacheServerProps{
+			OnGet: func(ctx context.Context, _ *pb.XGetRequest) (*pb.XGetResponse, error) {
+				_ = grpc.SetTrailer(ctx, metadata.Pairs("retry-after-ms", strconv.FormatInt(retryAfter.Milliseconds(), 10)))
+				return nil, status.Error(codes.Unavailable, "unavailable")
+			},
+		})
+		Expect(err).To(BeNil())
+		DeferCleanup(server.Close)
+
+		budget = retry.NewRetryBudget(retry.RetryBudgetProps{MaxTokens: 1})
+		credentialProvider, err := server.CredentialProvider()
+		Expect(err).To(BeNil())
+		client, err = NewCacheClient(
+			config.LaptopLatest().
+				WithClientTimeout(clientTimeout).
+				WithRetryStrategy(retry.NewRetryBudgetStrategy(retry.RetryBudgetStrategyProps{
+					Strategy: retry.NewFixedCountRetryStrategy(retry.FixedCountRetryStrategyProps{}),
+					Budget:   budget,
+				})),
+			credentialProvider,
+			time.Minute,
+		)
+		Expect(err).To(BeNil())
+		DeferCleanup(client.Close)
+	}
+
+	It("refunds a retry the server asks to make after the deadline", func() {
+		newClient(200*time.Millisecond, 10*time.Second)
+		_, err := client.Get(context.Background(), &GetRequest{CacheName: "cache", Key: String("key")})
+		Expect(err).To(HaveMomentoErrorCode(ServerUnavailableError))
+		Expect(budget.Tokens()).To(Equal(1.0))
+	})
+
+	It("stops waiting to retry when the request is cancelled, and refunds the retry", func() {
+		newClient(time.Minute, 30*time.Second)
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+		start := time.Now()
+		_, err := client.Get(ctx, &GetRequest{CacheName: "cache", Key: String("key")})
+		Expect(err).To(HaveMomentoErrorCode(ServerUnavailableError))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+		Expect(budget.Tokens()).To(Equal(1.0))
+	})
+})
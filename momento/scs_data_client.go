@@ -6,8 +6,11 @@ import (
 	"reflect"
 	"time"
 
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/metadata"
 
+	"github.com/momentohq/client-sdk-go/config/circuitbreaker"
+	"github.com/momentohq/client-sdk-go/config/concurrency"
 	"github.com/momentohq/client-sdk-go/config/logger"
 	"github.com/momentohq/client-sdk-go/config/middleware"
 	"github.com/momentohq/client-sdk-go/internal"
@@ -30,6 +33,14 @@ type scsDataClient struct {
 	loggerFactory       logger.MomentoLoggerFactory
 	logger              logger.MomentoLogger
 	middleware          []middleware.Middleware
+	// circuitBreaker is shared by all of a cache client's data clients. Nil if it is disabled.
+	circuitBreaker *circuitbreaker.CircuitBreaker
+	// limiter bounds the requests in flight. It comes from the configuration and may be shared with other
+	// clients. Nil if it is disabled.
+	limiter *concurrency.Limiter
+	load    *channelLoad
+	// operations tracks the requests in flight so that the cache client can drain them when it shuts down.
+	operations *operationTracker
 }
 
 func newScsDataClient(request *models.DataClientRequest, eagerConnectTimeout time.Duration) (*scsDataClient, momentoerrors.MomentoSvcErr) {
@@ -39,6 +50,7 @@ func newScsDataClient(request *models.DataClientRequest, eagerConnectTimeout tim
 		ReadConcern:        request.Configuration.GetReadConcern(),
 		GrpcConfiguration:  request.Configuration.GetTransportStrategy().GetGrpcConfig(),
 		Middleware:         request.Configuration.GetMiddleware(),
+		Channels:           request.Channels,
 	})
 	if err != nil {
 		return nil, err
@@ -55,7 +67,7 @@ func newScsDataClient(request *models.DataClientRequest, eagerConnectTimeout tim
 
 	return &scsDataClient{
 		grpcManager:         dataManager,
-		grpcClient:          pb.NewScsClient(dataManager.Conn),
+		grpcClient:          pb.NewScsClient(dataManager.Channel),
 		defaultTtl:          request.DefaultTtl,
 		requestTimeout:      timeout,
 		endpoint:            request.CredentialProvider.GetCacheEndpoint(),
@@ -63,6 +75,8 @@ func newScsDataClient(request *models.DataClientRequest, eagerConnectTimeout tim
 		loggerFactory:       lf,
 		logger:              lg,
 		middleware:          request.Configuration.GetMiddleware(),
+		limiter:             request.Configuration.GetConcurrencyLimiter(),
+		load:                &channelLoad{},
 	}, nil
 }
 
@@ -79,7 +93,7 @@ func deepCopyMap(original map[string]string) map[string]string {
 }
 
 func (client scsDataClient) applyMiddlewareRequestHandlers(
-	r requester, requestMetadata map[string]string,
+	ctx context.Context, r requester, requestMetadata map[string]string,
 ) ([]middleware.RequestHandler, requester, map[string]string, error) {
 	middlewareRequestHandlers := make([]middleware.RequestHandler, 0, len(client.middleware))
 	for _, mw := range client.middleware {
@@ -93,7 +107,12 @@ func (client scsDataClient) applyMiddlewareRequestHandlers(
 		// If the middleware is allowed to handle this request type, we use the base handler
 		// to compose a more specific handler off of. An error here means something actually went wrong,
 		// so we return it.
-		newHandler, err := mw.GetRequestHandler(newBaseHandler)
+		var newHandler middleware.RequestHandler
+		if cmw, ok := mw.(middleware.ContextMiddleware); ok {
+			newHandler, err = cmw.GetRequestHandlerWithContext(ctx, newBaseHandler)
+		} else {
+			newHandler, err = mw.GetRequestHandler(newBaseHandler)
+		}
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -135,11 +154,13 @@ func (client scsDataClient) applyMiddlewareResponseHandlers(
 	responseMetadata []metadata.MD,
 ) (interface{}, error) {
 	for i := len(middlewareRequestHandlers) - 1; i >= 0; i-- {
-		var requestHandlerError error
 		rh := middlewareRequestHandlers[i]
 		newResp, err := rh.OnResponse(resp)
 		if err != nil {
-			return nil, momentoerrors.ConvertSvcErr(requestHandlerError, responseMetadata...)
+			if momentoErr, ok := err.(MomentoError); ok {
+				return nil, momentoErr
+			}
+			return nil, momentoerrors.ConvertSvcErr(err, responseMetadata...)
 		}
 		if newResp != nil {
 			resp = newResp
@@ -148,7 +169,143 @@ func (client scsDataClient) applyMiddlewareResponseHandlers(
 	return resp, nil
 }
 
+// InFlight returns the number of requests in flight on the client's channel.
+func (client scsDataClient) InFlight() int64 {
+	return client.load.inFlight.Load()
+}
+
+// Ping sends a ping on the client's channel, so that the health monitor checks the channel requests use.
+func (client scsDataClient) Ping(ctx context.Context) error {
+	requestMetadata := internal.CreateMetadata(ctx, internal.Ping)
+	if _, err := pb.NewPingClient(client.grpcManager.Channel).Ping(requestMetadata, &pb.XPingRequest{}); err != nil {
+		return convertMomentoSvcErrorToCustomerError(momentoerrors.ConvertSvcErr(err))
+	}
+	return nil
+}
+
+// ConnectivityState returns the state of the client's channel.
+func (client scsDataClient) ConnectivityState() connectivity.State {
+	return client.grpcManager.Conn.GetState()
+}
+
+// WaitForStateChange waits until the state of the client's channel differs from source.
+func (client scsDataClient) WaitForStateChange(ctx context.Context, source connectivity.State) bool {
+	return client.grpcManager.Conn.WaitForStateChange(ctx, source)
+}
+
+// isHealthy returns false if the client's channel is in TRANSIENT_FAILURE.
+func (client scsDataClient) isHealthy() bool {
+	return client.grpcManager.Conn.GetState() != connectivity.TransientFailure
+}
+
 func (client scsDataClient) makeRequest(ctx context.Context, r requester) (interface{}, error) {
+	if client.operations != nil && !isTrackedOperation(ctx) {
+		end, err := client.operations.begin(r.requestName())
+		if err != nil {
+			return nil, err
+		}
+		defer end()
+	}
+	release, err := client.acquireConcurrencySlot(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	// A timeout only indicates that the service is unhealthy if the request had the whole client timeout to
+	// complete, so one caller's tight deadline does not count against the service for everyone else.
+	fullTimeout := !client.hasShortDeadline(ctx)
+	start := time.Now()
+	deadline := start.Add(client.timeoutFor(ctx))
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	client.load.begin(deadline)
+	resp, err := client.makeRequestThroughCircuitBreaker(ctx, r, fullTimeout)
+	client.load.end(isTimeout(err) && fullTimeout)
+	release(concurrency.Outcome{Overloaded: isServiceFailure(err, fullTimeout), Latency: time.Since(start)})
+	return resp, err
+}
+
+// acquireConcurrencySlot waits, for at most the request timeout, until the concurrency limiter admits the
+// request. The returned function reports the outcome of the request to the limiter.
+func (client scsDataClient) acquireConcurrencySlot(ctx context.Context, r requester) (func(concurrency.Outcome), error) {
+	if client.limiter == nil {
+		return func(concurrency.Outcome) {}, nil
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, client.timeoutFor(ctx))
+	defer cancel()
+	release, err := client.limiter.Acquire(waitCtx)
+	if err == nil {
+		return release, nil
+	}
+	client.logger.Debug("concurrency limit reached, rejecting %v request on cache %v: %v", r.requestName(), r.cacheName(), err)
+	switch {
+	case ctx.Err() == context.Canceled:
+		return nil, NewMomentoError(CanceledError, "context was cancelled while waiting for a concurrency slot", ctx.Err())
+	case ctx.Err() == context.DeadlineExceeded:
+		return nil, NewMomentoError(TimeoutError, "context deadline exceeded while waiting for a concurrency slot", ctx.Err())
+	default:
+		return nil, NewMomentoError(
+			ClientResourceExhaustedError,
+			fmt.Sprintf("client concurrency limit of %d requests reached; the request was not sent", client.limiter.Limit()),
+			err,
+		)
+	}
+}
+
+// timeoutFor returns the timeout of a request, which a request override in ctx may set.
+func (client scsDataClient) timeoutFor(ctx context.Context) time.Duration {
+	if overrides, ok := models.RequestOverridesFromContext(ctx); ok && overrides.Timeout > 0 {
+		return overrides.Timeout
+	}
+	return client.requestTimeout
+}
+
+// hasShortDeadline reports whether a request made with ctx must complete sooner than the client timeout,
+// because of a request override or the deadline of ctx itself.
+func (client scsDataClient) hasShortDeadline(ctx context.Context) bool {
+	if client.timeoutFor(ctx) < client.requestTimeout {
+		return true
+	}
+	deadline, ok := ctx.Deadline()
+	return ok && time.Until(deadline) < client.requestTimeout
+}
+
+func (client scsDataClient) makeRequestThroughCircuitBreaker(ctx context.Context, r requester, fullTimeout bool) (interface{}, error) {
+	if client.circuitBreaker == nil {
+		return client.sendRequest(ctx, r)
+	}
+
+	done, ok := client.circuitBreaker.Allow(r.cacheName())
+	if !ok {
+		client.logger.Debug("circuit is open, failing %v request on cache %v", r.requestName(), r.cacheName())
+		return nil, NewMomentoError(
+			CircuitOpenError,
+			fmt.Sprintf("circuit breaker is open for cache %s; the request was not sent", r.cacheName()),
+			nil,
+		)
+	}
+	start := time.Now()
+	resp, err := client.sendRequest(ctx, r)
+	done(circuitbreaker.Outcome{Failed: isServiceFailure(err, fullTimeout), Latency: time.Since(start)})
+	return resp, err
+}
+
+// isServiceFailure reports whether err indicates that the service is unhealthy, as opposed to a problem
+// with the request itself. Timeouts count only if fullTimeout is set, meaning the request had the whole
+// client timeout to complete.
+func isServiceFailure(err error, fullTimeout bool) bool {
+	if momentoErr, ok := err.(MomentoError); ok {
+		return (momentoErr.Code() == TimeoutError && fullTimeout) || momentoErr.Code() == ServerUnavailableError
+	}
+	return false
+}
+
+func isTimeout(err error) bool {
+	momentoErr, ok := err.(MomentoError)
+	return ok && momentoErr.Code() == TimeoutError
+}
+
+func (client scsDataClient) sendRequest(ctx context.Context, r requester) (interface{}, error) {
 	client.logger.Debug("%v request made on cache %v", r.requestName(), r.cacheName())
 	if _, err := prepareCacheName(r); err != nil {
 		return nil, err
@@ -156,42 +313,60 @@ func (client scsDataClient) makeRequest(ctx context.Context, r requester) (inter
 
 	var middlewareRequestHandlers []middleware.RequestHandler
 	requestMetadata := make(map[string]string)
+	timeout := client.requestTimeout
+	if overrides, ok := models.RequestOverridesFromContext(ctx); ok {
+		for key, value := range overrides.Metadata {
+			requestMetadata[key] = value
+		}
+		if overrides.Timeout > 0 {
+			timeout = overrides.Timeout
+		}
+	}
 	var err error
-	middlewareRequestHandlers, r, requestMetadata, err = client.applyMiddlewareRequestHandlers(r, requestMetadata)
+	middlewareRequestHandlers, r, requestMetadata, err = client.applyMiddlewareRequestHandlers(ctx, r, requestMetadata)
 	if err != nil {
-		client.logger.Error("failed to apply middleware request handlers: %v", err)
+		client.requestLogger(r).Error("failed to apply middleware request handlers: %v", err)
 		return nil, err
 	}
 	req, err := r.initGrpcRequest(client)
 	if err != nil {
-		client.logger.Error("failed to init gRPC request: %v", err)
+		client.requestLogger(r).Error("failed to init gRPC request: %v", err)
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, client.requestTimeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	requestContext := internal.CreateCacheRequestContextFromMetadataMap(ctx, r.cacheName(), requestMetadata)
 	resp, responseMetadata, err := r.makeGrpcRequest(req, requestContext, client)
 	if err != nil {
-		client.logger.Error("gRPC request failed: %v, responseMetadata=%v", err, responseMetadata)
+		client.requestLogger(r).Error("gRPC request failed: %v, responseMetadata=%v", err, responseMetadata)
 		return nil, momentoerrors.ConvertSvcErr(err, responseMetadata...)
 	}
 
 	momentoResp, err := r.interpretGrpcResponse(resp)
 	if err != nil {
-		client.logger.Error("failed to interpret gRPC response: %v", err)
+		client.requestLogger(r).Error("failed to interpret gRPC response: %v", err)
 		return nil, err
 	}
 
 	momentoResp, err = client.applyMiddlewareResponseHandlers(middlewareRequestHandlers, momentoResp, responseMetadata)
 	if err != nil {
-		client.logger.Error("failed to apply middleware response handlers: %v", err)
+		client.requestLogger(r).Error("failed to apply middleware response handlers: %v", err)
 		return nil, err
 	}
 	return momentoResp, nil
 }
 
+// requestLogger returns a logger that attaches the cache and request names of r to its messages. It is only
+// built where a failure is logged, so that requests that succeed do not pay for the fields.
+func (client scsDataClient) requestLogger(r requester) logger.MomentoLogger {
+	return logger.With(client.logger,
+		logger.Field{Key: logger.CacheNameKey, Value: r.cacheName()},
+		logger.Field{Key: logger.RequestNameKey, Value: r.requestName()},
+	)
+}
+
 func (client scsDataClient) Connect() error {
 	timeout := defaultEagerConnectTimeout
 	if client.eagerConnectTimeout > 0 {
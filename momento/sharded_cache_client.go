@@ -0,0 +1,1006 @@
+package momento
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/momentohq/client-sdk-go/config/health"
+	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/responses"
+)
+
+const defaultVirtualNodes = 100
+
+// ShardedCacheClientProps configures a ShardedCacheClient. The zero value of each optional field selects
+// its default.
+type ShardedCacheClientProps struct {
+	// Client sends the requests to the shards.
+	Client CacheClient
+	// Caches are the names of the caches that items are spread across. The caches must already exist.
+	Caches []string
+	// VirtualNodes is the number of points each cache has on the hash ring. More points spread items more
+	// evenly across the caches at the cost of a larger ring. Defaults to 100.
+	VirtualNodes int
+}
+
+// ShardedCacheClient is a CacheClient that spreads items across several caches. Each item is stored in the
+// cache that its key, or its collection's name, hashes to on a consistent-hash ring, so adding or removing
+// a cache moves only the items that hash to it. The CacheName of data requests is ignored.
+type ShardedCacheClient interface {
+	CacheClient
+
+	// ShardFor returns the cache that stores the item with the given key, or the collection with the given name.
+	ShardFor(key Key) string
+	// BeginResharding starts spreading items across a new set of caches. Writes go to the new shards, and
+	// reads that miss in an item's new shard are retried in its previous one until FinishResharding is
+	// called, which gives items written before resharding time to be rewritten or to expire. Until then,
+	// writes that update an item's current value, such as Increment, the SetIf* family and collection
+	// updates, fail with FailedPreconditionError for items that are moving to another cache.
+	BeginResharding(caches []string) error
+	// FinishResharding stops reading from the caches that items were stored in before BeginResharding.
+	FinishResharding() error
+}
+
+type shardedCacheClient struct {
+	props ShardedCacheClientProps
+	rings atomic.Pointer[shardRings]
+	// reshardMutex serializes BeginResharding and FinishResharding.
+	reshardMutex sync.Mutex
+}
+
+// shardRings are the hash rings in use. previous is set only while resharding.
+type shardRings struct {
+	current  *hashRing
+	previous *hashRing
+}
+
+// NewShardedCacheClient returns a CacheClient that spreads items across the given caches. Closing the
+// returned client closes the underlying client.
+func NewShardedCacheClient(props ShardedCacheClientProps) (ShardedCacheClient, error) {
+	if props.Client == nil {
+		return nil, NewMomentoError(InvalidArgumentError, "a client must be provided", nil)
+	}
+	if err := validateShards(props.Caches); err != nil {
+		return nil, err
+	}
+	if props.VirtualNodes <= 0 {
+		props.VirtualNodes = defaultVirtualNodes
+	}
+	client := &shardedCacheClient{props: props}
+	client.rings.Store(&shardRings{current: newHashRing(props.Caches, props.VirtualNodes)})
+	return client, nil
+}
+
+func validateShards(caches []string) error {
+	if len(caches) == 0 {
+		return NewMomentoError(InvalidArgumentError, "at least one cache must be provided", nil)
+	}
+	names := make(map[string]bool, len(caches))
+	for _, cache := range caches {
+		if err := isCacheNameValid(cache); err != nil {
+			return convertMomentoSvcErrorToCustomerError(err)
+		}
+		if names[cache] {
+			return NewMomentoError(InvalidArgumentError, fmt.Sprintf("cache %s is provided more than once", cache), nil)
+		}
+		names[cache] = true
+	}
+	return nil
+}
+
+func (c *shardedCacheClient) ShardFor(key Key) string {
+	return c.rings.Load().current.shard(shardKey(key))
+}
+
+func (c *shardedCacheClient) BeginResharding(caches []string) error {
+	if err := validateShards(caches); err != nil {
+		return err
+	}
+	c.reshardMutex.Lock()
+	defer c.reshardMutex.Unlock()
+	rings := c.rings.Load()
+	if rings.previous != nil {
+		return NewMomentoError(FailedPreconditionError, "resharding is already in progress", nil)
+	}
+	c.rings.Store(&shardRings{
+		current:  newHashRing(caches, c.props.VirtualNodes),
+		previous: rings.current,
+	})
+	return nil
+}
+
+func (c *shardedCacheClient) FinishResharding() error {
+	c.reshardMutex.Lock()
+	defer c.reshardMutex.Unlock()
+	rings := c.rings.Load()
+	if rings.previous == nil {
+		return NewMomentoError(FailedPreconditionError, "resharding is not in progress", nil)
+	}
+	c.rings.Store(&shardRings{current: rings.current})
+	return nil
+}
+
+func (c *shardedCacheClient) Logger() logger.MomentoLogger {
+	return c.props.Client.Logger()
+}
+
+func (c *shardedCacheClient) Health() health.Health {
+	return Health(c.props.Client)
+}
+
+func (c *shardedCacheClient) Ping(ctx context.Context) (responses.PingResponse, error) {
+	return c.props.Client.Ping(ctx)
+}
+
+func (c *shardedCacheClient) Shutdown(ctx context.Context) (ShutdownSummary, error) {
+	return Shutdown(ctx, c.props.Client)
+}
+
+func (c *shardedCacheClient) Close() {
+	c.props.Client.Close()
+}
+
+// hashRing maps keys to caches. Each cache owns several points on the ring, and a key belongs to the
+// owner of the first point at or after the key's hash.
+type hashRing struct {
+	caches []string
+	points []uint64
+	// owners[i] is the index in caches of the owner of points[i].
+	owners []int
+}
+
+func newHashRing(caches []string, virtualNodes int) *hashRing {
+	type point struct {
+		hash  uint64
+		owner int
+	}
+	points := make([]point, 0, len(caches)*virtualNodes)
+	for owner, cache := range caches {
+		for i := 0; i < virtualNodes; i++ {
+			points = append(points, point{hash: hashKey([]byte(cache + "#" + strconv.Itoa(i))), owner: owner})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].hash < points[j].hash
+	})
+	ring := &hashRing{
+		caches: append([]string(nil), caches...),
+		points: make([]uint64, len(points)),
+		owners: make([]int, len(points)),
+	}
+	for i, p := range points {
+		ring.points[i] = p.hash
+		ring.owners[i] = p.owner
+	}
+	return ring
+}
+
+func (r *hashRing) shard(key []byte) string {
+	return r.caches[r.owner(key)]
+}
+
+func (r *hashRing) owner(key []byte) int {
+	hash := hashKey(key)
+	i := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i] >= hash
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.owners[i]
+}
+
+// shardPart is the part of a batch stored in one cache. indexes are the positions of its elements in the batch.
+type shardPart struct {
+	cache   string
+	indexes []int
+}
+
+// split groups the keys of a batch by the cache that stores them, in the order of the ring's caches.
+func (r *hashRing) split(keys [][]byte) []shardPart {
+	byOwner := make([][]int, len(r.caches))
+	for i, key := range keys {
+		owner := r.owner(key)
+		byOwner[owner] = append(byOwner[owner], i)
+	}
+	var parts []shardPart
+	for owner, indexes := range byOwner {
+		if len(indexes) > 0 {
+			parts = append(parts, shardPart{cache: r.caches[owner], indexes: indexes})
+		}
+	}
+	return parts
+}
+
+// hashKey is FNV-1a followed by a finalizer that spreads the similar hashes of similar keys across the ring.
+func hashKey(key []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(key)
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+func shardKey(key Value) []byte {
+	if key == nil {
+		return nil
+	}
+	return key.asBytes()
+}
+
+// shardRequest sends a request to the cache that stores key. send sends a copy of the request to the given
+// cache.
+func shardRequest[T any](c *shardedCacheClient, key []byte, send func(cache string) (T, error)) (T, error) {
+	return send(c.rings.Load().current.shard(key))
+}
+
+// shardMutation sends a write whose effect depends on the item's current value, such as an increment, a
+// conditional set or a collection update, to the cache that stores key. While resharding, it is rejected
+// for items that are moving to another cache, as they may still be in their previous cache and the write
+// would be applied without their current value.
+func shardMutation[T any](c *shardedCacheClient, key []byte, send func(cache string) (T, error)) (T, error) {
+	rings := c.rings.Load()
+	cache := rings.current.shard(key)
+	if rings.previous != nil && rings.previous.shard(key) != cache {
+		var zero T
+		return zero, NewMomentoError(
+			FailedPreconditionError,
+			fmt.Sprintf("the item is moving to cache %s while resharding; it cannot be updated until resharding finishes", cache),
+			nil,
+		)
+	}
+	return send(cache)
+}
+
+// shardRead sends a read to the cache that stores key. While resharding, a miss is retried in the cache
+// that stored key before. If that fails too, the miss is returned, since the previous cache may no
+// longer exist.
+func shardRead[T any](c *shardedCacheClient, key []byte, send func(cache string) (T, error)) (T, error) {
+	rings := c.rings.Load()
+	cache := rings.current.shard(key)
+	resp, err := send(cache)
+	if err != nil || rings.previous == nil || !isMiss(resp) {
+		return resp, err
+	}
+	previous := rings.previous.shard(key)
+	if previous == cache {
+		return resp, nil
+	}
+	previousResp, previousErr := send(previous)
+	if previousErr != nil {
+		return resp, nil
+	}
+	return previousResp, nil
+}
+
+func isMiss(resp any) bool {
+	switch resp.(type) {
+	case *responses.GetMiss, *responses.GetWithHashMiss, *responses.ItemGetTypeMiss, *responses.ItemGetTtlMiss,
+		*responses.SortedSetFetchMiss, *responses.SortedSetGetScoreMiss, *responses.SortedSetGetScoresMiss,
+		*responses.SortedSetGetRankMiss, *responses.SortedSetLengthMiss, *responses.SortedSetLengthByScoreMiss,
+		*responses.SetFetchMiss, *responses.SetLengthMiss, *responses.SetContainsElementsMiss,
+		*responses.ListFetchMiss, *responses.ListLengthMiss,
+		*responses.DictionaryFetchMiss, *responses.DictionaryLengthMiss,
+		*responses.DictionaryGetFieldMiss, *responses.DictionaryGetFieldsMiss:
+		return true
+	default:
+		return false
+	}
+}
+
+// sendParts sends the parts of a batch concurrently. The results are in the order of the parts, and the
+// error is that of the first part that failed.
+func sendParts[T any](parts []shardPart, send func(part shardPart) (T, error)) ([]T, error) {
+	results := make([]T, len(parts))
+	errs := make([]error, len(parts))
+	var wg sync.WaitGroup
+	for i, part := range parts {
+		wg.Add(1)
+		go func(i int, part shardPart) {
+			defer wg.Done()
+			results[i], errs[i] = send(part)
+		}(i, part)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+func (c *shardedCacheClient) CreateCache(ctx context.Context, r *CreateCacheRequest) (responses.CreateCacheResponse, error) {
+	return c.props.Client.CreateCache(ctx, r)
+}
+
+func (c *shardedCacheClient) DeleteCache(ctx context.Context, r *DeleteCacheRequest) (responses.DeleteCacheResponse, error) {
+	return c.props.Client.DeleteCache(ctx, r)
+}
+
+func (c *shardedCacheClient) ListCaches(ctx context.Context, r *ListCachesRequest) (responses.ListCachesResponse, error) {
+	return c.props.Client.ListCaches(ctx, r)
+}
+
+// Delete removes the item from its cache and, while resharding, from the cache that stored it before.
+func (c *shardedCacheClient) Delete(ctx context.Context, r *DeleteRequest) (responses.DeleteResponse, error) {
+	rings := c.rings.Load()
+	key := shardKey(r.Key)
+	cache := rings.current.shard(key)
+	request := *r
+	request.CacheName = cache
+	resp, err := c.props.Client.Delete(ctx, &request)
+	if err != nil || rings.previous == nil {
+		return resp, err
+	}
+	if previous := rings.previous.shard(key); previous != cache {
+		previousRequest := *r
+		previousRequest.CacheName = previous
+		if _, err := c.props.Client.Delete(ctx, &previousRequest); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// GetBatch gets the items from each cache that stores some of them and merges the results in the order of
+// the keys.
+func (c *shardedCacheClient) GetBatch(ctx context.Context, r *GetBatchRequest) (responses.GetBatchResponse, error) {
+	rings := c.rings.Load()
+	keys := make([][]byte, len(r.Keys))
+	for i, key := range r.Keys {
+		keys[i] = shardKey(key)
+	}
+	results := make([]responses.GetResponse, len(r.Keys))
+	if err := c.getBatchFrom(ctx, r, rings.current, keys, allIndexes(len(keys)), results); err != nil {
+		return nil, err
+	}
+	if rings.previous != nil {
+		var missed []int
+		for i, result := range results {
+			if _, ok := result.(*responses.GetMiss); ok && rings.previous.shard(keys[i]) != rings.current.shard(keys[i]) {
+				missed = append(missed, i)
+			}
+		}
+		if len(missed) > 0 {
+			previous := make([]responses.GetResponse, len(r.Keys))
+			if err := c.getBatchFrom(ctx, r, rings.previous, keys, missed, previous); err == nil {
+				for _, i := range missed {
+					results[i] = previous[i]
+				}
+			}
+		}
+	}
+	return *responses.NewGetBatchSuccess(results, keys), nil
+}
+
+// getBatchFrom gets the items at the given indexes of a batch from the caches of a ring into results.
+func (c *shardedCacheClient) getBatchFrom(
+	ctx context.Context, r *GetBatchRequest, ring *hashRing, keys [][]byte, indexes []int, results []responses.GetResponse,
+) error {
+	subset := make([][]byte, len(indexes))
+	for i, index := range indexes {
+		subset[i] = keys[index]
+	}
+	parts := ring.split(subset)
+	partResults, err := sendParts(parts, func(part shardPart) ([]responses.GetResponse, error) {
+		request := GetBatchRequest{CacheName: part.cache, Keys: make([]Value, len(part.indexes))}
+		for i, index := range part.indexes {
+			request.Keys[i] = r.Keys[indexes[index]]
+		}
+		resp, err := c.props.Client.GetBatch(ctx, &request)
+		if err != nil {
+			return nil, err
+		}
+		// The client returns values, but other implementations may return pointers.
+		switch resp := resp.(type) {
+		case responses.GetBatchSuccess:
+			return resp.Results(), nil
+		case *responses.GetBatchSuccess:
+			return resp.Results(), nil
+		default:
+			return nil, NewMomentoError(UnknownServiceError, fmt.Sprintf("unexpected GetBatch response %T", resp), nil)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	for p, part := range parts {
+		for i, index := range part.indexes {
+			results[indexes[index]] = partResults[p][i]
+		}
+	}
+	return nil
+}
+
+// SetBatch sets the items in the caches that store them and merges the results in the order of the items.
+func (c *shardedCacheClient) SetBatch(ctx context.Context, r *SetBatchRequest) (responses.SetBatchResponse, error) {
+	ring := c.rings.Load().current
+	keys := make([][]byte, len(r.Items))
+	for i, item := range r.Items {
+		keys[i] = shardKey(item.Key)
+	}
+	parts := ring.split(keys)
+	partResults, err := sendParts(parts, func(part shardPart) ([]responses.SetResponse, error) {
+		request := *r
+		request.CacheName = part.cache
+		request.Items = make([]BatchSetItem, len(part.indexes))
+		for i, index := range part.indexes {
+			request.Items[i] = r.Items[index]
+		}
+		resp, err := c.props.Client.SetBatch(ctx, &request)
+		if err != nil {
+			return nil, err
+		}
+		// The client returns values, but other implementations may return pointers.
+		switch resp := resp.(type) {
+		case responses.SetBatchSuccess:
+			return resp.Results(), nil
+		case *responses.SetBatchSuccess:
+			return resp.Results(), nil
+		default:
+			return nil, NewMomentoError(UnknownServiceError, fmt.Sprintf("unexpected SetBatch response %T", resp), nil)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]responses.SetResponse, len(r.Items))
+	for p, part := range parts {
+		for i, index := range part.indexes {
+			results[index] = partResults[p][i]
+		}
+	}
+	return *responses.NewSetBatchSuccess(results), nil
+}
+
+// KeysExist checks each cache that stores some of the keys and merges the results in the order of the keys.
+func (c *shardedCacheClient) KeysExist(ctx context.Context, r *KeysExistRequest) (responses.KeysExistResponse, error) {
+	rings := c.rings.Load()
+	keys := make([][]byte, len(r.Keys))
+	for i, key := range r.Keys {
+		keys[i] = shardKey(key)
+	}
+	exists := make([]bool, len(r.Keys))
+	if err := c.keysExistIn(ctx, r, rings.current, keys, allIndexes(len(keys)), exists); err != nil {
+		return nil, err
+	}
+	if rings.previous != nil {
+		var missed []int
+		for i, found := range exists {
+			if !found && rings.previous.shard(keys[i]) != rings.current.shard(keys[i]) {
+				missed = append(missed, i)
+			}
+		}
+		if len(missed) > 0 {
+			// A failure leaves the keys reported missing, as they are in the new shards.
+			_ = c.keysExistIn(ctx, r, rings.previous, keys, missed, exists)
+		}
+	}
+	return responses.NewKeysExistSuccess(exists), nil
+}
+
+// keysExistIn checks whether the keys at the given indexes of a batch exist in the caches of a ring.
+func (c *shardedCacheClient) keysExistIn(
+	ctx context.Context, r *KeysExistRequest, ring *hashRing, keys [][]byte, indexes []int, exists []bool,
+) error {
+	subset := make([][]byte, len(indexes))
+	for i, index := range indexes {
+		subset[i] = keys[index]
+	}
+	parts := ring.split(subset)
+	partResults, err := sendParts(parts, func(part shardPart) ([]bool, error) {
+		request := KeysExistRequest{CacheName: part.cache, Keys: make([]Key, len(part.indexes))}
+		for i, index := range part.indexes {
+			request.Keys[i] = r.Keys[indexes[index]]
+		}
+		resp, err := c.props.Client.KeysExist(ctx, &request)
+		if err != nil {
+			return nil, err
+		}
+		switch resp := resp.(type) {
+		case *responses.KeysExistSuccess:
+			return resp.Exists(), nil
+		default:
+			return nil, NewMomentoError(UnknownServiceError, fmt.Sprintf("unexpected KeysExist response %T", resp), nil)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	for p, part := range parts {
+		for i, index := range part.indexes {
+			exists[indexes[index]] = partResults[p][i]
+		}
+	}
+	return nil
+}
+
+func (c *shardedCacheClient) Increment(ctx context.Context, r *IncrementRequest) (responses.IncrementResponse, error) {
+	return shardMutation(c, shardKey(r.Field), func(cache string) (responses.IncrementResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.Increment(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) Set(ctx context.Context, r *SetRequest) (responses.SetResponse, error) {
+	return shardRequest(c, shardKey(r.Key), func(cache string) (responses.SetResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.Set(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetIfNotExists(ctx context.Context, r *SetIfNotExistsRequest) (responses.SetIfNotExistsResponse, error) {
+	return shardMutation(c, shardKey(r.Key), func(cache string) (responses.SetIfNotExistsResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetIfNotExists(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetIfAbsent(ctx context.Context, r *SetIfAbsentRequest) (responses.SetIfAbsentResponse, error) {
+	return shardMutation(c, shardKey(r.Key), func(cache string) (responses.SetIfAbsentResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetIfAbsent(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetIfPresent(ctx context.Context, r *SetIfPresentRequest) (responses.SetIfPresentResponse, error) {
+	return shardMutation(c, shardKey(r.Key), func(cache string) (responses.SetIfPresentResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetIfPresent(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetIfPresentAndNotEqual(ctx context.Context, r *SetIfPresentAndNotEqualRequest) (responses.SetIfPresentAndNotEqualResponse, error) {
+	return shardMutation(c, shardKey(r.Key), func(cache string) (responses.SetIfPresentAndNotEqualResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetIfPresentAndNotEqual(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetIfEqual(ctx context.Context, r *SetIfEqualRequest) (responses.SetIfEqualResponse, error) {
+	return shardMutation(c, shardKey(r.Key), func(cache string) (responses.SetIfEqualResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetIfEqual(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetIfAbsentOrEqual(ctx context.Context, r *SetIfAbsentOrEqualRequest) (responses.SetIfAbsentOrEqualResponse, error) {
+	return shardMutation(c, shardKey(r.Key), func(cache string) (responses.SetIfAbsentOrEqualResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetIfAbsentOrEqual(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetIfNotEqual(ctx context.Context, r *SetIfNotEqualRequest) (responses.SetIfNotEqualResponse, error) {
+	return shardMutation(c, shardKey(r.Key), func(cache string) (responses.SetIfNotEqualResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetIfNotEqual(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetIfPresentAndHashNotEqual(ctx context.Context, r *SetIfPresentAndHashNotEqualRequest) (responses.SetIfPresentAndHashNotEqualResponse, error) {
+	return shardMutation(c, shardKey(r.Key), func(cache string) (responses.SetIfPresentAndHashNotEqualResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetIfPresentAndHashNotEqual(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetIfPresentAndHashEqual(ctx context.Context, r *SetIfPresentAndHashEqualRequest) (responses.SetIfPresentAndHashEqualResponse, error) {
+	return shardMutation(c, shardKey(r.Key), func(cache string) (responses.SetIfPresentAndHashEqualResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetIfPresentAndHashEqual(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetIfAbsentOrHashEqual(ctx context.Context, r *SetIfAbsentOrHashEqualRequest) (responses.SetIfAbsentOrHashEqualResponse, error) {
+	return shardMutation(c, shardKey(r.Key), func(cache string) (responses.SetIfAbsentOrHashEqualResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetIfAbsentOrHashEqual(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetIfAbsentOrHashNotEqual(ctx context.Context, r *SetIfAbsentOrHashNotEqualRequest) (responses.SetIfAbsentOrHashNotEqualResponse, error) {
+	return shardMutation(c, shardKey(r.Key), func(cache string) (responses.SetIfAbsentOrHashNotEqualResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetIfAbsentOrHashNotEqual(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetWithHash(ctx context.Context, r *SetWithHashRequest) (responses.SetWithHashResponse, error) {
+	return shardRequest(c, shardKey(r.Key), func(cache string) (responses.SetWithHashResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetWithHash(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) Get(ctx context.Context, r *GetRequest) (responses.GetResponse, error) {
+	return shardRead(c, shardKey(r.Key), func(cache string) (responses.GetResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.Get(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) GetWithHash(ctx context.Context, r *GetWithHashRequest) (responses.GetWithHashResponse, error) {
+	return shardRead(c, shardKey(r.Key), func(cache string) (responses.GetWithHashResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.GetWithHash(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) ItemGetType(ctx context.Context, r *ItemGetTypeRequest) (responses.ItemGetTypeResponse, error) {
+	return shardRead(c, shardKey(r.Key), func(cache string) (responses.ItemGetTypeResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.ItemGetType(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) ItemGetTtl(ctx context.Context, r *ItemGetTtlRequest) (responses.ItemGetTtlResponse, error) {
+	return shardRead(c, shardKey(r.Key), func(cache string) (responses.ItemGetTtlResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.ItemGetTtl(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SortedSetFetchByRank(ctx context.Context, r *SortedSetFetchByRankRequest) (responses.SortedSetFetchResponse, error) {
+	return shardRead(c, []byte(r.SetName), func(cache string) (responses.SortedSetFetchResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SortedSetFetchByRank(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SortedSetFetchByScore(ctx context.Context, r *SortedSetFetchByScoreRequest) (responses.SortedSetFetchResponse, error) {
+	return shardRead(c, []byte(r.SetName), func(cache string) (responses.SortedSetFetchResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SortedSetFetchByScore(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SortedSetPutElement(ctx context.Context, r *SortedSetPutElementRequest) (responses.SortedSetPutElementResponse, error) {
+	return shardMutation(c, []byte(r.SetName), func(cache string) (responses.SortedSetPutElementResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SortedSetPutElement(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SortedSetPutElements(ctx context.Context, r *SortedSetPutElementsRequest) (responses.SortedSetPutElementsResponse, error) {
+	return shardMutation(c, []byte(r.SetName), func(cache string) (responses.SortedSetPutElementsResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SortedSetPutElements(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SortedSetGetScore(ctx context.Context, r *SortedSetGetScoreRequest) (responses.SortedSetGetScoreResponse, error) {
+	return shardRead(c, []byte(r.SetName), func(cache string) (responses.SortedSetGetScoreResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SortedSetGetScore(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SortedSetGetScores(ctx context.Context, r *SortedSetGetScoresRequest) (responses.SortedSetGetScoresResponse, error) {
+	return shardRead(c, []byte(r.SetName), func(cache string) (responses.SortedSetGetScoresResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SortedSetGetScores(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SortedSetRemoveElement(ctx context.Context, r *SortedSetRemoveElementRequest) (responses.SortedSetRemoveElementResponse, error) {
+	return shardMutation(c, []byte(r.SetName), func(cache string) (responses.SortedSetRemoveElementResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SortedSetRemoveElement(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SortedSetRemoveElements(ctx context.Context, r *SortedSetRemoveElementsRequest) (responses.SortedSetRemoveElementsResponse, error) {
+	return shardMutation(c, []byte(r.SetName), func(cache string) (responses.SortedSetRemoveElementsResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SortedSetRemoveElements(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SortedSetGetRank(ctx context.Context, r *SortedSetGetRankRequest) (responses.SortedSetGetRankResponse, error) {
+	return shardRead(c, []byte(r.SetName), func(cache string) (responses.SortedSetGetRankResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SortedSetGetRank(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SortedSetLength(ctx context.Context, r *SortedSetLengthRequest) (responses.SortedSetLengthResponse, error) {
+	return shardRead(c, []byte(r.SetName), func(cache string) (responses.SortedSetLengthResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SortedSetLength(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SortedSetLengthByScore(ctx context.Context, r *SortedSetLengthByScoreRequest) (responses.SortedSetLengthByScoreResponse, error) {
+	return shardRead(c, []byte(r.SetName), func(cache string) (responses.SortedSetLengthByScoreResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SortedSetLengthByScore(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SortedSetIncrementScore(ctx context.Context, r *SortedSetIncrementScoreRequest) (responses.SortedSetIncrementScoreResponse, error) {
+	return shardMutation(c, []byte(r.SetName), func(cache string) (responses.SortedSetIncrementScoreResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SortedSetIncrementScore(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetAddElement(ctx context.Context, r *SetAddElementRequest) (responses.SetAddElementResponse, error) {
+	return shardMutation(c, []byte(r.SetName), func(cache string) (responses.SetAddElementResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetAddElement(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetAddElements(ctx context.Context, r *SetAddElementsRequest) (responses.SetAddElementsResponse, error) {
+	return shardMutation(c, []byte(r.SetName), func(cache string) (responses.SetAddElementsResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetAddElements(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetFetch(ctx context.Context, r *SetFetchRequest) (responses.SetFetchResponse, error) {
+	return shardRead(c, []byte(r.SetName), func(cache string) (responses.SetFetchResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetFetch(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetLength(ctx context.Context, r *SetLengthRequest) (responses.SetLengthResponse, error) {
+	return shardRead(c, []byte(r.SetName), func(cache string) (responses.SetLengthResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetLength(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetRemoveElement(ctx context.Context, r *SetRemoveElementRequest) (responses.SetRemoveElementResponse, error) {
+	return shardMutation(c, []byte(r.SetName), func(cache string) (responses.SetRemoveElementResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetRemoveElement(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetRemoveElements(ctx context.Context, r *SetRemoveElementsRequest) (responses.SetRemoveElementsResponse, error) {
+	return shardMutation(c, []byte(r.SetName), func(cache string) (responses.SetRemoveElementsResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetRemoveElements(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetContainsElements(ctx context.Context, r *SetContainsElementsRequest) (responses.SetContainsElementsResponse, error) {
+	return shardRead(c, []byte(r.SetName), func(cache string) (responses.SetContainsElementsResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetContainsElements(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) SetPop(ctx context.Context, r *SetPopRequest) (responses.SetPopResponse, error) {
+	return shardMutation(c, []byte(r.SetName), func(cache string) (responses.SetPopResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.SetPop(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) ListPushFront(ctx context.Context, r *ListPushFrontRequest) (responses.ListPushFrontResponse, error) {
+	return shardMutation(c, []byte(r.ListName), func(cache string) (responses.ListPushFrontResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.ListPushFront(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) ListPushBack(ctx context.Context, r *ListPushBackRequest) (responses.ListPushBackResponse, error) {
+	return shardMutation(c, []byte(r.ListName), func(cache string) (responses.ListPushBackResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.ListPushBack(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) ListPopFront(ctx context.Context, r *ListPopFrontRequest) (responses.ListPopFrontResponse, error) {
+	return shardMutation(c, []byte(r.ListName), func(cache string) (responses.ListPopFrontResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.ListPopFront(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) ListPopBack(ctx context.Context, r *ListPopBackRequest) (responses.ListPopBackResponse, error) {
+	return shardMutation(c, []byte(r.ListName), func(cache string) (responses.ListPopBackResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.ListPopBack(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) ListConcatenateFront(ctx context.Context, r *ListConcatenateFrontRequest) (responses.ListConcatenateFrontResponse, error) {
+	return shardMutation(c, []byte(r.ListName), func(cache string) (responses.ListConcatenateFrontResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.ListConcatenateFront(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) ListConcatenateBack(ctx context.Context, r *ListConcatenateBackRequest) (responses.ListConcatenateBackResponse, error) {
+	return shardMutation(c, []byte(r.ListName), func(cache string) (responses.ListConcatenateBackResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.ListConcatenateBack(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) ListFetch(ctx context.Context, r *ListFetchRequest) (responses.ListFetchResponse, error) {
+	return shardRead(c, []byte(r.ListName), func(cache string) (responses.ListFetchResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.ListFetch(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) ListLength(ctx context.Context, r *ListLengthRequest) (responses.ListLengthResponse, error) {
+	return shardRead(c, []byte(r.ListName), func(cache string) (responses.ListLengthResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.ListLength(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) ListRemoveValue(ctx context.Context, r *ListRemoveValueRequest) (responses.ListRemoveValueResponse, error) {
+	return shardMutation(c, []byte(r.ListName), func(cache string) (responses.ListRemoveValueResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.ListRemoveValue(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) DictionarySetField(ctx context.Context, r *DictionarySetFieldRequest) (responses.DictionarySetFieldResponse, error) {
+	return shardMutation(c, []byte(r.DictionaryName), func(cache string) (responses.DictionarySetFieldResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.DictionarySetField(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) DictionarySetFields(ctx context.Context, r *DictionarySetFieldsRequest) (responses.DictionarySetFieldsResponse, error) {
+	return shardMutation(c, []byte(r.DictionaryName), func(cache string) (responses.DictionarySetFieldsResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.DictionarySetFields(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) DictionaryFetch(ctx context.Context, r *DictionaryFetchRequest) (responses.DictionaryFetchResponse, error) {
+	return shardRead(c, []byte(r.DictionaryName), func(cache string) (responses.DictionaryFetchResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.DictionaryFetch(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) DictionaryLength(ctx context.Context, r *DictionaryLengthRequest) (responses.DictionaryLengthResponse, error) {
+	return shardRead(c, []byte(r.DictionaryName), func(cache string) (responses.DictionaryLengthResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.DictionaryLength(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) DictionaryGetField(ctx context.Context, r *DictionaryGetFieldRequest) (responses.DictionaryGetFieldResponse, error) {
+	return shardRead(c, []byte(r.DictionaryName), func(cache string) (responses.DictionaryGetFieldResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.DictionaryGetField(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) DictionaryGetFields(ctx context.Context, r *DictionaryGetFieldsRequest) (responses.DictionaryGetFieldsResponse, error) {
+	return shardRead(c, []byte(r.DictionaryName), func(cache string) (responses.DictionaryGetFieldsResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.DictionaryGetFields(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) DictionaryIncrement(ctx context.Context, r *DictionaryIncrementRequest) (responses.DictionaryIncrementResponse, error) {
+	return shardMutation(c, []byte(r.DictionaryName), func(cache string) (responses.DictionaryIncrementResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.DictionaryIncrement(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) DictionaryRemoveField(ctx context.Context, r *DictionaryRemoveFieldRequest) (responses.DictionaryRemoveFieldResponse, error) {
+	return shardMutation(c, []byte(r.DictionaryName), func(cache string) (responses.DictionaryRemoveFieldResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.DictionaryRemoveField(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) DictionaryRemoveFields(ctx context.Context, r *DictionaryRemoveFieldsRequest) (responses.DictionaryRemoveFieldsResponse, error) {
+	return shardMutation(c, []byte(r.DictionaryName), func(cache string) (responses.DictionaryRemoveFieldsResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.DictionaryRemoveFields(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) UpdateTtl(ctx context.Context, r *UpdateTtlRequest) (responses.UpdateTtlResponse, error) {
+	return shardMutation(c, shardKey(r.Key), func(cache string) (responses.UpdateTtlResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.UpdateTtl(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) IncreaseTtl(ctx context.Context, r *IncreaseTtlRequest) (responses.IncreaseTtlResponse, error) {
+	return shardMutation(c, shardKey(r.Key), func(cache string) (responses.IncreaseTtlResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.IncreaseTtl(ctx, &request)
+	})
+}
+
+func (c *shardedCacheClient) DecreaseTtl(ctx context.Context, r *DecreaseTtlRequest) (responses.DecreaseTtlResponse, error) {
+	return shardMutation(c, shardKey(r.Key), func(cache string) (responses.DecreaseTtlResponse, error) {
+		request := *r
+		request.CacheName = cache
+		return c.props.Client.DecreaseTtl(ctx, &request)
+	})
+}
+
+func allIndexes(n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	return indexes
+}
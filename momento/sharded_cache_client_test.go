@@ -0,0 +1,340 @@
+package momento_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/momentohq/client-sdk-go/momento"
+	"github.com/momentohq/client-sdk-go/responses"
+)
+
+// fakeShardedClient is a CacheClient that keeps the items of each cache in memory. Only the methods used
+// by the tests are implemented.
+type fakeShardedClient struct {
+	CacheClient
+	mutex  sync.Mutex
+	caches map[string]map[string]string
+	closed bool
+	// keysExistResponse, if set, answers every KeysExist request.
+	keysExistResponse responses.KeysExistResponse
+}
+
+// unexpectedKeysExistResponse is a KeysExist response that is not a KeysExistSuccess.
+type unexpectedKeysExistResponse struct {
+	responses.KeysExistResponse
+}
+
+func newFakeShardedClient() *fakeShardedClient {
+	return &fakeShardedClient{caches: map[string]map[string]string{}}
+}
+
+func (f *fakeShardedClient) items(cache string) map[string]string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	items := map[string]string{}
+	for key, value := range f.caches[cache] {
+		items[key] = value
+	}
+	return items
+}
+
+func (f *fakeShardedClient) set(cache string, key string, value string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.caches[cache] == nil {
+		f.caches[cache] = map[string]string{}
+	}
+	f.caches[cache][key] = value
+}
+
+func (f *fakeShardedClient) get(cache string, key string) (string, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	value, ok := f.caches[cache][key]
+	return value, ok
+}
+
+func (f *fakeShardedClient) Get(ctx context.Context, r *GetRequest) (responses.GetResponse, error) {
+	value, ok := f.get(r.CacheName, string(r.Key.(String)))
+	if !ok {
+		return &responses.GetMiss{}, nil
+	}
+	return responses.NewGetHit([]byte(value)), nil
+}
+
+func (f *fakeShardedClient) Set(ctx context.Context, r *SetRequest) (responses.SetResponse, error) {
+	f.set(r.CacheName, string(r.Key.(String)), string(r.Value.(String)))
+	return &responses.SetSuccess{}, nil
+}
+
+func (f *fakeShardedClient) Delete(ctx context.Context, r *DeleteRequest) (responses.DeleteResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	delete(f.caches[r.CacheName], string(r.Key.(String)))
+	return &responses.DeleteSuccess{}, nil
+}
+
+func (f *fakeShardedClient) DictionarySetField(ctx context.Context, r *DictionarySetFieldRequest) (responses.DictionarySetFieldResponse, error) {
+	f.set(r.CacheName, r.DictionaryName+"/"+string(r.Field.(String)), string(r.Value.(String)))
+	return &responses.DictionarySetFieldSuccess{}, nil
+}
+
+func (f *fakeShardedClient) Increment(ctx context.Context, r *IncrementRequest) (responses.IncrementResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.caches[r.CacheName] == nil {
+		f.caches[r.CacheName] = map[string]string{}
+	}
+	value, _ := strconv.ParseInt(f.caches[r.CacheName][string(r.Field.(String))], 10, 64)
+	value += r.Amount
+	f.caches[r.CacheName][string(r.Field.(String))] = strconv.FormatInt(value, 10)
+	return responses.NewIncrementSuccess(value), nil
+}
+
+func (f *fakeShardedClient) GetBatch(ctx context.Context, r *GetBatchRequest) (responses.GetBatchResponse, error) {
+	var results []responses.GetResponse
+	var keys [][]byte
+	for _, key := range r.Keys {
+		resp, _ := f.Get(ctx, &GetRequest{CacheName: r.CacheName, Key: key})
+		results = append(results, resp)
+		keys = append(keys, []byte(key.(String)))
+	}
+	return *responses.NewGetBatchSuccess(results, keys), nil
+}
+
+func (f *fakeShardedClient) SetBatch(ctx context.Context, r *SetBatchRequest) (responses.SetBatchResponse, error) {
+	var results []responses.SetResponse
+	for _, item := range r.Items {
+		resp, _ := f.Set(ctx, &SetRequest{CacheName: r.CacheName, Key: item.Key, Value: item.Value})
+		results = append(results, resp)
+	}
+	return *responses.NewSetBatchSuccess(results), nil
+}
+
+func (f *fakeShardedClient) KeysExist(ctx context.Context, r *KeysExistRequest) (responses.KeysExistResponse, error) {
+	if f.keysExistResponse != nil {
+		return f.keysExistResponse, nil
+	}
+	var exists []bool
+	for _, key := range r.Keys {
+		_, ok := f.get(r.CacheName, string(key.(String)))
+		exists = append(exists, ok)
+	}
+	return responses.NewKeysExistSuccess(exists), nil
+}
+
+func (f *fakeShardedClient) Close() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.closed = true
+}
+
+var _ = Describe("sharded-cache-client", func() {
+	var (
+		fake   *fakeShardedClient
+		client ShardedCacheClient
+		ctx    context.Context
+	)
+	caches := []string{"shard-a", "shard-b", "shard-c"}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fake = newFakeShardedClient()
+		var err error
+		client, err = NewShardedCacheClient(ShardedCacheClientProps{Client: fake, Caches: caches})
+		Expect(err).To(BeNil())
+	})
+
+	keyNames := func(n int) []string {
+		keys := make([]string, n)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("key-%d", i)
+		}
+		return keys
+	}
+
+	It("rejects invalid props", func() {
+		_, err := NewShardedCacheClient(ShardedCacheClientProps{Caches: caches})
+		Expect(err).To(HaveMomentoErrorCode(InvalidArgumentError))
+		_, err = NewShardedCacheClient(ShardedCacheClientProps{Client: fake})
+		Expect(err).To(HaveMomentoErrorCode(InvalidArgumentError))
+		_, err = NewShardedCacheClient(ShardedCacheClientProps{Client: fake, Caches: []string{"a", "a"}})
+		Expect(err).To(HaveMomentoErrorCode(InvalidArgumentError))
+		_, err = NewShardedCacheClient(ShardedCacheClientProps{Client: fake, Caches: []string{"a", " "}})
+		Expect(err).To(HaveMomentoErrorCode(InvalidArgumentError))
+	})
+
+	It("stores each item in the cache its key hashes to", func() {
+		for _, key := range keyNames(300) {
+			request := &SetRequest{CacheName: "ignored", Key: String(key), Value: String("value-" + key)}
+			_, err := client.Set(ctx, request)
+			Expect(err).To(BeNil())
+			Expect(request.CacheName).To(Equal("ignored"))
+		}
+		for _, cache := range caches {
+			items := fake.items(cache)
+			Expect(len(items)).To(BeNumerically(">", 50))
+			for key := range items {
+				Expect(client.ShardFor(String(key))).To(Equal(cache))
+			}
+		}
+		resp, err := client.Get(ctx, &GetRequest{Key: String("key-7")})
+		Expect(err).To(BeNil())
+		Expect(resp).To(BeAssignableToTypeOf(&responses.GetHit{}))
+		Expect(resp.(*responses.GetHit).ValueString()).To(Equal("value-key-7"))
+	})
+
+	It("stores a collection in the cache its name hashes to", func() {
+		for _, field := range []string{"a", "b", "c", "d"} {
+			_, err := client.DictionarySetField(ctx, &DictionarySetFieldRequest{
+				DictionaryName: "my-dictionary", Field: String(field), Value: String(field),
+			})
+			Expect(err).To(BeNil())
+		}
+		Expect(fake.items(client.ShardFor(String("my-dictionary")))).To(HaveLen(4))
+	})
+
+	It("moves only the keys that hash to an added cache", func() {
+		keys := keyNames(1000)
+		before := map[string]string{}
+		for _, key := range keys {
+			before[key] = client.ShardFor(String(key))
+		}
+		Expect(client.BeginResharding(append(caches, "shard-d"))).To(Succeed())
+		moved := 0
+		for _, key := range keys {
+			if shard := client.ShardFor(String(key)); shard != before[key] {
+				Expect(shard).To(Equal("shard-d"))
+				moved++
+			}
+		}
+		Expect(moved).To(BeNumerically("~", 250, 75))
+	})
+
+	It("updates items that stay in their cache while resharding", func() {
+		before := client.ShardFor(String("key-0"))
+		Expect(client.BeginResharding(append(caches, "shard-d"))).To(Succeed())
+		Expect(client.ShardFor(String("key-0"))).To(Equal(before))
+
+		resp, err := client.Increment(ctx, &IncrementRequest{Field: String("key-0"), Amount: 2})
+		Expect(err).To(BeNil())
+		Expect(resp.(*responses.IncrementSuccess).Value()).To(Equal(int64(2)))
+		Expect(fake.items(before)).To(HaveKeyWithValue("key-0", "2"))
+	})
+
+	It("splits batches across caches and merges the results in order", func() {
+		keys := keyNames(50)
+		var items []BatchSetItem
+		var values []Value
+		for _, key := range keys {
+			items = append(items, BatchSetItem{Key: String(key), Value: String("value-" + key)})
+			values = append(values, String(key))
+		}
+		setResp, err := client.SetBatch(ctx, &SetBatchRequest{Items: items})
+		Expect(err).To(BeNil())
+		Expect(setResp.(responses.SetBatchSuccess).Results()).To(HaveLen(50))
+		for _, cache := range caches {
+			Expect(fake.items(cache)).ToNot(BeEmpty())
+		}
+
+		getResp, err := client.GetBatch(ctx, &GetBatchRequest{Keys: append(values, String("missing"))})
+		Expect(err).To(BeNil())
+		results := getResp.(responses.GetBatchSuccess).Results()
+		Expect(results).To(HaveLen(51))
+		for i, key := range keys {
+			Expect(results[i].(*responses.GetHit).ValueString()).To(Equal("value-" + key))
+		}
+		Expect(results[50]).To(BeAssignableToTypeOf(&responses.GetMiss{}))
+
+		existResp, err := client.KeysExist(ctx, &KeysExistRequest{Keys: []Key{String("missing"), String("key-3"), String("key-4")}})
+		Expect(err).To(BeNil())
+		Expect(existResp.(*responses.KeysExistSuccess).Exists()).To(Equal([]bool{false, true, true}))
+	})
+
+	It("fails batches whose responses it does not recognize", func() {
+		fake.keysExistResponse = unexpectedKeysExistResponse{}
+		_, err := client.KeysExist(ctx, &KeysExistRequest{Keys: []Key{String("key-1"), String("key-2")}})
+		Expect(err).To(HaveMomentoErrorCode(UnknownServiceError))
+	})
+
+	Describe("resharding", func() {
+		// Every key moves, since the new set of caches has none of the old ones.
+		movedKey := "key-1"
+
+		BeforeEach(func() {
+			for _, key := range keyNames(100) {
+				_, err := client.Set(ctx, &SetRequest{Key: String(key), Value: String("old")})
+				Expect(err).To(BeNil())
+			}
+			Expect(client.BeginResharding([]string{"shard-d"})).To(Succeed())
+		})
+
+		It("reads items from their previous cache until resharding finishes", func() {
+			resp, err := client.Get(ctx, &GetRequest{Key: String(movedKey)})
+			Expect(err).To(BeNil())
+			Expect(resp.(*responses.GetHit).ValueString()).To(Equal("old"))
+
+			batchResp, err := client.GetBatch(ctx, &GetBatchRequest{Keys: []Value{String("key-1"), String("key-2"), String("missing")}})
+			Expect(err).To(BeNil())
+			results := batchResp.(responses.GetBatchSuccess).Results()
+			Expect(results[0]).To(BeAssignableToTypeOf(&responses.GetHit{}))
+			Expect(results[1]).To(BeAssignableToTypeOf(&responses.GetHit{}))
+			Expect(results[2]).To(BeAssignableToTypeOf(&responses.GetMiss{}))
+
+			existResp, err := client.KeysExist(ctx, &KeysExistRequest{Keys: []Key{String("key-1"), String("missing")}})
+			Expect(err).To(BeNil())
+			Expect(existResp.(*responses.KeysExistSuccess).Exists()).To(Equal([]bool{true, false}))
+
+			Expect(client.FinishResharding()).To(Succeed())
+			resp, err = client.Get(ctx, &GetRequest{Key: String(movedKey)})
+			Expect(err).To(BeNil())
+			Expect(resp).To(BeAssignableToTypeOf(&responses.GetMiss{}))
+		})
+
+		It("writes to the new caches and deletes from both", func() {
+			_, err := client.Set(ctx, &SetRequest{Key: String(movedKey), Value: String("new")})
+			Expect(err).To(BeNil())
+			Expect(fake.items("shard-d")).To(HaveKeyWithValue(movedKey, "new"))
+			resp, err := client.Get(ctx, &GetRequest{Key: String(movedKey)})
+			Expect(err).To(BeNil())
+			Expect(resp.(*responses.GetHit).ValueString()).To(Equal("new"))
+
+			_, err = client.Delete(ctx, &DeleteRequest{Key: String(movedKey)})
+			Expect(err).To(BeNil())
+			resp, err = client.Get(ctx, &GetRequest{Key: String(movedKey)})
+			Expect(err).To(BeNil())
+			Expect(resp).To(BeAssignableToTypeOf(&responses.GetMiss{}))
+		})
+
+		It("rejects updates of items that are moving to another cache", func() {
+			_, err := client.Increment(ctx, &IncrementRequest{Field: String(movedKey), Amount: 1})
+			Expect(err).To(HaveMomentoErrorCode(FailedPreconditionError))
+			_, err = client.DictionarySetField(ctx, &DictionarySetFieldRequest{
+				DictionaryName: movedKey, Field: String("field"), Value: String("value"),
+			})
+			Expect(err).To(HaveMomentoErrorCode(FailedPreconditionError))
+			Expect(fake.items("shard-d")).To(BeEmpty())
+
+			Expect(client.FinishResharding()).To(Succeed())
+			resp, err := client.Increment(ctx, &IncrementRequest{Field: String(movedKey), Amount: 1})
+			Expect(err).To(BeNil())
+			Expect(resp.(*responses.IncrementSuccess).Value()).To(Equal(int64(1)))
+		})
+
+		It("allows one resharding at a time", func() {
+			Expect(client.BeginResharding(caches)).To(HaveMomentoErrorCode(FailedPreconditionError))
+			Expect(client.FinishResharding()).To(Succeed())
+			Expect(client.FinishResharding()).To(HaveMomentoErrorCode(FailedPreconditionError))
+		})
+	})
+
+	It("closes the underlying client", func() {
+		client.Close()
+		Expect(fake.closed).To(BeTrue())
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"github.com/momentohq/client-sdk-go/auth"
 	"github.com/momentohq/client-sdk-go/config"
 	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/internal/grpcmanagers/channelpool"
 	"github.com/momentohq/client-sdk-go/internal/models"
 	"github.com/momentohq/client-sdk-go/internal/momentoerrors"
 	"github.com/momentohq/client-sdk-go/internal/services"
@@ -44,6 +45,7 @@ type defaultPreviewStorageClient struct {
 	controlClient      *services.ScsControlClient
 	storageDataClients []*storageDataClient
 	logger             logger.MomentoLogger
+	operations         *operationTracker
 }
 
 // NewPreviewStorageClient creates a new PreviewStorageClient with the provided configuration and credential provider.
@@ -51,12 +53,18 @@ type defaultPreviewStorageClient struct {
 // WARNING: the API for this client is not yet stable and may change without notice.
 // Please contact Momento if you would like to try this preview.
 func NewPreviewStorageClient(storageConfiguration config.StorageConfiguration, credentialProvider auth.CredentialProvider) (PreviewStorageClient, error) {
+	return newPreviewStorageClient(storageConfiguration, credentialProvider, nil)
+}
+
+// newPreviewStorageClient creates a storage client whose connections are leased from channels, if it is not nil.
+func newPreviewStorageClient(storageConfiguration config.StorageConfiguration, credentialProvider auth.CredentialProvider, channels *channelpool.Pool) (PreviewStorageClient, error) {
 	if storageConfiguration.GetClientSideTimeout() < 1 {
 		return nil, NewMomentoError(momentoerrors.InvalidArgumentError, "request timeout must be greater than 0", nil)
 	}
 	client := &defaultPreviewStorageClient{
 		credentialProvider: credentialProvider,
 		logger:             storageConfiguration.GetLoggerFactory().GetLogger("store-client"),
+		operations:         newOperationTracker("storage client"),
 	}
 
 	controlConfig := config.NewCacheConfiguration(&config.ConfigurationProps{
@@ -66,6 +74,7 @@ func NewPreviewStorageClient(storageConfiguration config.StorageConfiguration, c
 	controlClient, err := services.NewScsControlClient(&models.ControlClientRequest{
 		CredentialProvider: credentialProvider,
 		Configuration:      controlConfig,
+		Channels:           channels.NewGroup(),
 	})
 	if err != nil {
 		return nil, convertMomentoSvcErrorToCustomerError(err)
@@ -76,11 +85,13 @@ func NewPreviewStorageClient(storageConfiguration config.StorageConfiguration, c
 		numChannels = 1
 	}
 	dataClients := make([]*storageDataClient, 0)
+	dataChannels := channels.NewGroup()
 
 	for i := uint32(0); i < numChannels; i++ {
 		storeDataClient, err := newStorageDataClient(&models.StorageDataClientRequest{
 			CredentialProvider: credentialProvider,
 			Configuration:      storageConfiguration,
+			Channels:           dataChannels,
 		})
 		if err != nil {
 			return nil, convertMomentoSvcErrorToCustomerError(err)
@@ -105,6 +116,11 @@ func (c defaultPreviewStorageClient) Logger() logger.MomentoLogger {
 }
 
 func (c defaultPreviewStorageClient) CreateStore(ctx context.Context, request *CreateStoreRequest) (responses.CreateStoreResponse, error) {
+	end, trackErr := c.operations.begin("CreateStore")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	if err := isStoreNameValid(request.StoreName); err != nil {
 		return nil, err
 	}
@@ -124,6 +140,11 @@ func (c defaultPreviewStorageClient) CreateStore(ctx context.Context, request *C
 }
 
 func (c defaultPreviewStorageClient) DeleteStore(ctx context.Context, request *DeleteStoreRequest) (responses.DeleteStoreResponse, error) {
+	end, trackErr := c.operations.begin("DeleteStore")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	if err := isStoreNameValid(request.StoreName); err != nil {
 		return nil, err
 	}
@@ -148,6 +169,11 @@ func (c defaultPreviewStorageClient) DeleteStore(ctx context.Context, request *D
 }
 
 func (c defaultPreviewStorageClient) ListStores(ctx context.Context, request *ListStoresRequest) (responses.ListStoresResponse, error) {
+	end, trackErr := c.operations.begin("ListStores")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	resp, err := c.controlClient.ListStores(ctx, &models.ListStoresRequest{})
 	if err != nil {
 		return nil, convertMomentoSvcErrorToCustomerError(err)
@@ -156,6 +182,11 @@ func (c defaultPreviewStorageClient) ListStores(ctx context.Context, request *Li
 }
 
 func (c defaultPreviewStorageClient) Delete(ctx context.Context, request *StorageDeleteRequest) (responses.StorageDeleteResponse, error) {
+	end, trackErr := c.operations.begin("StorageDelete")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	if err := isStoreNameValid(request.StoreName); err != nil {
 		return nil, err
 	}
@@ -172,6 +203,11 @@ func (c defaultPreviewStorageClient) Delete(ctx context.Context, request *Storag
 }
 
 func (c defaultPreviewStorageClient) Get(ctx context.Context, request *StorageGetRequest) (responses.StorageGetResponse, error) {
+	end, trackErr := c.operations.begin("StorageGet")
+	if trackErr != nil {
+		return *responses.NewStoreGetResponse_Nil(), trackErr
+	}
+	defer end()
 	if err := isStoreNameValid(request.StoreName); err != nil {
 		return *responses.NewStoreGetResponse_Nil(), err
 	}
@@ -190,6 +226,11 @@ func (c defaultPreviewStorageClient) Get(ctx context.Context, request *StorageGe
 }
 
 func (c defaultPreviewStorageClient) Put(ctx context.Context, request *StoragePutRequest) (responses.StoragePutResponse, error) {
+	end, trackErr := c.operations.begin("StoragePut")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
 	if err := isStoreNameValid(request.StoreName); err != nil {
 		return nil, err
 	}
@@ -210,7 +251,18 @@ func (c defaultPreviewStorageClient) Put(ctx context.Context, request *StoragePu
 	return resp, nil
 }
 
+func (c defaultPreviewStorageClient) Shutdown(ctx context.Context) (ShutdownSummary, error) {
+	c.logger.Info("Shutting down storage client")
+	summary, err := c.operations.shutdown(ctx)
+	if err != nil {
+		c.logger.Warn("Storage client shut down before its requests finished: %s", summary)
+	}
+	c.Close()
+	return summary, err
+}
+
 func (c defaultPreviewStorageClient) Close() {
+	c.operations.reject()
 	for _, dataClient := range c.storageDataClients {
 		dataClient.Close()
 	}
@@ -26,6 +26,7 @@ func newStorageDataClient(request *models.StorageDataClientRequest) (*storageDat
 	dataManager, err := grpcmanagers.NewStoreGrpcManager(&models.StoreGrpcManagerRequest{
 		CredentialProvider: request.CredentialProvider,
 		GrpcConfiguration:  request.Configuration.GetTransportStrategy().GetGrpcConfig(),
+		Channels:           request.Channels,
 	})
 	if err != nil {
 		return nil, err
@@ -39,7 +40,7 @@ func newStorageDataClient(request *models.StorageDataClientRequest) (*storageDat
 
 	return &storageDataClient{
 		grpcManager:    dataManager,
-		grpcClient:     pb.NewStoreClient(dataManager.Conn),
+		grpcClient:     pb.NewStoreClient(dataManager.Channel),
 		requestTimeout: timeout,
 		endpoint:       request.CredentialProvider.GetStorageEndpoint(),
 	}, nil
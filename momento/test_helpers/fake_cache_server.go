@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+
+	"github.com/momentohq/client-sdk-go/auth"
+	pb "github.com/momentohq/client-sdk-go/internal/protos"
+)
+
+// FakeCacheServerProps configures how a FakeCacheServer answers requests. Requests without a handler fail
+// with codes.Unimplemented.
+type FakeCacheServerProps struct {
+	// OnGet answers Get requests. It is called concurrently.
+	OnGet func(ctx context.Context, req *pb.XGetRequest) (*pb.XGetResponse, error)
+	// OnGetBatch answers GetBatch requests with a response for each item. It is called concurrently.
+	OnGetBatch func(ctx context.Context, req *pb.XGetBatchRequest) ([]*pb.XGetResponse, error)
+}
+
+// FakeCacheServer is an in-process cache service for tests that need to control how requests are
+// answered, for instance to delay or fail them.
+type FakeCacheServer struct {
+	pb.UnimplementedScsServer
+	props    FakeCacheServerProps
+	server   *grpc.Server
+	listener net.Listener
+}
+
+// NewFakeCacheServer starts a FakeCacheServer on a free local port.
+func NewFakeCacheServer(props FakeCacheServerProps) (*FakeCacheServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &FakeCacheServer{props: props, server: grpc.NewServer(), listener: listener}
+	pb.RegisterScsServer(s.server, s)
+	go func() {
+		_ = s.server.Serve(listener)
+	}()
+	return s, nil
+}
+
+// CredentialProvider returns a credential provider that connects to the server without TLS.
+func (s *FakeCacheServer) CredentialProvider() (auth.CredentialProvider, error) {
+	return auth.NewMomentoLocalProvider(&auth.MomentoLocalConfig{
+		Port: uint(s.listener.Addr().(*net.TCPAddr).Port),
+	})
+}
+
+func (s *FakeCacheServer) Get(ctx context.Context, req *pb.XGetRequest) (*pb.XGetResponse, error) {
+	if s.props.OnGet == nil {
+		return s.UnimplementedScsServer.Get(ctx, req)
+	}
+	return s.props.OnGet(ctx, req)
+}
+
+func (s *FakeCacheServer) GetBatch(req *pb.XGetBatchRequest, stream pb.Scs_GetBatchServer) error {
+	if s.props.OnGetBatch == nil {
+		return s.UnimplementedScsServer.GetBatch(req, stream)
+	}
+	results, err := s.props.OnGetBatch(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if err := stream.Send(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close stops the server, cancelling the requests it is still answering.
+func (s *FakeCacheServer) Close() {
+	s.server.Stop()
+}
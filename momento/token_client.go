@@ -22,17 +22,20 @@ type tokenClient struct {
 }
 
 func newTokenClient(request *models.TokenClientRequest) (*tokenClient, momentoerrors.MomentoSvcErr) {
-	// NOTE: This is hard-coded for now but we may want to expose it via TopicConfiguration in the future,
-	// as we do with some of the other clients. Defaults to keep-alive pings enabled.
-	grpcConfig := config.NewStaticGrpcConfiguration(&config.GrpcConfigurationProps{})
+	grpcConfig := request.GrpcConfiguration
+	if grpcConfig == nil {
+		// Defaults to keep-alive pings enabled.
+		grpcConfig = config.NewStaticGrpcConfiguration(&config.GrpcConfigurationProps{})
+	}
 	tokenManager, err := grpcmanagers.NewTokenGrpcManager(&models.TokenGrpcManagerRequest{
 		CredentialProvider: request.CredentialProvider,
 		GrpcConfiguration:  grpcConfig,
+		Channels:           request.Channels,
 	})
 	if err != nil {
 		return nil, momentoerrors.ConvertSvcErr(err)
 	}
-	return &tokenClient{grpcManager: tokenManager, grpcClient: pb.NewTokenClient(tokenManager.Conn)}, nil
+	return &tokenClient{grpcManager: tokenManager, grpcClient: pb.NewTokenClient(tokenManager.Channel)}, nil
 }
 
 func (client *tokenClient) close() {
@@ -269,7 +272,7 @@ func (client *tokenClient) GenerateDisposableToken(ctx context.Context, request
 
 	var header, trailer metadata.MD
 	resp, err := client.grpcClient.GenerateDisposableToken(ctx, &pb.XGenerateDisposableTokenRequest{
-		AuthToken: client.grpcManager.AuthToken,
+		AuthToken: client.grpcManager.CredentialProvider.GetAuthToken(),
 		Expires: &pb.XGenerateDisposableTokenRequest_Expires{
 			ValidForSeconds: uint32(request.ExpiresIn.Seconds()),
 		},
@@ -15,6 +15,7 @@ import (
 	"github.com/momentohq/client-sdk-go/auth"
 	"github.com/momentohq/client-sdk-go/config"
 	"github.com/momentohq/client-sdk-go/config/logger"
+	"github.com/momentohq/client-sdk-go/internal/grpcmanagers/channelpool"
 	"github.com/momentohq/client-sdk-go/internal/models"
 	"github.com/momentohq/client-sdk-go/internal/momentoerrors"
 	pb "github.com/momentohq/client-sdk-go/internal/protos"
@@ -35,10 +36,16 @@ type defaultTopicClient struct {
 	log                logger.MomentoLogger
 	requestTimeout     time.Duration
 	retryStrategy      retry.Strategy
+	operations         *operationTracker
 }
 
 // NewTopicClient returns a new TopicClient with provided configuration and credential provider arguments.
 func NewTopicClient(topicsConfiguration config.TopicsConfiguration, credentialProvider auth.CredentialProvider) (TopicClient, error) {
+	return newTopicClient(topicsConfiguration, credentialProvider, nil)
+}
+
+// newTopicClient creates a topic client whose unary connections are leased from channels, if it is not nil.
+func newTopicClient(topicsConfiguration config.TopicsConfiguration, credentialProvider auth.CredentialProvider, channels *channelpool.Pool) (TopicClient, error) {
 	var timeout time.Duration
 	if topicsConfiguration.GetClientSideTimeout() < 1 {
 		timeout = defaultRequestTimeout
@@ -51,12 +58,14 @@ func NewTopicClient(topicsConfiguration config.TopicsConfiguration, credentialPr
 		log:                topicsConfiguration.GetLoggerFactory().GetLogger("topic-client"),
 		requestTimeout:     timeout,
 		retryStrategy:      topicsConfiguration.GetRetryStrategy(),
+		operations:         newOperationTracker("topic client"),
 	}
 
 	pubSubClient, err := newPubSubClient(&models.PubSubClientRequest{
 		CredentialProvider:  credentialProvider,
 		TopicsConfiguration: topicsConfiguration,
 		Log:                 client.log,
+		ChannelPool:         channels,
 	})
 	if err != nil {
 		return nil, convertMomentoSvcErrorToCustomerError(momentoerrors.ConvertSvcErr(err))
@@ -76,6 +85,22 @@ func (c defaultTopicClient) Subscribe(ctx context.Context, request *TopicSubscri
 		return nil, err
 	}
 
+	end, trackErr := c.operations.begin("Subscribe")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
+
+	topicName, err := c.pubSubClient.applyTopicNameMiddleware(ctx, request.CacheName, request.TopicName)
+	if err != nil {
+		return nil, err
+	}
+	if topicName != request.TopicName {
+		renamed := *request
+		renamed.TopicName = topicName
+		request = &renamed
+	}
+
 	// Set a timeout by which the first heartbeat message should be received.
 	// If the first message is not received within this time, we will cancel the subscription.
 	firstMessageCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
@@ -100,7 +125,9 @@ func (c defaultTopicClient) Subscribe(ctx context.Context, request *TopicSubscri
 			nil,
 		)
 	case subscription := <-subChan:
-		return &subscription, nil
+		sub := &subscription
+		sub.untrack = c.operations.track(sub.Close)
+		return sub, nil
 	case err := <-errChan:
 		return nil, err
 	}
@@ -197,9 +224,20 @@ func (c defaultTopicClient) Publish(ctx context.Context, request *TopicPublishRe
 		)
 	}
 
-	err := c.pubSubClient.topicPublish(ctx, &TopicPublishRequest{
+	end, trackErr := c.operations.begin("Publish")
+	if trackErr != nil {
+		return nil, trackErr
+	}
+	defer end()
+
+	topicName, err := c.pubSubClient.applyTopicNameMiddleware(ctx, request.CacheName, request.TopicName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.pubSubClient.topicPublish(ctx, &TopicPublishRequest{
 		CacheName: request.CacheName,
-		TopicName: request.TopicName,
+		TopicName: topicName,
 		Value:     request.Value,
 	})
 
@@ -211,6 +249,18 @@ func (c defaultTopicClient) Publish(ctx context.Context, request *TopicPublishRe
 	return &responses.TopicPublishSuccess{}, err
 }
 
+// Shutdown also closes the client's open subscriptions, while publishes in flight may finish.
+func (c defaultTopicClient) Shutdown(ctx context.Context) (ShutdownSummary, error) {
+	c.log.Info("Shutting down topic client")
+	summary, err := c.operations.shutdown(ctx)
+	if err != nil {
+		c.log.Warn("Topic client shut down before its requests finished: %s", summary)
+	}
+	c.Close()
+	return summary, err
+}
+
 func (c defaultTopicClient) Close() {
+	c.operations.reject()
 	defer c.pubSubClient.close()
 }
@@ -3,12 +3,15 @@ package momento_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/momentohq/client-sdk-go/config/compression"
+	"github.com/momentohq/client-sdk-go/config/middleware/impl"
 	. "github.com/momentohq/client-sdk-go/momento"
 )
 
@@ -98,6 +101,54 @@ var _ = Describe("topic-client", Label(TOPICS_SERVICE_LABEL), func() {
 		Expect(receivedValues).To(Equal(publishedValues))
 	})
 
+	It("Publishes and receives with a compression middleware", func() {
+		compressionMiddleware := impl.NewGzipTopicCompressionMiddleware(impl.GzipTopicCompressionMiddlewareProps{
+			CompressionStrategyProps: compression.CompressionStrategyProps{
+				CompressionLevel: compression.CompressionLevelDefault,
+			},
+		})
+		compressingClient, err := NewTopicClient(
+			sharedContext.TopicConfiguration.AddMiddleware(compressionMiddleware), sharedContext.CredentialProvider,
+		)
+		if err != nil {
+			panic(err)
+		}
+		defer compressingClient.Close()
+
+		publishedValues := []TopicValue{
+			String(strings.Repeat("compressible ", 100)),
+			Bytes([]byte{1, 2, 3}),
+		}
+
+		sub, err := compressingClient.Subscribe(sharedContext.Ctx, &TopicSubscribeRequest{
+			CacheName: sharedContext.CacheName,
+			TopicName: topicName,
+		})
+		if err != nil {
+			panic(err)
+		}
+		defer sub.Close()
+
+		for _, value := range publishedValues {
+			_, err := compressingClient.Publish(sharedContext.Ctx, &TopicPublishRequest{
+				CacheName: sharedContext.CacheName,
+				TopicName: topicName,
+				Value:     value,
+			})
+			if err != nil {
+				panic(err)
+			}
+		}
+
+		var receivedValues []TopicValue
+		for range publishedValues {
+			value, err := sub.Item(sharedContext.Ctx)
+			Expect(err).To(BeNil())
+			receivedValues = append(receivedValues, value)
+		}
+		Expect(receivedValues).To(Equal(publishedValues))
+	})
+
 	It("should error on deadline exceeded", func() {
 		newGrpcConfig := sharedContext.TopicConfiguration.GetTransportStrategy().GetGrpcConfig()
 		newCfg := sharedContext.TopicConfiguration.WithTransportStrategy(
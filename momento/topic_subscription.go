@@ -76,6 +76,8 @@ type topicSubscription struct {
 	cancelContext           context.Context
 	cancelFunction          context.CancelFunc
 	retryStrategy           retry.Strategy
+	// untrack removes the subscription from those its client closes when it shuts down.
+	untrack func()
 }
 
 func (s *topicSubscription) Item(ctx context.Context) (TopicValue, error) {
@@ -186,12 +188,23 @@ func (s *topicSubscription) Event(ctx context.Context) (TopicEvent, error) {
 				s.lastKnownSequenceNumber, s.lastKnownSequencePage, publisherId,
 			)
 
+			var payload middleware.TopicPayload
 			switch subscriptionItem := typedMsg.Item.Value.Kind.(type) {
 			case *pb.XTopicValue_Text:
-				return NewTopicItem(String(subscriptionItem.Text), String(publisherId), s.lastKnownSequenceNumber, s.lastKnownSequencePage), nil
+				payload = middleware.TopicPayload{Data: []byte(subscriptionItem.Text), IsText: true}
 			case *pb.XTopicValue_Binary:
-				return NewTopicItem(Bytes(subscriptionItem.Binary), String(publisherId), s.lastKnownSequenceNumber, s.lastKnownSequencePage), nil
+				payload = middleware.TopicPayload{Data: subscriptionItem.Binary}
+			default:
+				continue
+			}
+			payload, err = s.momentoTopicClient.applySubscriptionPayloadMiddleware(s.cacheName, s.topicName, payload)
+			if err != nil {
+				return nil, err
 			}
+			if payload.IsText {
+				return NewTopicItem(String(payload.Data), String(publisherId), s.lastKnownSequenceNumber, s.lastKnownSequencePage), nil
+			}
+			return NewTopicItem(Bytes(payload.Data), String(publisherId), s.lastKnownSequenceNumber, s.lastKnownSequencePage), nil
 		case *pb.XSubscriptionItem_Heartbeat:
 			s.log.Trace("received heartbeat item")
 			s.onTopicEvent(methodName, middleware.HEARTBEAT)
@@ -265,4 +278,7 @@ func (s *topicSubscription) attemptReconnect(ctx context.Context, err error) err
 // to avoid double counting.
 func (s *topicSubscription) Close() {
 	s.cancelFunction()
+	if s.untrack != nil {
+		s.untrack()
+	}
 }